@@ -52,16 +52,64 @@ func main() {
 
 	db := mongoClient.Database("loterias")
 	resultadoRepo := repository.NewResultadoRepository(db)
+	bolaoRepo := repository.NewBolaoRepository(db)
 	consumerService := service.NewConsumer()
 	defer consumerService.CloseBrowser() // Garantir que browser seja fechado
 	resultadoService := service.NewResultadoService(resultadoRepo)
-	loteriasUpdate := service.NewLoteriasUpdate(consumerService, resultadoService)
+	bolaoService := service.NewBolaoService(bolaoRepo, resultadoService)
+	loteriasUpdate := service.NewLoteriasUpdate(consumerService, resultadoService, bolaoService)
+	conferenciaService := service.NewConferenciaService(resultadoService)
+	estatisticaService := service.NewEstatisticaService(resultadoRepo)
+	geradorService := service.NewGeradorService(resultadoRepo)
+	ganhadorService := service.NewGanhadorService(resultadoRepo)
+	backtestService := service.NewBacktestService(resultadoRepo)
+
+	go func() {
+		if err := resultadoService.CriarIndices(); err != nil {
+			log.Printf("Erro ao criar os índices dos resultados: %v", err)
+		}
+
+		migrados, err := resultadoService.MigrarSorteios()
+		if err != nil {
+			log.Printf("Erro ao migrar sorteios da Dupla Sena: %v", err)
+		} else if migrados > 0 {
+			log.Printf("✓ %d resultados da Dupla Sena migrados para sorteios separados", migrados)
+		}
+
+		datas, err := resultadoService.MigrarDatas()
+		if err != nil {
+			log.Printf("Erro ao migrar datas dos resultados: %v", err)
+		} else if datas > 0 {
+			log.Printf("✓ %d resultados migrados para datas convertidas", datas)
+		}
+
+		premios, err := resultadoService.MigrarMaiorPremio()
+		if err != nil {
+			log.Printf("Erro ao migrar o maior prêmio dos resultados: %v", err)
+		} else if premios > 0 {
+			log.Printf("✓ %d resultados com o maior prêmio pago", premios)
+		}
+
+		especiais, err := resultadoService.MigrarEspeciais()
+		if err != nil {
+			log.Printf("Erro ao marcar concursos especiais: %v", err)
+		} else if especiais > 0 {
+			log.Printf("✓ %d resultados marcados como concurso especial ou regular", especiais)
+		}
+
+		composicoes, err := resultadoService.MigrarComposicao()
+		if err != nil {
+			log.Printf("Erro ao calcular a composição dos resultados: %v", err)
+		} else if composicoes > 0 {
+			log.Printf("✓ %d resultados com a composição calculada", composicoes)
+		}
+	}()
 
 	schedulerLoteria := scheduler.NewScheduledConsumer(loteriasUpdate)
 	schedulerLoteria.Start()
 	defer schedulerLoteria.Stop()
 
-	router := setupRouter(resultadoService, loteriasUpdate)
+	router := setupRouter(resultadoService, conferenciaService, estatisticaService, geradorService, ganhadorService, backtestService, bolaoService, loteriasUpdate)
 
 	port := getEnv("PORT", "9050")
 	log.Printf("Starting server on port %s", port)
@@ -101,7 +149,7 @@ func connectMongoDB() *mongo.Client {
 	return client
 }
 
-func setupRouter(resultadoService *service.ResultadoService, loteriasUpdate *service.LoteriasUpdate) *gin.Engine {
+func setupRouter(resultadoService *service.ResultadoService, conferenciaService *service.ConferenciaService, estatisticaService *service.EstatisticaService, geradorService *service.GeradorService, ganhadorService *service.GanhadorService, backtestService *service.BacktestService, bolaoService *service.BolaoService, loteriasUpdate *service.LoteriasUpdate) *gin.Engine {
 	ginMode := getEnv("GIN_MODE", "debug")
 	gin.SetMode(ginMode)
 
@@ -115,12 +163,56 @@ func setupRouter(resultadoService *service.ResultadoService, loteriasUpdate *ser
 	router.GET("/", rootController.Root)
 
 	apiController := controller.NewApiController(resultadoService)
+	conferenciaController := controller.NewConferenciaController(conferenciaService)
+	estatisticaController := controller.NewEstatisticaController(estatisticaService)
+	geradorController := controller.NewGeradorController(geradorService)
+	ganhadorController := controller.NewGanhadorController(ganhadorService)
+	backtestController := controller.NewBacktestController(backtestService)
+	bolaoController := controller.NewBolaoController(bolaoService)
 	api := router.Group("/api")
 	{
 		api.GET("", apiController.GetLotteries)
 		api.GET("/:loteria", apiController.GetResultsByLottery)
+		api.GET("/sorteios/:data", apiController.GetResultsByDate)
+		api.GET("/proximos", apiController.GetUpcoming)
+		api.GET("/ranking/premios", ganhadorController.GetRankingPremios)
+		api.GET("/calendario.ics", apiController.GetCalendar)
+		api.GET("/:loteria/calendario.ics", apiController.GetLotteryCalendar)
 		api.GET("/:loteria/:concurso", apiController.GetResultByID)
 		api.GET("/:loteria/latest", apiController.GetLatestResult)
+		api.GET("/:loteria/regras", apiController.GetRules)
+		api.GET("/:loteria/busca", apiController.SearchByNumbers)
+		api.GET("/:loteria/especiais", apiController.GetSpecialContests)
+		api.GET("/:loteria/especiais/reserva", apiController.GetSpecialReserve)
+		api.POST("/:loteria/:concurso/conferir", conferenciaController.ConferirAposta)
+		api.POST("/:loteria/conferir-historico", conferenciaController.ConferirHistorico)
+		api.POST("/:loteria/backtest", backtestController.Executar)
+		api.GET("/:loteria/:concurso/bilhete", conferenciaController.ConferirBilhete)
+		api.GET("/:loteria/estatisticas/frequencia", estatisticaController.GetFrequencia)
+		api.GET("/:loteria/estatisticas/atraso", estatisticaController.GetAtraso)
+		api.GET("/:loteria/estatisticas/combinacoes", estatisticaController.GetCoocorrencia)
+		api.GET("/:loteria/estatisticas/acumulacoes", estatisticaController.GetAcumulacoes)
+		api.GET("/:loteria/estatisticas/composicao", estatisticaController.GetComposicao)
+		api.GET("/:loteria/estatisticas/aleatoriedade", estatisticaController.GetAleatoriedade)
+		api.GET("/:loteria/series", estatisticaController.GetSerie)
+		api.GET("/:loteria/probabilidades", estatisticaController.GetProbabilidades)
+		api.POST("/:loteria/gerar", geradorController.GerarApostas)
+		api.POST("/:loteria/desdobramento", geradorController.GerarDesdobramento)
+		api.GET("/:loteria/ganhadores", ganhadorController.ListarGanhadores)
+		api.GET("/:loteria/ganhadores/estados", ganhadorController.GetRankingEstados)
+		api.GET("/:loteria/ganhadores/municipios", ganhadorController.GetRankingMunicipios)
+
+		api.GET("/boloes", bolaoController.ListarBoloes)
+		api.POST("/boloes", bolaoController.CriarBolao)
+		api.GET("/boloes/:id", bolaoController.GetBolao)
+		api.PUT("/boloes/:id", bolaoController.AtualizarBolao)
+		api.DELETE("/boloes/:id", bolaoController.ExcluirBolao)
+		api.POST("/boloes/:id/participantes", bolaoController.AdicionarParticipante)
+		api.PUT("/boloes/:id/participantes/:participanteId", bolaoController.AtualizarParticipante)
+		api.DELETE("/boloes/:id/participantes/:participanteId", bolaoController.RemoverParticipante)
+		api.POST("/boloes/:id/bilhetes", bolaoController.AdicionarBilhete)
+		api.DELETE("/boloes/:id/bilhetes/:bilheteId", bolaoController.RemoverBilhete)
+		api.GET("/boloes/:id/relatorio", bolaoController.GetRelatorio)
 	}
 
 	// Endpoint administrativo para forçar atualização
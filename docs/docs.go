@@ -45,35 +45,22 @@ const docTemplate = `{
                 }
             }
         },
-        "/{loteria}": {
+        "/boloes": {
             "get": {
-                "description": "Retorna todos os resultados já realizados da loteria especificada",
+                "description": "Retorna os bolões cadastrados, do mais recente para o mais antigo, opcionalmente filtrados por loteria.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Loterias"
+                    "Bolões"
                 ],
-                "summary": "Busca resultados por loteria",
+                "summary": "Lista bolões",
                 "parameters": [
                     {
-                        "enum": [
-                            "maismilionaria",
-                            "megasena",
-                            "lotofacil",
-                            "quina",
-                            "lotomania",
-                            "timemania",
-                            "duplasena",
-                            "federal",
-                            "diadesorte",
-                            "supersete"
-                        ],
                         "type": "string",
                         "description": "ID da Loteria",
                         "name": "loteria",
-                        "in": "path",
-                        "required": true
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -82,7 +69,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/model.Resultado"
+                                "$ref": "#/definitions/model.Bolao"
                             }
                         }
                     },
@@ -93,35 +80,61 @@ const docTemplate = `{
                         }
                     }
                 }
+            },
+            "post": {
+                "description": "Cadastra um bolão para uma loteria e faixa de concursos. Participantes podem ser enviados já na criação; cada um tem 1 cota se nenhuma for informada.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bolões"
+                ],
+                "summary": "Cria um bolão",
+                "parameters": [
+                    {
+                        "description": "Nome, loteria, faixa de concursos e participantes",
+                        "name": "bolao",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Bolao"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.Bolao"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
             }
         },
-        "/{loteria}/latest": {
+        "/boloes/{id}": {
             "get": {
-                "description": "Retorna o resultado mais recente da loteria especificada",
+                "description": "Retorna o bolão, seus participantes e os bilhetes com a conferência de cada concurso já apurado.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Loterias"
+                    "Bolões"
                 ],
-                "summary": "Busca resultado mais recente",
+                "summary": "Busca um bolão",
                 "parameters": [
                     {
-                        "enum": [
-                            "maismilionaria",
-                            "megasena",
-                            "lotofacil",
-                            "quina",
-                            "lotomania",
-                            "timemania",
-                            "duplasena",
-                            "federal",
-                            "diadesorte",
-                            "supersete"
-                        ],
                         "type": "string",
-                        "description": "ID da Loteria",
-                        "name": "loteria",
+                        "description": "ID do bolão",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -130,8 +143,86 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Resultado"
+                            "$ref": "#/definitions/model.BolaoDetalhado"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Altera o nome e a faixa de concursos do bolão. Os bilhetes são conferidos novamente quando a faixa muda. A loteria não pode ser alterada.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bolões"
+                ],
+                "summary": "Atualiza um bolão",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do bolão",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Nome e faixa de concursos",
+                        "name": "bolao",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Bolao"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Bolao"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
                         }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove o bolão e todos os seus bilhetes.",
+                "tags": [
+                    "Bolões"
+                ],
+                "summary": "Exclui um bolão",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do bolão",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
                     },
                     "404": {
                         "description": "Not Found",
@@ -142,49 +233,197 @@ const docTemplate = `{
                 }
             }
         },
-        "/{loteria}/{concurso}": {
-            "get": {
-                "description": "Retorna o resultado da loteria e concurso especificado",
+        "/boloes/{id}/bilhetes": {
+            "post": {
+                "description": "Valida a aposta contra as regras da loteria do bolão e já a confere nos concursos da faixa que estão armazenados. Novos concursos são conferidos automaticamente a cada atualização.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Loterias"
+                    "Bolões"
                 ],
-                "summary": "Busca resultado por loteria e concurso",
+                "summary": "Adiciona um bilhete",
                 "parameters": [
                     {
-                        "enum": [
-                            "maismilionaria",
-                            "megasena",
-                            "lotofacil",
-                            "quina",
-                            "lotomania",
-                            "timemania",
-                            "duplasena",
-                            "federal",
-                            "diadesorte",
-                            "supersete"
-                        ],
                         "type": "string",
-                        "description": "ID da Loteria",
-                        "name": "loteria",
+                        "description": "ID do bolão",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Número do Concurso",
-                        "name": "concurso",
+                        "description": "Aposta do bilhete",
+                        "name": "aposta",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Aposta"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.BilheteBolao"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/boloes/{id}/bilhetes/{bilheteId}": {
+            "delete": {
+                "description": "Retira o bilhete e suas conferências do bolão.",
+                "tags": [
+                    "Bolões"
+                ],
+                "summary": "Remove um bilhete",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do bolão",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do bilhete",
+                        "name": "bilheteId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/boloes/{id}/participantes": {
+            "post": {
+                "description": "Inclui um participante com a quantidade de cotas informada (1 se omitida).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bolões"
+                ],
+                "summary": "Adiciona um participante",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do bolão",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Nome e cotas",
+                        "name": "participante",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Participante"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.Bolao"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/boloes/{id}/participantes/{participanteId}": {
+            "put": {
+                "description": "Altera o nome e as cotas de um participante do bolão.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bolões"
+                ],
+                "summary": "Atualiza um participante",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do bolão",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do participante",
+                        "name": "participanteId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Nome e cotas",
+                        "name": "participante",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Participante"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Resultado"
+                            "$ref": "#/definitions/model.Bolao"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
                         }
                     },
                     "404": {
@@ -194,71 +433,4224 @@ const docTemplate = `{
                         }
                     }
                 }
+            },
+            "delete": {
+                "description": "Retira o participante do bolão. As cotas restantes passam a dividir custo e prêmios.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bolões"
+                ],
+                "summary": "Remove um participante",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do bolão",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do participante",
+                        "name": "participanteId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Bolao"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/boloes/{id}/relatorio": {
+            "get": {
+                "description": "Soma o custo dos bilhetes nos concursos da faixa já apurados e os prêmios, por concurso, e divide os valores entre os participantes na proporção das cotas. Concursos ainda não sorteados não entram no custo.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bolões"
+                ],
+                "summary": "Relatório do bolão",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do bolão",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.RelatorioBolao"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/calendario.ics": {
+            "get": {
+                "description": "Calendário iCalendar (RFC 5545) com um evento por sorteio das loterias nos próximos dias. O próximo concurso de cada loteria traz o prêmio estimado; os seguintes são previstos pelos dias de sorteio do jogo.",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "Loterias"
+                ],
+                "summary": "Calendário de sorteios",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 14,
+                        "description": "Quantidade de dias cobertos (máx. 90)",
+                        "name": "dias",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/proximos": {
+            "get": {
+                "description": "Retorna, para cada loteria, o número, a data e o prêmio estimado do próximo concurso segundo o último resultado armazenado, ordenados pela data do sorteio. Loterias cujo último resultado está atrasado são marcadas como desatualizadas.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Loterias"
+                ],
+                "summary": "Próximos sorteios",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.ProximoSorteio"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ranking/premios": {
+            "get": {
+                "description": "Ranking dos maiores prêmios pagos por aposta vencedora, em todas as loterias ou em uma delas, com concurso, data, faixa, total pago e municípios dos ganhadores.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ganhadores"
+                ],
+                "summary": "Maiores prêmios pagos",
+                "parameters": [
+                    {
+                        "enum": [
+                            "maismilionaria",
+                            "megasena",
+                            "lotofacil",
+                            "quina",
+                            "lotomania",
+                            "timemania",
+                            "duplasena",
+                            "federal",
+                            "diadesorte",
+                            "supersete"
+                        ],
+                        "type": "string",
+                        "description": "ID da Loteria (todas quando omitido)",
+                        "name": "loteria",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Considerar apenas esta faixa de premiação",
+                        "name": "faixa",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Considerar apenas os sorteios deste ano",
+                        "name": "ano",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Quantidade de prêmios retornados (máx. 500)",
+                        "name": "top",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.RankingPremios"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sorteios/{data}": {
+            "get": {
+                "description": "Retorna os resultados de todas as loterias sorteadas no dia informado",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Loterias"
+                ],
+                "summary": "Busca sorteios por data",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Data do sorteio (aaaa-mm-dd ou dd-mm-aaaa)",
+                        "name": "data",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Resultado"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/{loteria}": {
+            "get": {
+                "description": "Retorna os resultados já realizados da loteria especificada, opcionalmente limitados a um intervalo de datas. Sem limit nem cursor a resposta continua sendo a lista com todos os concursos. Com limit ou cursor a resposta passa a ser uma página (model.PaginaResultados: total, limite, ordem, links proximo e anterior e os resultados); só com cursor a página tem 100 concursos. O parâmetro fields projeta apenas os campos informados (loteria e concurso vêm sempre).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Loterias"
+                ],
+                "summary": "Busca resultados por loteria",
+                "parameters": [
+                    {
+                        "enum": [
+                            "maismilionaria",
+                            "megasena",
+                            "lotofacil",
+                            "quina",
+                            "lotomania",
+                            "timemania",
+                            "duplasena",
+                            "federal",
+                            "diadesorte",
+                            "supersete"
+                        ],
+                        "type": "string",
+                        "description": "ID da Loteria",
+                        "name": "loteria",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data inicial (dd/mm/aaaa ou aaaa-mm-dd)",
+                        "name": "de",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data final (dd/mm/aaaa ou aaaa-mm-dd)",
+                        "name": "ate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Concursos por página (máx. 1000); ativa a paginação",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor retornado nos links proximo e anterior; ativa a paginação",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "desc",
+                        "description": "Ordem por concurso",
+                        "name": "ordem",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Campos retornados, separados por vírgula (ex: data,dezenas)",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Resultado"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/{loteria}/backtest": {
+            "post": {
+                "description": "Repete uma estratégia em cada concurso armazenado (ou na janela informada), em ordem de concurso, e retorna a aposta, os acertos e o saldo de cada concurso, além do custo total, prêmios, ROI e faixas atingidas. Estratégias: fixas (a mesma aposta sempre), quentes e frias (os números mais e menos sorteados nos concursos anteriores da janela) e aleatoria (reproduzível pela semente). Na Timemania e no Dia de Sorte, aposta.timeCoracao e aposta.mesSorte são obrigatórios em todas as estratégias.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Conferência"
+                ],
+                "summary": "Backtest de estratégia",
+                "parameters": [
+                    {
+                        "enum": [
+                            "maismilionaria",
+                            "megasena",
+                            "lotofacil",
+                            "quina",
+                            "lotomania",
+                            "timemania",
+                            "duplasena",
+                            "diadesorte",
+                            "supersete"
+                        ],
+                        "type": "string",
+                        "description": "ID da Loteria",
+                        "name": "loteria",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Estratégia a simular",
+                        "name": "pedido",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PedidoBacktest"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Primeiro concurso apostado",
+                        "name": "concursoInicio",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Último concurso apostado",
+                        "name": "concursoFim",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data inicial (dd/mm/aaaa ou aaaa-mm-dd)",
+                        "name": "dataInicio",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data final (dd/mm/aaaa ou aaaa-mm-dd)",
+                        "name": "dataFim",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Apostar apenas nos N concursos mais recentes",
+                        "name": "ultimos",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Backtest"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/{loteria}/busca": {
+            "get": {
+                "description": "Retorna os concursos em que saíram todos os números informados, ou ao menos min deles, do que teve mais acertos para o que teve menos. Trevos da +Milionária são buscados à parte, com seu próprio mínimo. Na Super Sete a busca é feita por coluna, no formato coluna:número.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Loterias"
+                ],
+                "summary": "Busca concursos por números",
+                "parameters": [
+                    {
+                        "enum": [
+                            "maismilionaria",
+                            "megasena",
+                            "lotofacil",
+                            "quina",
+                            "lotomania",
+                            "timemania",
+                            "duplasena",
+                            "diadesorte",
+                            "supersete"
+                        ],
+                        "type": "string",
+                        "description": "ID da Loteria",
+                        "name": "loteria",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Dezenas separadas por vírgula (ex: 04,15,33)",
+                        "name": "dezenas",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Trevos separados por vírgula (+Milionária)",
+                        "name": "trevos",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pares coluna:número separados por vírgula (Super Sete, ex: 1:5,3:7)",
+                        "name": "colunas",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Mínimo de dezenas ou colunas acertadas (padrão: todas)",
+                        "name": "min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Mínimo de trevos acertados (padrão: todos)",
+                        "name": "minTrevos",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Quantidade de concursos retornados (máx. 500)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Busca"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/{loteria}/calendario.ics": {
+            "get": {
+                "description": "Calendário iCalendar (RFC 5545) com um evento por sorteio da loteria nos próximos dias. O próximo concurso traz o prêmio estimado; os seguintes são previstos pelos dias de sorteio do jogo.",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "Loterias"
+                ],
+                "summary": "Calendário de sorteios da loteria",
+                "parameters": [
+                    {
+                        "enum": [
+                            "maismilionaria",
+                            "megasena",
+                            "lotofacil",
+                            "quina",
+                            "lotomania",
+                            "timemania",
+                            "duplasena",
+                            "federal",
+                            "diadesorte",
+                            "supersete"
+                        ],
+                        "type": "string",
+                        "description": "ID da Loteria",
+                        "name": "loteria",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 14,
+                        "description": "Quantidade de dias cobertos (máx. 90)",
+                        "name": "dias",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/{loteria}/conferir-historico": {
+            "post": {
+                "description": "Confere a mesma aposta em todos os concursos armazenados (ou na janela informada) e retorna quantas vezes cada faixa foi atingida, os concursos premiados e o total que a aposta teria recebido.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Conferência"
+                ],
+                "summary": "Confere uma aposta em todo o histórico",
+                "parameters": [
+                    {
+                        "enum": [
+                            "maismilionaria",
+                            "megasena",
+                            "lotofacil",
+                            "quina",
+                            "lotomania",
+                            "timemania",
+                            "duplasena",
+                            "diadesorte",
+                            "supersete"
+                        ],
+                        "type": "string",
+                        "description": "ID da Loteria",
+                        "name": "loteria",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Aposta a conferir",
+                        "name": "aposta",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Aposta"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Primeiro concurso considerado",
+                        "name": "concursoInicio",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Último concurso considerado",
+                        "name": "concursoFim",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data inicial (dd/mm/aaaa ou aaaa-mm-dd)",
+                        "name": "dataInicio",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data final (dd/mm/aaaa ou aaaa-mm-dd)",
+                        "name": "dataFim",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Considerar apenas os N concursos mais recentes",
+                        "name": "ultimos",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ConferenciaHistorico"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/{loteria}/desdobramento": {
+            "post": {
+                "description": "Gera um conjunto reduzido de apostas com as dezenas escolhidas que garante a quantidade de acertos pedida sempre que a condição for atendida, por exemplo uma quadra se 6 das dezenas escolhidas forem sorteadas. A busca (construção gulosa seguida de busca local) roda até o tempo máximo, e as apostas retornadas são conferidas contra todas as combinações possíveis. Pedidos com mais de 250.000 combinações a cobrir ou cuja garantia exija mais de 2.000 apostas são recusados. Na Timemania e no Dia de Sorte, timeCoracao e mesSorte são obrigatórios e repetidos em todas as apostas.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gerador"
+                ],
+                "summary": "Gera um desdobramento (fechamento)",
+                "parameters": [
+                    {
+                        "enum": [
+                            "maismilionaria",
+                            "megasena",
+                            "lotofacil",
+                            "quina",
+                            "lotomania",
+                            "timemania",
+                            "duplasena",
+                            "diadesorte"
+                        ],
+                        "type": "string",
+                        "description": "ID da Loteria",
+                        "name": "loteria",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Dezenas escolhidas, tamanho das apostas e garantia",
+                        "name": "pedido",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PedidoDesdobramento"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Desdobramento"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/{loteria}/especiais": {
+            "get": {
+                "description": "Lista os concursos especiais da loteria (Mega da Virada, Lotofácil da Independência, Quina de São João, Dupla de Páscoa), do mais recente para o mais antigo, com o prêmio pago na faixa principal. Concursos especiais não acumulam.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Loterias"
+                ],
+                "summary": "Concursos especiais",
+                "parameters": [
+                    {
+                        "enum": [
+                            "megasena",
+                            "lotofacil",
+                            "quina",
+                            "duplasena"
+                        ],
+                        "type": "string",
+                        "description": "ID da Loteria",
+                        "name": "loteria",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.ConcursoEspecial"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/{loteria}/especiais/reserva": {
+            "get": {
+                "description": "Retorna o valor já reservado para o próximo concurso especial, o último especial realizado, quantos concursos se passaram desde ele, o crescimento médio da reserva por concurso e sua evolução.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Loterias"
+                ],
+                "summary": "Reserva do próximo concurso especial",
+                "parameters": [
+                    {
+                        "enum": [
+                            "megasena",
+                            "lotofacil",
+                            "quina",
+                            "duplasena"
+                        ],
+                        "type": "string",
+                        "description": "ID da Loteria",
+                        "name": "loteria",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReservaEspecial"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/{loteria}/estatisticas/acumulacoes": {
+            "get": {
+                "description": "Analisa as sequências de concursos seguidos sem ganhador na faixa principal: a sequência atual, a maior, todas as registradas com o crescimento do prêmio e o concurso que a encerrou, e a distribuição dos tamanhos das sequências encerradas.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Estatísticas"
+                ],
+                "summary": "Sequências de acumulação",
+                "parameters": [
+                    {
+                        "enum": [
+                            "maismilionaria",
+                            "megasena",
+                            "lotofacil",
+                            "quina",
+                            "lotomania",
+                            "timemania",
+                            "duplasena",
+                            "diadesorte",
+                            "supersete"
+                        ],
+                        "type": "string",
+                        "description": "ID da Loteria",
+                        "name": "loteria",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Primeiro concurso considerado",
+                        "name": "concursoInicio",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Último concurso considerado",
+                        "name": "concursoFim",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data inicial (dd/mm/aaaa ou aaaa-mm-dd)",
+                        "name": "dataInicio",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data final (dd/mm/aaaa ou aaaa-mm-dd)",
+                        "name": "dataFim",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Considerar apenas os N concursos mais recentes",
+                        "name": "ultimos",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Acumulacoes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/{loteria}/estatisticas/aleatoriedade": {
+            "get": {
+                "description": "Aplica às dezenas dos concursos selecionados, em ordem de concurso, quatro testes estatísticos: qui-quadrado das frequências contra a distribuição uniforme, sequências acima e abaixo da mediana da soma (Wald-Wolfowitz), intervalos entre aparições de cada número contra a distribuição geométrica e correlação serial da soma. Retorna a estatística, o valor esperado e o p-valor de cada teste, além de um veredito geral com correção de Bonferroni para a quantidade de testes.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Estatísticas"
+                ],
+                "summary": "Testes de aleatoriedade",
+                "parameters": [
+                    {
+                        "enum": [
+                            "maismilionaria",
+                            "megasena",
+                            "lotofacil",
+                            "quina",
+                            "lotomania",
+                            "timemania",
+                            "duplasena",
+                            "diadesorte",
+                            "supersete"
+                        ],
+                        "type": "string",
+                        "description": "ID da Loteria",
+                        "name": "loteria",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Nível de significância dos testes (padrão: 0.05)",
+                        "name": "significancia",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Primeiro concurso considerado",
+                        "name": "concursoInicio",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Último concurso considerado",
+                        "name": "concursoFim",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data inicial (dd/mm/aaaa ou aaaa-mm-dd)",
+                        "name": "dataInicio",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data final (dd/mm/aaaa ou aaaa-mm-dd)",
+                        "name": "dataFim",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Considerar apenas os N concursos mais recentes",
+                        "name": "ultimos",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Aleatoriedade"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/{loteria}/estatisticas/atraso": {
+            "get": {
+                "description": "Retorna, para cada número, quantos concursos se passaram desde a última vez que foi sorteado, o maior atraso já registrado e o atraso médio, ordenados do mais para o menos atrasado. Os trevos da +Milionária são calculados à parte.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Estatísticas"
+                ],
+                "summary": "Atraso dos números",
+                "parameters": [
+                    {
+                        "enum": [
+                            "maismilionaria",
+                            "megasena",
+                            "lotofacil",
+                            "quina",
+                            "lotomania",
+                            "timemania",
+                            "duplasena",
+                            "diadesorte",
+                            "supersete"
+                        ],
+                        "type": "string",
+                        "description": "ID da Loteria",
+                        "name": "loteria",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Primeiro concurso considerado",
+                        "name": "concursoInicio",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Último concurso considerado",
+                        "name": "concursoFim",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data inicial (dd/mm/aaaa ou aaaa-mm-dd)",
+                        "name": "dataInicio",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data final (dd/mm/aaaa ou aaaa-mm-dd)",
+                        "name": "dataFim",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Considerar apenas os N concursos mais recentes",
+                        "name": "ultimos",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Atraso"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/{loteria}/estatisticas/combinacoes": {
+            "get": {
+                "description": "Conta quantas vezes cada par ou trio de números saiu no mesmo sorteio e retorna os mais frequentes com a quantidade esperada em sorteios uniformes. Na Dupla Sena os dois sorteios são analisados separadamente.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Estatísticas"
+                ],
+                "summary": "Pares e trios mais frequentes",
+                "parameters": [
+                    {
+                        "enum": [
+                            "maismilionaria",
+                            "megasena",
+                            "lotofacil",
+                            "quina",
+                            "lotomania",
+                            "timemania",
+                            "duplasena",
+                            "diadesorte"
+                        ],
+                        "type": "string",
+                        "description": "ID da Loteria",
+                        "name": "loteria",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 2,
+                        "description": "2 para pares, 3 para trios",
+                        "name": "tamanho",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Quantidade de combinações retornadas (máx. 500)",
+                        "name": "top",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Primeiro concurso considerado",
+                        "name": "concursoInicio",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Último concurso considerado",
+                        "name": "concursoFim",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data inicial (dd/mm/aaaa ou aaaa-mm-dd)",
+                        "name": "dataInicio",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data final (dd/mm/aaaa ou aaaa-mm-dd)",
+                        "name": "dataFim",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Considerar apenas os N concursos mais recentes",
+                        "name": "ultimos",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Coocorrencia"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/{loteria}/estatisticas/composicao": {
+            "get": {
+                "description": "Distribuição, nos concursos selecionados, dos atributos calculados para cada sorteio: soma dos números (agrupada em intervalos), pares, primos, números de Fibonacci, números seguidos, maior sequência e quantidade de números em cada década, linha e coluna do volante. A composição de cada concurso também vem no campo composicao do resultado. Na Super Sete apenas soma, pares, primos e Fibonacci se aplicam. No Dia de Sorte, cujo volante de 31 números não é uma grade regular, linhas e colunas são omitidas.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Estatísticas"
+                ],
+                "summary": "Composição dos sorteios",
+                "parameters": [
+                    {
+                        "enum": [
+                            "maismilionaria",
+                            "megasena",
+                            "lotofacil",
+                            "quina",
+                            "lotomania",
+                            "timemania",
+                            "duplasena",
+                            "diadesorte",
+                            "supersete"
+                        ],
+                        "type": "string",
+                        "description": "ID da Loteria",
+                        "name": "loteria",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tamanho dos intervalos da soma (padrão: 10)",
+                        "name": "intervalo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Primeiro concurso considerado",
+                        "name": "concursoInicio",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Último concurso considerado",
+                        "name": "concursoFim",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data inicial (dd/mm/aaaa ou aaaa-mm-dd)",
+                        "name": "dataInicio",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data final (dd/mm/aaaa ou aaaa-mm-dd)",
+                        "name": "dataFim",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Considerar apenas os N concursos mais recentes",
+                        "name": "ultimos",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.DistribuicaoComposicao"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/{loteria}/estatisticas/frequencia": {
+            "get": {
+                "description": "Retorna quantas vezes cada número foi sorteado, incluindo os que nunca saíram, ordenados do mais para o menos frequente. Na Super Sete a frequência é calculada por coluna.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Estatísticas"
+                ],
+                "summary": "Frequência dos números",
+                "parameters": [
+                    {
+                        "enum": [
+                            "maismilionaria",
+                            "megasena",
+                            "lotofacil",
+                            "quina",
+                            "lotomania",
+                            "timemania",
+                            "duplasena",
+                            "diadesorte",
+                            "supersete"
+                        ],
+                        "type": "string",
+                        "description": "ID da Loteria",
+                        "name": "loteria",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Primeiro concurso considerado",
+                        "name": "concursoInicio",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Último concurso considerado",
+                        "name": "concursoFim",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data inicial (dd/mm/aaaa ou aaaa-mm-dd)",
+                        "name": "dataInicio",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data final (dd/mm/aaaa ou aaaa-mm-dd)",
+                        "name": "dataFim",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Considerar apenas os N concursos mais recentes",
+                        "name": "ultimos",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Frequencia"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/{loteria}/ganhadores": {
+            "get": {
+                "description": "Lista, do mais recente para o mais antigo, os concursos com ganhadores na UF ou no município informados, com a faixa e o valor pago a cada ganhador. Maiúsculas e acentos são ignorados na comparação.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ganhadores"
+                ],
+                "summary": "Concursos com ganhadores na UF ou município",
+                "parameters": [
+                    {
+                        "enum": [
+                            "maismilionaria",
+                            "megasena",
+                            "lotofacil",
+                            "quina",
+                            "lotomania",
+                            "timemania",
+                            "duplasena",
+                            "federal",
+                            "diadesorte",
+                            "supersete"
+                        ],
+                        "type": "string",
+                        "description": "ID da Loteria",
+                        "name": "loteria",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UF dos ganhadores (ex.: SP)",
+                        "name": "uf",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Município dos ganhadores",
+                        "name": "municipio",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Considerar apenas esta faixa de premiação",
+                        "name": "faixa",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Quantidade de concursos retornados (máx. 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Primeiro concurso considerado",
+                        "name": "concursoInicio",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Último concurso considerado",
+                        "name": "concursoFim",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data inicial (dd/mm/aaaa ou aaaa-mm-dd)",
+                        "name": "dataInicio",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data final (dd/mm/aaaa ou aaaa-mm-dd)",
+                        "name": "dataFim",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Considerar apenas os N concursos mais recentes",
+                        "name": "ultimos",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ConcursosComGanhadores"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/{loteria}/ganhadores/estados": {
+            "get": {
+                "description": "Soma ganhadores e prêmios pagos em cada estado, separando as apostas feitas pela internet (Canal Eletrônico) das feitas em lotéricas. Ganhadores sem UF conhecida entram apenas nos totais.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ganhadores"
+                ],
+                "summary": "Ganhadores por estado",
+                "parameters": [
+                    {
+                        "enum": [
+                            "maismilionaria",
+                            "megasena",
+                            "lotofacil",
+                            "quina",
+                            "lotomania",
+                            "timemania",
+                            "duplasena",
+                            "federal",
+                            "diadesorte",
+                            "supersete"
+                        ],
+                        "type": "string",
+                        "description": "ID da Loteria",
+                        "name": "loteria",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Considerar apenas esta faixa de premiação",
+                        "name": "faixa",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ganhadores",
+                            "valor"
+                        ],
+                        "type": "string",
+                        "default": "ganhadores",
+                        "description": "Critério do ranking",
+                        "name": "ordenar",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Quantidade de estados retornados (0 para todos)",
+                        "name": "top",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Primeiro concurso considerado",
+                        "name": "concursoInicio",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Último concurso considerado",
+                        "name": "concursoFim",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data inicial (dd/mm/aaaa ou aaaa-mm-dd)",
+                        "name": "dataInicio",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data final (dd/mm/aaaa ou aaaa-mm-dd)",
+                        "name": "dataFim",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Considerar apenas os N concursos mais recentes",
+                        "name": "ultimos",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.RankingGanhadores"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/{loteria}/ganhadores/municipios": {
+            "get": {
+                "description": "Soma ganhadores e prêmios pagos em cada município, do mais para o menos premiado, separando as apostas feitas pela internet das feitas em lotéricas. Apostas do Canal Eletrônico sem município entram apenas nos totais.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ganhadores"
+                ],
+                "summary": "Ganhadores por município",
+                "parameters": [
+                    {
+                        "enum": [
+                            "maismilionaria",
+                            "megasena",
+                            "lotofacil",
+                            "quina",
+                            "lotomania",
+                            "timemania",
+                            "duplasena",
+                            "federal",
+                            "diadesorte",
+                            "supersete"
+                        ],
+                        "type": "string",
+                        "description": "ID da Loteria",
+                        "name": "loteria",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Considerar apenas os municípios desta UF",
+                        "name": "uf",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Considerar apenas esta faixa de premiação",
+                        "name": "faixa",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ganhadores",
+                            "valor"
+                        ],
+                        "type": "string",
+                        "default": "ganhadores",
+                        "description": "Critério do ranking",
+                        "name": "ordenar",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Quantidade de municípios retornados (0 para todos)",
+                        "name": "top",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Primeiro concurso considerado",
+                        "name": "concursoInicio",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Último concurso considerado",
+                        "name": "concursoFim",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data inicial (dd/mm/aaaa ou aaaa-mm-dd)",
+                        "name": "dataInicio",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data final (dd/mm/aaaa ou aaaa-mm-dd)",
+                        "name": "dataFim",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Considerar apenas os N concursos mais recentes",
+                        "name": "ultimos",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.RankingGanhadores"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/{loteria}/gerar": {
+            "post": {
+                "description": "Gera apostas válidas seguindo as regras da loteria, com filtros opcionais de dezenas fixas, dezenas excluídas, quantidade de pares, faixa de soma e apostas cujo prêmio máximo nunca saiu. Na +Milionária também sorteia os trevos, na Timemania o time do coração, entre os clubes que já saíram nos resultados, e no Dia de Sorte o mês da sorte.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gerador"
+                ],
+                "summary": "Gera apostas aleatórias",
+                "parameters": [
+                    {
+                        "enum": [
+                            "maismilionaria",
+                            "megasena",
+                            "lotofacil",
+                            "quina",
+                            "lotomania",
+                            "timemania",
+                            "duplasena",
+                            "diadesorte",
+                            "supersete"
+                        ],
+                        "type": "string",
+                        "description": "ID da Loteria",
+                        "name": "loteria",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Quantidade, tamanho e filtros das apostas",
+                        "name": "pedido",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/model.PedidoGeracao"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ApostasGeradas"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/{loteria}/latest": {
+            "get": {
+                "description": "Retorna o resultado mais recente da loteria especificada",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Loterias"
+                ],
+                "summary": "Busca resultado mais recente",
+                "parameters": [
+                    {
+                        "enum": [
+                            "maismilionaria",
+                            "megasena",
+                            "lotofacil",
+                            "quina",
+                            "lotomania",
+                            "timemania",
+                            "duplasena",
+                            "federal",
+                            "diadesorte",
+                            "supersete"
+                        ],
+                        "type": "string",
+                        "description": "ID da Loteria",
+                        "name": "loteria",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Resultado"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/{loteria}/probabilidades": {
+            "get": {
+                "description": "Calcula com inteiros exatos a chance de uma aposta com N números atingir cada faixa de premiação em um sorteio, o preço da aposta e o valor esperado. A faixa principal usa o prêmio estimado para o próximo concurso, como se houvesse um único ganhador; as demais usam a média paga por ganhador nos concursos selecionados. Na Super Sete os números são distribuídos entre as colunas o mais igualmente possível.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Estatísticas"
+                ],
+                "summary": "Probabilidades e valor esperado da aposta",
+                "parameters": [
+                    {
+                        "enum": [
+                            "maismilionaria",
+                            "megasena",
+                            "lotofacil",
+                            "quina",
+                            "lotomania",
+                            "timemania",
+                            "duplasena",
+                            "diadesorte",
+                            "supersete"
+                        ],
+                        "type": "string",
+                        "description": "ID da Loteria",
+                        "name": "loteria",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Quantidade de números da aposta (padrão: aposta simples)",
+                        "name": "numeros",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Quantidade de trevos da aposta (apenas +Milionária)",
+                        "name": "trevos",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Primeiro concurso considerado na média dos prêmios",
+                        "name": "concursoInicio",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Último concurso considerado na média dos prêmios",
+                        "name": "concursoFim",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data inicial (dd/mm/aaaa ou aaaa-mm-dd)",
+                        "name": "dataInicio",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data final (dd/mm/aaaa ou aaaa-mm-dd)",
+                        "name": "dataFim",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Considerar na média apenas os N concursos mais recentes",
+                        "name": "ultimos",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Probabilidades"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/{loteria}/regras": {
+            "get": {
+                "description": "Retorna as regras do jogo: intervalo de números, quantidade sorteada, mínimo e máximo por aposta, preços, faixas de premiação, elementos extras e dias de sorteio",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Loterias"
+                ],
+                "summary": "Regras da loteria",
+                "parameters": [
+                    {
+                        "enum": [
+                            "maismilionaria",
+                            "megasena",
+                            "lotofacil",
+                            "quina",
+                            "lotomania",
+                            "timemania",
+                            "duplasena",
+                            "federal",
+                            "diadesorte",
+                            "supersete"
+                        ],
+                        "type": "string",
+                        "description": "ID da Loteria",
+                        "name": "loteria",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Regras"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/{loteria}/series": {
+            "get": {
+                "description": "Agrupa arrecadação, prêmio acumulado ou estimativa do próximo concurso por concurso, mês ou ano, com soma, média e máximo de cada período. Concursos sem o valor informado ficam fora da soma, da média e do máximo, que ficam nulos nos períodos em que nenhum concurso tem o valor.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Estatísticas"
+                ],
+                "summary": "Série histórica de arrecadação e prêmios",
+                "parameters": [
+                    {
+                        "enum": [
+                            "maismilionaria",
+                            "megasena",
+                            "lotofacil",
+                            "quina",
+                            "lotomania",
+                            "timemania",
+                            "duplasena",
+                            "federal",
+                            "diadesorte",
+                            "supersete"
+                        ],
+                        "type": "string",
+                        "description": "ID da Loteria",
+                        "name": "loteria",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "valorArrecadado",
+                            "valorAcumuladoProximoConcurso",
+                            "valorEstimadoProximoConcurso",
+                            "valorAcumuladoConcursoEspecial",
+                            "valorAcumuladoConcurso_0_5"
+                        ],
+                        "type": "string",
+                        "description": "Valor acompanhado",
+                        "name": "campo",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "concurso",
+                            "mes",
+                            "ano"
+                        ],
+                        "type": "string",
+                        "default": "concurso",
+                        "description": "Período de agrupamento",
+                        "name": "agrupar",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Primeiro concurso considerado",
+                        "name": "concursoInicio",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Último concurso considerado",
+                        "name": "concursoFim",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data inicial (dd/mm/aaaa ou aaaa-mm-dd)",
+                        "name": "dataInicio",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data final (dd/mm/aaaa ou aaaa-mm-dd)",
+                        "name": "dataFim",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Considerar apenas os N concursos mais recentes",
+                        "name": "ultimos",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Serie"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/{loteria}/{concurso}": {
+            "get": {
+                "description": "Retorna o resultado da loteria e concurso especificado",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Loterias"
+                ],
+                "summary": "Busca resultado por loteria e concurso",
+                "parameters": [
+                    {
+                        "enum": [
+                            "maismilionaria",
+                            "megasena",
+                            "lotofacil",
+                            "quina",
+                            "lotomania",
+                            "timemania",
+                            "duplasena",
+                            "federal",
+                            "diadesorte",
+                            "supersete"
+                        ],
+                        "type": "string",
+                        "description": "ID da Loteria",
+                        "name": "loteria",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Número do Concurso",
+                        "name": "concurso",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Resultado"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/{loteria}/{concurso}/bilhete": {
+            "get": {
+                "description": "Confere número e série de um bilhete contra a extração informada e retorna todos os prêmios recebidos: prêmios principais, aproximações ao 1º prêmio e terminações (milhar, centena e dezena). O valor dos prêmios derivados só é preenchido quando consta no rateio publicado pela Caixa.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Conferência"
+                ],
+                "summary": "Confere um bilhete da Federal",
+                "parameters": [
+                    {
+                        "enum": [
+                            "federal"
+                        ],
+                        "type": "string",
+                        "description": "ID da Loteria",
+                        "name": "loteria",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Número do Concurso",
+                        "name": "concurso",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Número do bilhete (até 5 dígitos)",
+                        "name": "numero",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Série do bilhete",
+                        "name": "serie",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ConferenciaFederal"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/{loteria}/{concurso}/conferir": {
+            "post": {
+                "description": "Confere os números jogados contra o concurso informado e retorna acertos, faixas atingidas e valor dos prêmios. Para a Super Sete informe as colunas; para a +Milionária, os trevos.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Conferência"
+                ],
+                "summary": "Confere uma aposta",
+                "parameters": [
+                    {
+                        "enum": [
+                            "maismilionaria",
+                            "megasena",
+                            "lotofacil",
+                            "quina",
+                            "lotomania",
+                            "timemania",
+                            "duplasena",
+                            "diadesorte",
+                            "supersete"
+                        ],
+                        "type": "string",
+                        "description": "ID da Loteria",
+                        "name": "loteria",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Número do Concurso",
+                        "name": "concurso",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Aposta a conferir",
+                        "name": "aposta",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Aposta"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Conferencia"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "controller.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AcertosCobertura": {
+            "type": "object",
+            "properties": {
+                "acertos": {
+                    "type": "integer"
+                },
+                "combinacoes": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Acumulacoes": {
+            "type": "object",
+            "properties": {
+                "atual": {
+                    "$ref": "#/definitions/model.SequenciaAcumulacao"
+                },
+                "concursos": {
+                    "type": "integer"
+                },
+                "concursosAcumulados": {
+                    "type": "integer"
+                },
+                "distribuicao": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.DistribuicaoSequencia"
+                    }
+                },
+                "loteria": {
+                    "type": "string"
+                },
+                "maior": {
+                    "$ref": "#/definitions/model.SequenciaAcumulacao"
+                },
+                "mediaConcursos": {
+                    "type": "number"
+                },
+                "sequencias": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SequenciaAcumulacao"
+                    }
+                }
+            }
+        },
+        "model.Aleatoriedade": {
+            "type": "object",
+            "properties": {
+                "conclusao": {
+                    "type": "string"
+                },
+                "concursoFim": {
+                    "type": "integer"
+                },
+                "concursoInicio": {
+                    "type": "integer"
+                },
+                "concursos": {
+                    "type": "integer"
+                },
+                "loteria": {
+                    "type": "string"
+                },
+                "significancia": {
+                    "type": "number"
+                },
+                "sorteios": {
+                    "type": "integer"
+                },
+                "testes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TesteAleatoriedade"
+                    }
+                },
+                "veredito": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Aposta": {
+            "type": "object",
+            "properties": {
+                "colunas": {
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "dezenas": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "mesSorte": {
+                    "type": "string"
+                },
+                "timeCoracao": {
+                    "type": "string"
+                },
+                "trevos": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.ApostaGerada": {
+            "type": "object",
+            "properties": {
+                "colunas": {
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "dezenas": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "mesSorte": {
+                    "type": "string"
+                },
+                "preco": {
+                    "type": "number"
+                },
+                "timeCoracao": {
+                    "type": "string"
+                },
+                "trevos": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.ApostasGeradas": {
+            "type": "object",
+            "properties": {
+                "apostas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ApostaGerada"
+                    }
+                },
+                "loteria": {
+                    "type": "string"
+                },
+                "valorTotal": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.Atraso": {
+            "type": "object",
+            "properties": {
+                "colunas": {
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/model.AtrasoNumero"
+                        }
+                    }
+                },
+                "concursoFim": {
+                    "type": "integer"
+                },
+                "concursoInicio": {
+                    "type": "integer"
+                },
+                "concursos": {
+                    "type": "integer"
+                },
+                "dezenas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AtrasoNumero"
+                    }
+                },
+                "loteria": {
+                    "type": "string"
+                },
+                "trevos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AtrasoNumero"
+                    }
+                }
+            }
+        },
+        "model.AtrasoNumero": {
+            "type": "object",
+            "properties": {
+                "atrasoAtual": {
+                    "type": "integer"
+                },
+                "atrasoMaximo": {
+                    "type": "integer"
+                },
+                "atrasoMedio": {
+                    "type": "number"
+                },
+                "frequencia": {
+                    "type": "integer"
+                },
+                "numero": {
+                    "type": "string"
+                },
+                "ultimoConcurso": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Backtest": {
+            "type": "object",
+            "properties": {
+                "concursoFim": {
+                    "type": "integer"
+                },
+                "concursoInicio": {
+                    "type": "integer"
+                },
+                "concursos": {
+                    "type": "integer"
+                },
+                "concursosPremiados": {
+                    "type": "integer"
+                },
+                "custo": {
+                    "type": "number"
+                },
+                "distribuicaoAcertos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ContagemAcertos"
+                    }
+                },
+                "estrategia": {
+                    "type": "string"
+                },
+                "faixas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ResumoFaixa"
+                    }
+                },
+                "janela": {
+                    "type": "integer"
+                },
+                "loteria": {
+                    "type": "string"
+                },
+                "rodadas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.RodadaBacktest"
+                    }
+                },
+                "roi": {
+                    "type": "number"
+                },
+                "saldo": {
+                    "type": "number"
+                },
+                "semente": {
+                    "type": "integer"
+                },
+                "valorTotal": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.BilheteBolao": {
+            "type": "object",
+            "properties": {
+                "aposta": {
+                    "$ref": "#/definitions/model.Aposta"
+                },
+                "bolaoId": {
+                    "type": "string"
+                },
+                "conferencias": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ConferenciaBilhete"
+                    }
+                },
+                "criadoEm": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "preco": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.BilhetePremiado": {
+            "type": "object",
+            "properties": {
+                "bilhete": {
+                    "type": "string"
+                },
+                "locais": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.LocalVenda"
+                    }
+                },
+                "premio": {
+                    "type": "integer"
+                },
+                "valor": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.Bolao": {
+            "type": "object",
+            "properties": {
+                "atualizadoEm": {
+                    "type": "string"
+                },
+                "concursoFim": {
+                    "type": "integer"
+                },
+                "concursoInicio": {
+                    "type": "integer"
+                },
+                "criadoEm": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "loteria": {
+                    "type": "string"
+                },
+                "nome": {
+                    "type": "string"
+                },
+                "participantes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Participante"
+                    }
+                }
+            }
+        },
+        "model.BolaoDetalhado": {
+            "type": "object",
+            "properties": {
+                "atualizadoEm": {
+                    "type": "string"
+                },
+                "bilhetes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.BilheteBolao"
+                    }
+                },
+                "concursoFim": {
+                    "type": "integer"
+                },
+                "concursoInicio": {
+                    "type": "integer"
+                },
+                "criadoEm": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "loteria": {
+                    "type": "string"
+                },
+                "nome": {
+                    "type": "string"
+                },
+                "participantes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Participante"
+                    }
+                }
+            }
+        },
+        "model.Busca": {
+            "type": "object",
+            "properties": {
+                "colunas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ColunaBuscada"
+                    }
+                },
+                "concursos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ConcursoEncontrado"
+                    }
+                },
+                "dezenas": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "loteria": {
+                    "type": "string"
+                },
+                "min": {
+                    "type": "integer"
+                },
+                "minTrevos": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "trevos": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.CoberturaDesdobramento": {
+            "type": "object",
+            "properties": {
+                "cobertas": {
+                    "type": "integer"
+                },
+                "combinacoes": {
+                    "type": "integer"
+                },
+                "distribuicao": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AcertosCobertura"
+                    }
+                },
+                "garantida": {
+                    "type": "boolean"
+                },
+                "percentual": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.ColunaBuscada": {
+            "type": "object",
+            "properties": {
+                "coluna": {
+                    "type": "integer"
+                },
+                "numero": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CombinacaoCompleta": {
+            "type": "object",
+            "properties": {
+                "apostas": {
+                    "type": "integer"
+                },
+                "valor": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.CombinacaoFrequente": {
+            "type": "object",
+            "properties": {
+                "numeros": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "ocorrencias": {
+                    "type": "integer"
+                },
+                "razao": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.Composicao": {
+            "type": "object",
+            "properties": {
+                "colunas": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "consecutivos": {
+                    "type": "integer"
+                },
+                "decadas": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "fibonacci": {
+                    "type": "integer"
+                },
+                "impares": {
+                    "type": "integer"
+                },
+                "linhas": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "maiorSequencia": {
+                    "type": "integer"
+                },
+                "pares": {
+                    "type": "integer"
+                },
+                "primos": {
+                    "type": "integer"
+                },
+                "soma": {
+                    "type": "integer"
+                },
+                "sorteio": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ConcursoComGanhadores": {
+            "type": "object",
+            "properties": {
+                "concurso": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "string"
+                },
+                "ganhadores": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GanhadorLocal"
+                    }
+                }
+            }
+        },
+        "model.ConcursoEncontrado": {
+            "type": "object",
+            "properties": {
+                "acertos": {
+                    "type": "integer"
+                },
+                "acertosTrevos": {
+                    "type": "integer"
+                },
+                "colunasEncontradas": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "concurso": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "string"
+                },
+                "dezenas": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "dezenasEncontradas": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "trevos": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "trevosEncontrados": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.ConcursoEspecial": {
+            "type": "object",
+            "properties": {
+                "concurso": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "string"
+                },
+                "dezenas": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "ganhadores": {
+                    "type": "integer"
+                },
+                "premioPrincipal": {
+                    "type": "number"
+                },
+                "tipoEspecial": {
+                    "type": "string"
+                },
+                "valorArrecadado": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.ConcursoPremiado": {
+            "type": "object",
+            "properties": {
+                "concurso": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "string"
+                },
+                "premios": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PremioAposta"
+                    }
+                },
+                "valor": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.ConcursosComGanhadores": {
+            "type": "object",
+            "properties": {
+                "concursos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ConcursoComGanhadores"
+                    }
+                },
+                "loteria": {
+                    "type": "string"
+                },
+                "municipio": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "uf": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Conferencia": {
+            "type": "object",
+            "properties": {
+                "concurso": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "string"
+                },
+                "loteria": {
+                    "type": "string"
+                },
+                "premiado": {
+                    "type": "boolean"
+                },
+                "sorteios": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ConferenciaSorteio"
+                    }
+                },
+                "valorTotal": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.ConferenciaBilhete": {
+            "type": "object",
+            "properties": {
+                "acertos": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "concurso": {
+                    "type": "integer"
+                },
+                "conferidoEm": {
+                    "type": "string"
+                },
+                "data": {
+                    "type": "string"
+                },
+                "premios": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PremioAposta"
+                    }
+                },
+                "valor": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.ConferenciaFederal": {
+            "type": "object",
+            "properties": {
+                "bilhete": {
+                    "type": "string"
+                },
+                "concurso": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "string"
+                },
+                "premiado": {
+                    "type": "boolean"
+                },
+                "premios": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PremioFederal"
+                    }
+                },
+                "serie": {
+                    "type": "string"
+                },
+                "valorTotal": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.ConferenciaHistorico": {
+            "type": "object",
+            "properties": {
+                "concursoFim": {
+                    "type": "integer"
+                },
+                "concursoInicio": {
+                    "type": "integer"
+                },
+                "concursos": {
+                    "type": "integer"
+                },
+                "concursosIgnorados": {
+                    "type": "integer"
+                },
+                "concursosPremiados": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ConcursoPremiado"
+                    }
+                },
+                "custo": {
+                    "type": "number"
+                },
+                "distribuicaoAcertos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ContagemAcertos"
+                    }
+                },
+                "faixas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ResumoFaixa"
+                    }
+                },
+                "loteria": {
+                    "type": "string"
+                },
+                "saldo": {
+                    "type": "number"
+                },
+                "valorTotal": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.ConferenciaSorteio": {
+            "type": "object",
+            "properties": {
+                "acertos": {
+                    "type": "integer"
+                },
+                "acertouMesSorte": {
+                    "type": "boolean"
+                },
+                "acertouTimeCoracao": {
+                    "type": "boolean"
+                },
+                "dezenas": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "dezenasAcertadas": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "premios": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PremioAposta"
+                    }
+                },
+                "sorteio": {
+                    "type": "integer"
+                },
+                "trevosAcertados": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.ContagemAcertos": {
+            "type": "object",
+            "properties": {
+                "acertos": {
+                    "type": "integer"
+                },
+                "sorteios": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Coocorrencia": {
+            "type": "object",
+            "properties": {
+                "concursoFim": {
+                    "type": "integer"
+                },
+                "concursoInicio": {
+                    "type": "integer"
+                },
+                "concursos": {
+                    "type": "integer"
+                },
+                "loteria": {
+                    "type": "string"
+                },
+                "sorteios": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CoocorrenciaSorteio"
+                    }
+                },
+                "tamanho": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.CoocorrenciaSorteio": {
+            "type": "object",
+            "properties": {
+                "combinacoes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CombinacaoFrequente"
+                    }
+                },
+                "esperado": {
+                    "type": "number"
+                },
+                "sorteio": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Desdobramento": {
+            "type": "object",
+            "properties": {
+                "apostas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ApostaGerada"
+                    }
+                },
+                "cobertura": {
+                    "$ref": "#/definitions/model.CoberturaDesdobramento"
+                },
+                "combinacaoCompleta": {
+                    "$ref": "#/definitions/model.CombinacaoCompleta"
+                },
+                "condicao": {
+                    "type": "integer"
+                },
+                "dezenas": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "faixa": {
+                    "type": "string"
+                },
+                "garantia": {
+                    "type": "integer"
+                },
+                "loteria": {
+                    "type": "string"
+                },
+                "valorTotal": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.DistribuicaoComposicao": {
+            "type": "object",
+            "properties": {
+                "colunas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.HistogramaGrupo"
+                    }
+                },
+                "concursoFim": {
+                    "type": "integer"
+                },
+                "concursoInicio": {
+                    "type": "integer"
+                },
+                "concursos": {
+                    "type": "integer"
+                },
+                "consecutivos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Histograma"
+                    }
+                },
+                "decadas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.HistogramaGrupo"
+                    }
+                },
+                "fibonacci": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Histograma"
+                    }
+                },
+                "intervaloSoma": {
+                    "type": "integer"
+                },
+                "linhas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.HistogramaGrupo"
+                    }
+                },
+                "loteria": {
+                    "type": "string"
+                },
+                "maiorSequencia": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Histograma"
+                    }
+                },
+                "pares": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Histograma"
+                    }
+                },
+                "primos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Histograma"
+                    }
+                },
+                "soma": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.IntervaloSoma"
+                    }
+                },
+                "somaEsperada": {
+                    "type": "number"
+                },
+                "somaMedia": {
+                    "type": "number"
+                },
+                "sorteios": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.DistribuicaoSequencia": {
+            "type": "object",
+            "properties": {
+                "concursos": {
+                    "type": "integer"
+                },
+                "percentual": {
+                    "type": "number"
+                },
+                "quantidade": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Estado": {
+            "type": "object",
+            "properties": {
+                "ganhadores": {
+                    "type": "integer"
+                },
+                "nome": {
+                    "type": "string"
+                },
+                "uf": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Faixa": {
+            "type": "object",
+            "properties": {
+                "acertos": {
+                    "type": "integer"
+                },
+                "descricao": {
+                    "type": "string"
+                },
+                "especial": {
+                    "type": "string"
+                },
+                "faixa": {
+                    "type": "integer"
+                },
+                "sorteio": {
+                    "type": "integer"
+                },
+                "trevos": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "model.Frequencia": {
+            "type": "object",
+            "properties": {
+                "colunas": {
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/model.FrequenciaNumero"
+                        }
+                    }
+                },
+                "concursoFim": {
+                    "type": "integer"
+                },
+                "concursoInicio": {
+                    "type": "integer"
+                },
+                "concursos": {
+                    "type": "integer"
+                },
+                "dezenas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FrequenciaNumero"
+                    }
+                },
+                "loteria": {
+                    "type": "string"
+                },
+                "trevos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FrequenciaNumero"
+                    }
+                }
+            }
+        },
+        "model.FrequenciaNumero": {
+            "type": "object",
+            "properties": {
+                "frequencia": {
+                    "type": "integer"
+                },
+                "numero": {
+                    "type": "string"
+                },
+                "percentual": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.GanhadorLocal": {
+            "type": "object",
+            "properties": {
+                "canalEletronico": {
+                    "type": "boolean"
+                },
+                "estabelecimento": {
+                    "type": "string"
+                },
+                "faixa": {
+                    "type": "integer"
+                },
+                "ganhadores": {
+                    "type": "integer"
+                },
+                "municipio": {
+                    "type": "string"
+                },
+                "uf": {
+                    "type": "string"
+                },
+                "valorUnitario": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.Histograma": {
+            "type": "object",
+            "properties": {
+                "percentual": {
+                    "type": "number"
+                },
+                "sorteios": {
+                    "type": "integer"
+                },
+                "valor": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.HistogramaGrupo": {
+            "type": "object",
+            "properties": {
+                "grupo": {
+                    "type": "string"
+                },
+                "histograma": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Histograma"
+                    }
+                }
+            }
+        },
+        "model.IntervaloSoma": {
+            "type": "object",
+            "properties": {
+                "fim": {
+                    "type": "integer"
+                },
+                "inicio": {
+                    "type": "integer"
+                },
+                "percentual": {
+                    "type": "number"
+                },
+                "sorteios": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.LocalPremiado": {
+            "type": "object",
+            "properties": {
+                "canalEletronico": {
+                    "$ref": "#/definitions/model.TotalGanhadores"
+                },
+                "concursos": {
+                    "type": "integer"
+                },
+                "ganhadores": {
+                    "type": "integer"
+                },
+                "lotericas": {
+                    "$ref": "#/definitions/model.TotalGanhadores"
+                },
+                "municipio": {
+                    "type": "string"
+                },
+                "uf": {
+                    "type": "string"
+                },
+                "valor": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.LocalVenda": {
+            "type": "object",
+            "properties": {
+                "estabelecimento": {
+                    "type": "string"
+                },
+                "municipio": {
+                    "type": "string"
+                },
+                "serie": {
+                    "type": "string"
+                },
+                "uf": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Loteria": {
+            "type": "string",
+            "enum": [
+                "maismilionaria",
+                "megasena",
+                "lotofacil",
+                "quina",
+                "lotomania",
+                "timemania",
+                "duplasena",
+                "federal",
+                "diadesorte",
+                "supersete"
+            ],
+            "x-enum-varnames": [
+                "MaisMilionaria",
+                "MegaSena",
+                "Lotofacil",
+                "Quina",
+                "Lotomania",
+                "Timemania",
+                "DuplaSena",
+                "Federal",
+                "DiaDeSorte",
+                "SuperSete"
+            ]
+        },
+        "model.MunicipioUFGanhadores": {
+            "type": "object",
+            "properties": {
+                "estabelecimento": {
+                    "type": "string"
+                },
+                "ganhadores": {
+                    "type": "integer"
+                },
+                "municipio": {
+                    "type": "string"
+                },
+                "numeroBilhete": {
+                    "type": "string"
+                },
+                "posicao": {
+                    "type": "integer"
+                },
+                "serie": {
+                    "type": "string"
+                },
+                "uf": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ParticipacaoBolao": {
+            "type": "object",
+            "properties": {
+                "cotas": {
+                    "type": "integer"
+                },
+                "custo": {
+                    "type": "number"
+                },
+                "nome": {
+                    "type": "string"
+                },
+                "participanteId": {
+                    "type": "string"
+                },
+                "percentual": {
+                    "type": "number"
+                },
+                "premio": {
+                    "type": "number"
+                },
+                "saldo": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.Participante": {
+            "type": "object",
+            "properties": {
+                "cotas": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "nome": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.PedidoBacktest": {
+            "type": "object",
+            "properties": {
+                "aposta": {
+                    "$ref": "#/definitions/model.Aposta"
+                },
+                "estrategia": {
+                    "type": "string"
+                },
+                "janela": {
+                    "type": "integer"
+                },
+                "numeros": {
+                    "type": "integer"
+                },
+                "semente": {
+                    "type": "integer"
+                },
+                "trevos": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.PedidoDesdobramento": {
+            "type": "object",
+            "properties": {
+                "condicao": {
+                    "type": "integer"
+                },
+                "dezenas": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "garantia": {
+                    "type": "integer"
+                },
+                "mesSorte": {
+                    "type": "string"
+                },
+                "numeros": {
+                    "type": "integer"
+                },
+                "tempoMaximo": {
+                    "type": "integer"
+                },
+                "timeCoracao": {
+                    "type": "string"
+                },
+                "trevos": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.PedidoGeracao": {
+            "type": "object",
+            "properties": {
+                "excluidos": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "fixos": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "inedita": {
+                    "type": "boolean"
+                },
+                "numeros": {
+                    "type": "integer"
+                },
+                "pares": {
+                    "type": "integer"
+                },
+                "quantidade": {
+                    "type": "integer"
+                },
+                "somaMaxima": {
+                    "type": "integer"
+                },
+                "somaMinima": {
+                    "type": "integer"
+                },
+                "trevos": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.PontoSerie": {
+            "type": "object",
+            "properties": {
+                "concursoFim": {
+                    "type": "integer"
+                },
+                "concursoInicio": {
+                    "type": "integer"
+                },
+                "concursos": {
+                    "type": "integer"
+                },
+                "concursosComValor": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "string"
+                },
+                "maximo": {
+                    "type": "number"
+                },
+                "media": {
+                    "type": "number"
+                },
+                "periodo": {
+                    "type": "string"
+                },
+                "soma": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.PrecoAposta": {
+            "type": "object",
+            "properties": {
+                "apostasSimples": {
+                    "type": "integer"
+                },
+                "numeros": {
+                    "type": "integer"
+                },
+                "trevos": {
+                    "type": "integer"
+                },
+                "valor": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.Premiacao": {
+            "type": "object",
+            "properties": {
+                "descricao": {
+                    "type": "string"
+                },
+                "faixa": {
+                    "type": "integer"
+                },
+                "numeroDeGanhadores": {
+                    "type": "integer"
+                },
+                "valor": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.PremioAposta": {
+            "type": "object",
+            "properties": {
+                "descricao": {
+                    "type": "string"
+                },
+                "faixa": {
+                    "type": "integer"
+                },
+                "quantidade": {
+                    "type": "integer"
+                },
+                "valor": {
+                    "type": "number"
+                },
+                "valorUnitario": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.PremioDerivado": {
+            "type": "object",
+            "properties": {
+                "bilhetes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "descricao": {
+                    "type": "string"
+                },
+                "terminacoes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "tipo": {
+                    "type": "string"
+                },
+                "valor": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.PremioFederal": {
+            "type": "object",
+            "properties": {
+                "descricao": {
+                    "type": "string"
+                },
+                "locais": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.LocalVenda"
+                    }
+                },
+                "tipo": {
+                    "type": "string"
+                },
+                "valor": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.PremioPago": {
+            "type": "object",
+            "properties": {
+                "concurso": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "string"
+                },
+                "descricao": {
+                    "type": "string"
+                },
+                "especial": {
+                    "type": "boolean"
+                },
+                "faixa": {
+                    "type": "integer"
+                },
+                "ganhadores": {
+                    "type": "integer"
+                },
+                "locais": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GanhadorLocal"
+                    }
+                },
+                "loteria": {
+                    "type": "string"
+                },
+                "tipoEspecial": {
+                    "type": "string"
+                },
+                "valorPorGanhador": {
+                    "type": "number"
+                },
+                "valorTotal": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.ProbabilidadeFaixa": {
+            "type": "object",
+            "properties": {
+                "casos": {
+                    "type": "string"
+                },
+                "descricao": {
+                    "type": "string"
+                },
+                "faixa": {
+                    "type": "integer"
+                },
+                "fontePremio": {
+                    "type": "string"
+                },
+                "premio": {
+                    "type": "number"
+                },
+                "premiosEsperados": {
+                    "type": "number"
+                },
+                "probabilidade": {
+                    "type": "number"
+                },
+                "total": {
+                    "type": "string"
+                },
+                "umEm": {
+                    "type": "string"
+                },
+                "valorEsperado": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.Probabilidades": {
+            "type": "object",
+            "properties": {
+                "apostasSimples": {
+                    "type": "integer"
+                },
+                "colunas": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "faixas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ProbabilidadeFaixa"
+                    }
+                },
+                "loteria": {
+                    "type": "string"
+                },
+                "numeros": {
+                    "type": "integer"
+                },
+                "preco": {
+                    "type": "number"
+                },
+                "premioEstimado": {
+                    "type": "number"
+                },
+                "proximoConcurso": {
+                    "type": "integer"
+                },
+                "retorno": {
+                    "type": "number"
+                },
+                "trevos": {
+                    "type": "integer"
+                },
+                "valorEsperado": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.ProximoSorteio": {
+            "type": "object",
+            "properties": {
+                "acumulado": {
+                    "type": "boolean"
+                },
+                "concurso": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "string"
+                },
+                "dataSorteio": {
+                    "type": "string"
+                },
+                "dataUltimoConcurso": {
+                    "type": "string"
+                },
+                "desatualizado": {
+                    "type": "boolean"
+                },
+                "loteria": {
+                    "type": "string"
+                },
+                "motivo": {
+                    "type": "string"
+                },
+                "nome": {
+                    "type": "string"
+                },
+                "ultimoConcurso": {
+                    "type": "integer"
+                },
+                "valorAcumulado": {
+                    "type": "number"
+                },
+                "valorEstimado": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.RankingGanhadores": {
+            "type": "object",
+            "properties": {
+                "agrupamento": {
+                    "type": "string"
+                },
+                "canalEletronico": {
+                    "$ref": "#/definitions/model.TotalGanhadores"
+                },
+                "faixa": {
+                    "type": "integer"
+                },
+                "locais": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.LocalPremiado"
+                    }
+                },
+                "loteria": {
+                    "type": "string"
+                },
+                "lotericas": {
+                    "$ref": "#/definitions/model.TotalGanhadores"
+                },
+                "total": {
+                    "$ref": "#/definitions/model.TotalGanhadores"
+                }
+            }
+        },
+        "model.RankingPremios": {
+            "type": "object",
+            "properties": {
+                "ano": {
+                    "type": "integer"
+                },
+                "faixa": {
+                    "type": "integer"
+                },
+                "loteria": {
+                    "type": "string"
+                },
+                "premios": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PremioPago"
+                    }
+                }
+            }
+        },
+        "model.RegraColunas": {
+            "type": "object",
+            "properties": {
+                "maxPorColuna": {
+                    "type": "integer"
+                },
+                "minPorColuna": {
+                    "type": "integer"
+                },
+                "quantidade": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.RegraTrevos": {
+            "type": "object",
+            "properties": {
+                "maiorNumero": {
+                    "type": "integer"
+                },
+                "maxNumeros": {
+                    "type": "integer"
+                },
+                "menorNumero": {
+                    "type": "integer"
+                },
+                "minNumeros": {
+                    "type": "integer"
+                },
+                "numerosApostaSimples": {
+                    "type": "integer"
+                },
+                "sorteados": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Regras": {
+            "type": "object",
+            "properties": {
+                "colunas": {
+                    "$ref": "#/definitions/model.RegraColunas"
+                },
+                "concursoEspecial": {
+                    "type": "string"
+                },
+                "diasSorteio": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "faixas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Faixa"
+                    }
+                },
+                "loteria": {
+                    "$ref": "#/definitions/model.Loteria"
+                },
+                "maiorNumero": {
+                    "type": "integer"
+                },
+                "maxNumeros": {
+                    "type": "integer"
+                },
+                "menorNumero": {
+                    "type": "integer"
+                },
+                "mesSorte": {
+                    "type": "boolean"
+                },
+                "minNumeros": {
+                    "type": "integer"
+                },
+                "nome": {
+                    "type": "string"
+                },
+                "numerosApostaSimples": {
+                    "type": "integer"
+                },
+                "numerosPorLinha": {
+                    "type": "integer"
+                },
+                "numerosSorteados": {
+                    "type": "integer"
+                },
+                "precoApostaSimples": {
+                    "type": "number"
+                },
+                "precos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PrecoAposta"
+                    }
+                },
+                "sorteios": {
+                    "type": "integer"
+                },
+                "tipo": {
+                    "$ref": "#/definitions/model.TipoJogo"
+                },
+                "trevos": {
+                    "$ref": "#/definitions/model.RegraTrevos"
+                }
             }
-        }
-    },
-    "definitions": {
-        "controller.ErrorResponse": {
+        },
+        "model.RelatorioBolao": {
             "type": "object",
             "properties": {
-                "error": {
-                    "type": "string"
+                "bilhetes": {
+                    "type": "integer"
                 },
-                "message": {
-                    "type": "string"
+                "bolao": {
+                    "$ref": "#/definitions/model.Bolao"
+                },
+                "concursos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ResumoConcursoBolao"
+                    }
+                },
+                "custo": {
+                    "type": "number"
+                },
+                "participantes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ParticipacaoBolao"
+                    }
+                },
+                "saldo": {
+                    "type": "number"
+                },
+                "totalCotas": {
+                    "type": "integer"
+                },
+                "valorTotal": {
+                    "type": "number"
                 }
             }
         },
-        "model.Estado": {
+        "model.ReservaConcurso": {
             "type": "object",
             "properties": {
-                "ganhadores": {
+                "concurso": {
                     "type": "integer"
                 },
-                "nome": {
+                "data": {
                     "type": "string"
                 },
-                "uf": {
-                    "type": "string"
+                "valor": {
+                    "type": "number"
                 }
             }
         },
-        "model.MunicipioUFGanhadores": {
+        "model.ReservaEspecial": {
             "type": "object",
             "properties": {
-                "ganhadores": {
+                "concursosDesde": {
                     "type": "integer"
                 },
-                "municipio": {
-                    "type": "string"
-                },
-                "numeroBilhete": {
-                    "type": "string"
+                "crescimentoMedio": {
+                    "type": "number"
                 },
-                "posicao": {
-                    "type": "integer"
+                "evolucao": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ReservaConcurso"
+                    }
                 },
-                "serie": {
+                "loteria": {
                     "type": "string"
                 },
-                "uf": {
-                    "type": "string"
-                }
-            }
-        },
-        "model.Premiacao": {
-            "type": "object",
-            "properties": {
-                "descricao": {
+                "tipoEspecial": {
                     "type": "string"
                 },
-                "faixa": {
-                    "type": "integer"
-                },
-                "numeroDeGanhadores": {
-                    "type": "integer"
+                "ultimoEspecial": {
+                    "$ref": "#/definitions/model.ConcursoEspecial"
                 },
-                "valor": {
+                "valorAtual": {
                     "type": "number"
                 }
             }
@@ -269,6 +4661,12 @@ const docTemplate = `{
                 "acumulou": {
                     "type": "boolean"
                 },
+                "composicao": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Composicao"
+                    }
+                },
                 "concurso": {
                     "type": "integer"
                 },
@@ -278,6 +4676,12 @@ const docTemplate = `{
                 "dataProximoConcurso": {
                     "type": "string"
                 },
+                "dataProximoSorteio": {
+                    "type": "string"
+                },
+                "dataSorteio": {
+                    "type": "string"
+                },
                 "dezenas": {
                     "type": "array",
                     "items": {
@@ -290,12 +4694,18 @@ const docTemplate = `{
                         "type": "string"
                     }
                 },
+                "especial": {
+                    "type": "boolean"
+                },
                 "estadosPremiados": {
                     "type": "array",
                     "items": {
                         "$ref": "#/definitions/model.Estado"
                     }
                 },
+                "federal": {
+                    "$ref": "#/definitions/model.ResultadoFederal"
+                },
                 "local": {
                     "type": "string"
                 },
@@ -323,9 +4733,18 @@ const docTemplate = `{
                 "proximoConcurso": {
                     "type": "integer"
                 },
+                "sorteios": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SorteioResultado"
+                    }
+                },
                 "timeCoracao": {
                     "type": "string"
                 },
+                "tipoEspecial": {
+                    "type": "string"
+                },
                 "trevos": {
                     "type": "array",
                     "items": {
@@ -348,6 +4767,232 @@ const docTemplate = `{
                     "type": "number"
                 }
             }
+        },
+        "model.ResultadoFederal": {
+            "type": "object",
+            "properties": {
+                "bilhetes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.BilhetePremiado"
+                    }
+                },
+                "premiosDerivados": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PremioDerivado"
+                    }
+                }
+            }
+        },
+        "model.ResumoConcursoBolao": {
+            "type": "object",
+            "properties": {
+                "bilhetesPremiados": {
+                    "type": "integer"
+                },
+                "concurso": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "string"
+                },
+                "valor": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.ResumoFaixa": {
+            "type": "object",
+            "properties": {
+                "descricao": {
+                    "type": "string"
+                },
+                "faixa": {
+                    "type": "integer"
+                },
+                "quantidade": {
+                    "type": "integer"
+                },
+                "valor": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.RodadaBacktest": {
+            "type": "object",
+            "properties": {
+                "acertos": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "aposta": {
+                    "$ref": "#/definitions/model.Aposta"
+                },
+                "concurso": {
+                    "type": "integer"
+                },
+                "custo": {
+                    "type": "number"
+                },
+                "data": {
+                    "type": "string"
+                },
+                "premio": {
+                    "type": "number"
+                },
+                "saldo": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.SequenciaAcumulacao": {
+            "type": "object",
+            "properties": {
+                "concursoFim": {
+                    "type": "integer"
+                },
+                "concursoInicio": {
+                    "type": "integer"
+                },
+                "concursoPremiado": {
+                    "type": "integer"
+                },
+                "concursos": {
+                    "type": "integer"
+                },
+                "concursosEspeciais": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "crescimento": {
+                    "type": "number"
+                },
+                "dataFim": {
+                    "type": "string"
+                },
+                "dataInicio": {
+                    "type": "string"
+                },
+                "emAndamento": {
+                    "type": "boolean"
+                },
+                "ganhadores": {
+                    "type": "integer"
+                },
+                "premioPago": {
+                    "type": "number"
+                },
+                "valorFinal": {
+                    "type": "number"
+                },
+                "valorInicial": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.Serie": {
+            "type": "object",
+            "properties": {
+                "agrupamento": {
+                    "type": "string"
+                },
+                "campo": {
+                    "type": "string"
+                },
+                "loteria": {
+                    "type": "string"
+                },
+                "pontos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PontoSerie"
+                    }
+                }
+            }
+        },
+        "model.SorteioResultado": {
+            "type": "object",
+            "properties": {
+                "dezenas": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "dezenasOrdemSorteio": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "premiacoes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Premiacao"
+                    }
+                },
+                "sorteio": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.TesteAleatoriedade": {
+            "type": "object",
+            "properties": {
+                "descricao": {
+                    "type": "string"
+                },
+                "esperado": {
+                    "type": "number"
+                },
+                "estatistica": {
+                    "type": "number"
+                },
+                "grausLiberdade": {
+                    "type": "integer"
+                },
+                "nome": {
+                    "type": "string"
+                },
+                "pValor": {
+                    "type": "number"
+                },
+                "teste": {
+                    "type": "string"
+                },
+                "veredito": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.TipoJogo": {
+            "type": "string",
+            "enum": [
+                "dezenas",
+                "colunas",
+                "bilhete"
+            ],
+            "x-enum-varnames": [
+                "TipoDezenas",
+                "TipoColunas",
+                "TipoBilhete"
+            ]
+        },
+        "model.TotalGanhadores": {
+            "type": "object",
+            "properties": {
+                "ganhadores": {
+                    "type": "integer"
+                },
+                "valor": {
+                    "type": "number"
+                }
+            }
         }
     }
 }`
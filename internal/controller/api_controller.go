@@ -1,8 +1,12 @@
 package controller
 
 import (
+	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"loterias-api-golang/internal/model"
 	"loterias-api-golang/internal/service"
@@ -20,6 +24,11 @@ func NewApiController(resultadoService *service.ResultadoService) *ApiController
 	}
 }
 
+const (
+	limitePadraoPagina = 100
+	limiteMaximoPagina = 1000
+)
+
 type ErrorResponse struct {
 	Error   string `json:"error"`
 	Message string `json:"message"`
@@ -37,14 +46,21 @@ func (c *ApiController) GetLotteries(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, model.AllLoterias())
 }
 
-// GetResultsByLottery retorna todos os resultados de uma loteria
+// GetResultsByLottery retorna os resultados de uma loteria
 //
 //	@Summary		Busca resultados por loteria
-//	@Description	Retorna todos os resultados já realizados da loteria especificada
+//	@Description	Retorna os resultados já realizados da loteria especificada, opcionalmente limitados a um intervalo de datas. Sem limit nem cursor a resposta continua sendo a lista com todos os concursos. Com limit ou cursor a resposta passa a ser uma página (model.PaginaResultados: total, limite, ordem, links proximo e anterior e os resultados); só com cursor a página tem 100 concursos. O parâmetro fields projeta apenas os campos informados (loteria e concurso vêm sempre).
 //	@Tags			Loterias
 //	@Produce		json
 //	@Param			loteria	path		string	true	"ID da Loteria"	Enums(maismilionaria, megasena, lotofacil, quina, lotomania, timemania, duplasena, federal, diadesorte, supersete)
+//	@Param			de		query		string	false	"Data inicial (dd/mm/aaaa ou aaaa-mm-dd)"
+//	@Param			ate		query		string	false	"Data final (dd/mm/aaaa ou aaaa-mm-dd)"
+//	@Param			limit	query		int		false	"Concursos por página (máx. 1000); ativa a paginação"
+//	@Param			cursor	query		string	false	"Cursor retornado nos links proximo e anterior; ativa a paginação"
+//	@Param			ordem	query		string	false	"Ordem por concurso"	Enums(asc, desc)	default(desc)
+//	@Param			fields	query		string	false	"Campos retornados, separados por vírgula (ex: data,dezenas)"
 //	@Success		200		{array}		model.Resultado
+//	@Failure		400		{object}	ErrorResponse
 //	@Failure		404		{object}	ErrorResponse
 //	@Router			/{loteria} [get]
 func (c *ApiController) GetResultsByLottery(ctx *gin.Context) {
@@ -53,12 +69,36 @@ func (c *ApiController) GetResultsByLottery(ctx *gin.Context) {
 	if !model.IsValid(loteria) {
 		ctx.JSON(http.StatusNotFound, ErrorResponse{
 			Error:   "Resource Not Found",
-			Message: c.getInvalidLotteryMessage(loteria),
+			Message: invalidLotteryMessage(loteria),
+		})
+		return
+	}
+
+	var filtro model.FiltroConcursos
+	var err error
+	if filtro.DataInicio, err = queryDate(ctx, "de"); err != nil {
+		respondError(ctx, err)
+		return
+	}
+	if filtro.DataFim, err = queryDate(ctx, "ate"); err != nil {
+		respondError(ctx, err)
+		return
+	}
+	if filtro.DataInicio != nil && filtro.DataFim != nil && filtro.DataInicio.After(*filtro.DataFim) {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Bad Request",
+			Message: "A data inicial não pode ser posterior à data final",
 		})
 		return
 	}
 
-	resultados, err := c.resultadoService.FindByLoteria(loteria)
+	paginacao, campos, err := parsePaginacao(ctx)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	pagina, err := c.resultadoService.FindByLoteria(loteria, filtro, paginacao)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error:   "Internal Server Error",
@@ -67,9 +107,340 @@ func (c *ApiController) GetResultsByLottery(ctx *gin.Context) {
 		return
 	}
 
+	var resultados any = pagina.Resultados
+	if len(campos) > 0 {
+		projetados := make([]map[string]json.RawMessage, 0, len(pagina.Resultados))
+		for _, r := range pagina.Resultados {
+			projetado, err := model.ProjetarResultado(r, campos)
+			if err != nil {
+				respondError(ctx, err)
+				return
+			}
+			projetados = append(projetados, projetado)
+		}
+		resultados = projetados
+	}
+
+	if paginacao.Limite == 0 {
+		ctx.JSON(http.StatusOK, resultados)
+		return
+	}
+
+	resposta := model.PaginaResultados{
+		Total:      pagina.Total,
+		Limite:     paginacao.Limite,
+		Ordem:      paginacao.Ordem,
+		Resultados: resultados,
+	}
+	if n := len(pagina.Resultados); n > 0 {
+		if pagina.TemProximo {
+			resposta.Proximo = linkPagina(ctx, model.Cursor{Concurso: pagina.Resultados[n-1].ID.Concurso})
+		}
+		if pagina.TemAnterior {
+			resposta.Anterior = linkPagina(ctx, model.Cursor{Concurso: pagina.Resultados[0].ID.Concurso, Anterior: true})
+		}
+	}
+
+	ctx.JSON(http.StatusOK, resposta)
+}
+
+// parsePaginacao lê limite, cursor, ordem e campos da listagem de
+// resultados. A paginação só é usada quando a requisição traz limit ou
+// cursor; sem eles o limite fica zerado e a listagem mantém a resposta
+// original, com todos os concursos. Só com cursor a página tem
+// limitePadraoPagina concursos. Também retorna os campos pelo nome usado na
+// API.
+func parsePaginacao(ctx *gin.Context) (model.Paginacao, []string, error) {
+	paginacao := model.Paginacao{Ordem: model.OrdemDecrescente}
+	var err error
+
+	if ctx.Query("limit") != "" || ctx.Query("cursor") != "" {
+		if paginacao.Limite, err = queryIntDefault(ctx, "limit", limitePadraoPagina); err != nil {
+			return paginacao, nil, err
+		}
+		if paginacao.Limite < 1 || paginacao.Limite > limiteMaximoPagina {
+			return paginacao, nil, &model.ParametroInvalidoException{
+				Message: "Parâmetro 'limit' deve estar entre 1 e " + strconv.Itoa(limiteMaximoPagina),
+			}
+		}
+	}
+
+	if valor := ctx.Query("cursor"); valor != "" {
+		if paginacao.Cursor, err = model.ParseCursor(valor); err != nil {
+			return paginacao, nil, &model.ParametroInvalidoException{Message: "Parâmetro 'cursor' inválido"}
+		}
+	}
+
+	switch ordem := ctx.Query("ordem"); ordem {
+	case "":
+	case model.OrdemCrescente, model.OrdemDecrescente:
+		paginacao.Ordem = ordem
+	default:
+		return paginacao, nil, &model.ParametroInvalidoException{Message: "Parâmetro 'ordem' deve ser asc ou desc"}
+	}
+
+	var campos []string
+	if valor := ctx.Query("fields"); valor != "" {
+		for _, campo := range strings.Split(valor, ",") {
+			campo = strings.TrimSpace(campo)
+			if campo == "" {
+				continue
+			}
+			nome, ok := model.CampoResultado(campo)
+			if !ok {
+				return paginacao, nil, &model.ParametroInvalidoException{Message: "Campo '" + campo + "' não existe no resultado"}
+			}
+			campos = append(campos, campo)
+			paginacao.Campos = append(paginacao.Campos, nome)
+		}
+	}
+
+	return paginacao, campos, nil
+}
+
+// linkPagina repete a requisição atual trocando apenas o cursor.
+func linkPagina(ctx *gin.Context, cursor model.Cursor) string {
+	url := *ctx.Request.URL
+	query := url.Query()
+	query.Set("cursor", cursor.String())
+	url.RawQuery = query.Encode()
+	return url.RequestURI()
+}
+
+// GetResultsByDate retorna os sorteios de todas as loterias em um dia
+//
+//	@Summary		Busca sorteios por data
+//	@Description	Retorna os resultados de todas as loterias sorteadas no dia informado
+//	@Tags			Loterias
+//	@Produce		json
+//	@Param			data	path		string	true	"Data do sorteio (aaaa-mm-dd ou dd-mm-aaaa)"
+//	@Success		200		{array}		model.Resultado
+//	@Failure		400		{object}	ErrorResponse
+//	@Router			/sorteios/{data} [get]
+func (c *ApiController) GetResultsByDate(ctx *gin.Context) {
+	data, ok := model.ParseDataConsulta(ctx.Param("data"))
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Bad Request",
+			Message: "A data deve estar no formato aaaa-mm-dd ou dd-mm-aaaa",
+		})
+		return
+	}
+
+	resultados, err := c.resultadoService.FindByData(*data)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
 	ctx.JSON(http.StatusOK, resultados)
 }
 
+// GetUpcoming retorna o próximo concurso de cada loteria
+//
+//	@Summary		Próximos sorteios
+//	@Description	Retorna, para cada loteria, o número, a data e o prêmio estimado do próximo concurso segundo o último resultado armazenado, ordenados pela data do sorteio. Loterias cujo último resultado está atrasado são marcadas como desatualizadas.
+//	@Tags			Loterias
+//	@Produce		json
+//	@Success		200	{array}		model.ProximoSorteio
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/proximos [get]
+func (c *ApiController) GetUpcoming(ctx *gin.Context) {
+	proximos, err := c.resultadoService.Proximos()
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, proximos)
+}
+
+// GetCalendar retorna os próximos sorteios de todas as loterias em iCalendar
+//
+//	@Summary		Calendário de sorteios
+//	@Description	Calendário iCalendar (RFC 5545) com um evento por sorteio das loterias nos próximos dias. O próximo concurso de cada loteria traz o prêmio estimado; os seguintes são previstos pelos dias de sorteio do jogo.
+//	@Tags			Loterias
+//	@Produce		text/calendar
+//	@Param			dias	query		int	false	"Quantidade de dias cobertos (máx. 90)"	default(14)
+//	@Success		200		{string}	string
+//	@Failure		400		{object}	ErrorResponse
+//	@Router			/calendario.ics [get]
+func (c *ApiController) GetCalendar(ctx *gin.Context) {
+	c.responderCalendario(ctx, "Sorteios das Loterias Caixa", model.AllLoterias())
+}
+
+// GetLotteryCalendar retorna os próximos sorteios de uma loteria em iCalendar
+//
+//	@Summary		Calendário de sorteios da loteria
+//	@Description	Calendário iCalendar (RFC 5545) com um evento por sorteio da loteria nos próximos dias. O próximo concurso traz o prêmio estimado; os seguintes são previstos pelos dias de sorteio do jogo.
+//	@Tags			Loterias
+//	@Produce		text/calendar
+//	@Param			loteria	path		string	true	"ID da Loteria"	Enums(maismilionaria, megasena, lotofacil, quina, lotomania, timemania, duplasena, federal, diadesorte, supersete)
+//	@Param			dias	query		int		false	"Quantidade de dias cobertos (máx. 90)"	default(14)
+//	@Success		200		{string}	string
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		404		{object}	ErrorResponse
+//	@Router			/{loteria}/calendario.ics [get]
+func (c *ApiController) GetLotteryCalendar(ctx *gin.Context) {
+	loteria := ctx.Param("loteria")
+
+	if !model.IsValid(loteria) {
+		ctx.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Resource Not Found",
+			Message: invalidLotteryMessage(loteria),
+		})
+		return
+	}
+
+	regras, _ := model.GetRegras(loteria)
+	c.responderCalendario(ctx, "Sorteios - "+regras.Nome, []string{loteria})
+}
+
+func (c *ApiController) responderCalendario(ctx *gin.Context, nome string, loterias []string) {
+	dias, err := queryInt(ctx, "dias")
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	sorteios, err := c.resultadoService.Calendario(loterias, dias)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Data(http.StatusOK, "text/calendar; charset=utf-8", []byte(service.GerarICS(nome, sorteios, time.Now())))
+}
+
+// GetSpecialContests retorna os concursos especiais da loteria
+//
+//	@Summary		Concursos especiais
+//	@Description	Lista os concursos especiais da loteria (Mega da Virada, Lotofácil da Independência, Quina de São João, Dupla de Páscoa), do mais recente para o mais antigo, com o prêmio pago na faixa principal. Concursos especiais não acumulam.
+//	@Tags			Loterias
+//	@Produce		json
+//	@Param			loteria	path		string	true	"ID da Loteria"	Enums(megasena, lotofacil, quina, duplasena)
+//	@Success		200		{array}		model.ConcursoEspecial
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		404		{object}	ErrorResponse
+//	@Router			/{loteria}/especiais [get]
+func (c *ApiController) GetSpecialContests(ctx *gin.Context) {
+	loteria := ctx.Param("loteria")
+
+	if !model.IsValid(loteria) {
+		ctx.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Resource Not Found",
+			Message: invalidLotteryMessage(loteria),
+		})
+		return
+	}
+
+	especiais, err := c.resultadoService.Especiais(loteria)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, especiais)
+}
+
+// GetSpecialReserve retorna a reserva acumulada para o próximo concurso especial
+//
+//	@Summary		Reserva do próximo concurso especial
+//	@Description	Retorna o valor já reservado para o próximo concurso especial, o último especial realizado, quantos concursos se passaram desde ele, o crescimento médio da reserva por concurso e sua evolução.
+//	@Tags			Loterias
+//	@Produce		json
+//	@Param			loteria	path		string	true	"ID da Loteria"	Enums(megasena, lotofacil, quina, duplasena)
+//	@Success		200		{object}	model.ReservaEspecial
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		404		{object}	ErrorResponse
+//	@Router			/{loteria}/especiais/reserva [get]
+func (c *ApiController) GetSpecialReserve(ctx *gin.Context) {
+	loteria := ctx.Param("loteria")
+
+	if !model.IsValid(loteria) {
+		ctx.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Resource Not Found",
+			Message: invalidLotteryMessage(loteria),
+		})
+		return
+	}
+
+	reserva, err := c.resultadoService.ReservaEspecial(loteria)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, reserva)
+}
+
+// SearchByNumbers busca concursos pelos números sorteados
+//
+//	@Summary		Busca concursos por números
+//	@Description	Retorna os concursos em que saíram todos os números informados, ou ao menos min deles, do que teve mais acertos para o que teve menos. Trevos da +Milionária são buscados à parte, com seu próprio mínimo. Na Super Sete a busca é feita por coluna, no formato coluna:número.
+//	@Tags			Loterias
+//	@Produce		json
+//	@Param			loteria		path		string	true	"ID da Loteria"	Enums(maismilionaria, megasena, lotofacil, quina, lotomania, timemania, duplasena, diadesorte, supersete)
+//	@Param			dezenas		query		string	false	"Dezenas separadas por vírgula (ex: 04,15,33)"
+//	@Param			trevos		query		string	false	"Trevos separados por vírgula (+Milionária)"
+//	@Param			colunas		query		string	false	"Pares coluna:número separados por vírgula (Super Sete, ex: 1:5,3:7)"
+//	@Param			min			query		int		false	"Mínimo de dezenas ou colunas acertadas (padrão: todas)"
+//	@Param			minTrevos	query		int		false	"Mínimo de trevos acertados (padrão: todos)"
+//	@Param			limit		query		int		false	"Quantidade de concursos retornados (máx. 500)"	default(50)
+//	@Success		200			{object}	model.Busca
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		404			{object}	ErrorResponse
+//	@Router			/{loteria}/busca [get]
+func (c *ApiController) SearchByNumbers(ctx *gin.Context) {
+	loteria := ctx.Param("loteria")
+
+	if !model.IsValid(loteria) {
+		ctx.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Resource Not Found",
+			Message: invalidLotteryMessage(loteria),
+		})
+		return
+	}
+
+	pedido := model.PedidoBusca{
+		Dezenas: queryList(ctx, "dezenas"),
+		Trevos:  queryList(ctx, "trevos"),
+		Colunas: queryList(ctx, "colunas"),
+	}
+	var err error
+	if pedido.Min, err = queryInt(ctx, "min"); err != nil {
+		respondError(ctx, err)
+		return
+	}
+	if pedido.MinTrevos, err = queryInt(ctx, "minTrevos"); err != nil {
+		respondError(ctx, err)
+		return
+	}
+	if pedido.Limite, err = queryInt(ctx, "limit"); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	busca, err := c.resultadoService.Buscar(loteria, pedido)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, busca)
+}
+
+// queryList lê um parâmetro com valores separados por vírgula.
+func queryList(ctx *gin.Context, nome string) []string {
+	var valores []string
+	for _, v := range strings.Split(ctx.Query(nome), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			valores = append(valores, v)
+		}
+	}
+	return valores
+}
+
 // GetResultByID retorna um resultado específico
 //
 //	@Summary		Busca resultado por loteria e concurso
@@ -88,7 +459,7 @@ func (c *ApiController) GetResultByID(ctx *gin.Context) {
 	if !model.IsValid(loteria) {
 		ctx.JSON(http.StatusNotFound, ErrorResponse{
 			Error:   "Resource Not Found",
-			Message: c.getInvalidLotteryMessage(loteria),
+			Message: invalidLotteryMessage(loteria),
 		})
 		return
 	}
@@ -138,7 +509,7 @@ func (c *ApiController) GetLatestResult(ctx *gin.Context) {
 	if !model.IsValid(loteria) {
 		ctx.JSON(http.StatusNotFound, ErrorResponse{
 			Error:   "Resource Not Found",
-			Message: c.getInvalidLotteryMessage(loteria),
+			Message: invalidLotteryMessage(loteria),
 		})
 		return
 	}
@@ -155,7 +526,58 @@ func (c *ApiController) GetLatestResult(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, resultado)
 }
 
-func (c *ApiController) getInvalidLotteryMessage(loteria string) string {
+// GetRules retorna as regras de uma loteria
+//
+//	@Summary		Regras da loteria
+//	@Description	Retorna as regras do jogo: intervalo de números, quantidade sorteada, mínimo e máximo por aposta, preços, faixas de premiação, elementos extras e dias de sorteio
+//	@Tags			Loterias
+//	@Produce		json
+//	@Param			loteria	path		string	true	"ID da Loteria"	Enums(maismilionaria, megasena, lotofacil, quina, lotomania, timemania, duplasena, federal, diadesorte, supersete)
+//	@Success		200		{object}	model.Regras
+//	@Failure		404		{object}	ErrorResponse
+//	@Router			/{loteria}/regras [get]
+func (c *ApiController) GetRules(ctx *gin.Context) {
+	loteria := ctx.Param("loteria")
+
+	regras, ok := model.GetRegras(loteria)
+	if !ok {
+		ctx.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Resource Not Found",
+			Message: invalidLotteryMessage(loteria),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, regras)
+}
+
+// respondError traduz os erros dos services para o status HTTP adequado.
+func respondError(ctx *gin.Context, err error) {
+	var apostaInvalida *model.ApostaInvalidaException
+	var parametroInvalido *model.ParametroInvalidoException
+	var loteriaInvalida *model.LoteriaInvalidException
+	var notFound *model.ResourceNotFoundException
+
+	switch {
+	case errors.As(err, &apostaInvalida), errors.As(err, &parametroInvalido):
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Bad Request",
+			Message: err.Error(),
+		})
+	case errors.As(err, &loteriaInvalida), errors.As(err, &notFound):
+		ctx.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Resource Not Found",
+			Message: err.Error(),
+		})
+	default:
+		ctx.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Internal Server Error",
+			Message: err.Error(),
+		})
+	}
+}
+
+func invalidLotteryMessage(loteria string) string {
 	loterias := model.AllLoterias()
 	return "'" + loteria + "' não é o id de nenhuma das loterias suportadas. Loterias suportadas: " +
 		"[" + join(loterias, ", ") + "]"
@@ -0,0 +1,73 @@
+package controller
+
+import (
+	"net/http"
+
+	"loterias-api-golang/internal/model"
+	"loterias-api-golang/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BacktestController struct {
+	backtestService *service.BacktestService
+}
+
+func NewBacktestController(backtestService *service.BacktestService) *BacktestController {
+	return &BacktestController{
+		backtestService: backtestService,
+	}
+}
+
+// Executar simula uma estratégia de apostas sobre o histórico da loteria
+//
+//	@Summary		Backtest de estratégia
+//	@Description	Repete uma estratégia em cada concurso armazenado (ou na janela informada), em ordem de concurso, e retorna a aposta, os acertos e o saldo de cada concurso, além do custo total, prêmios, ROI e faixas atingidas. Estratégias: fixas (a mesma aposta sempre), quentes e frias (os números mais e menos sorteados nos concursos anteriores da janela) e aleatoria (reproduzível pela semente). Na Timemania e no Dia de Sorte, aposta.timeCoracao e aposta.mesSorte são obrigatórios em todas as estratégias.
+//	@Tags			Conferência
+//	@Accept			json
+//	@Produce		json
+//	@Param			loteria			path		string					true	"ID da Loteria"	Enums(maismilionaria, megasena, lotofacil, quina, lotomania, timemania, duplasena, diadesorte, supersete)
+//	@Param			pedido			body		model.PedidoBacktest	true	"Estratégia a simular"
+//	@Param			concursoInicio	query		int						false	"Primeiro concurso apostado"
+//	@Param			concursoFim		query		int						false	"Último concurso apostado"
+//	@Param			dataInicio		query		string					false	"Data inicial (dd/mm/aaaa ou aaaa-mm-dd)"
+//	@Param			dataFim			query		string					false	"Data final (dd/mm/aaaa ou aaaa-mm-dd)"
+//	@Param			ultimos			query		int						false	"Apostar apenas nos N concursos mais recentes"
+//	@Success		200				{object}	model.Backtest
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		404				{object}	ErrorResponse
+//	@Router			/{loteria}/backtest [post]
+func (c *BacktestController) Executar(ctx *gin.Context) {
+	loteria := ctx.Param("loteria")
+
+	if !model.IsValid(loteria) {
+		ctx.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Resource Not Found",
+			Message: invalidLotteryMessage(loteria),
+		})
+		return
+	}
+
+	filtro, err := parseFiltroConcursos(ctx)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	var pedido model.PedidoBacktest
+	if err := ctx.ShouldBindJSON(&pedido); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Bad Request",
+			Message: "Invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	backtest, err := c.backtestService.Executar(loteria, pedido, filtro)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, backtest)
+}
@@ -0,0 +1,307 @@
+package controller
+
+import (
+	"net/http"
+
+	"loterias-api-golang/internal/model"
+	"loterias-api-golang/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BolaoController struct {
+	bolaoService *service.BolaoService
+}
+
+func NewBolaoController(bolaoService *service.BolaoService) *BolaoController {
+	return &BolaoController{
+		bolaoService: bolaoService,
+	}
+}
+
+// ListarBoloes lista os bolões cadastrados
+//
+//	@Summary		Lista bolões
+//	@Description	Retorna os bolões cadastrados, do mais recente para o mais antigo, opcionalmente filtrados por loteria.
+//	@Tags			Bolões
+//	@Produce		json
+//	@Param			loteria	query		string	false	"ID da Loteria"
+//	@Success		200		{array}		model.Bolao
+//	@Failure		404		{object}	ErrorResponse
+//	@Router			/boloes [get]
+func (c *BolaoController) ListarBoloes(ctx *gin.Context) {
+	loteria := ctx.Query("loteria")
+
+	if loteria != "" && !model.IsValid(loteria) {
+		ctx.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Resource Not Found",
+			Message: invalidLotteryMessage(loteria),
+		})
+		return
+	}
+
+	boloes, err := c.bolaoService.Listar(loteria)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, boloes)
+}
+
+// CriarBolao cadastra um bolão
+//
+//	@Summary		Cria um bolão
+//	@Description	Cadastra um bolão para uma loteria e faixa de concursos. Participantes podem ser enviados já na criação; cada um tem 1 cota se nenhuma for informada.
+//	@Tags			Bolões
+//	@Accept			json
+//	@Produce		json
+//	@Param			bolao	body		model.Bolao	true	"Nome, loteria, faixa de concursos e participantes"
+//	@Success		201		{object}	model.Bolao
+//	@Failure		400		{object}	ErrorResponse
+//	@Router			/boloes [post]
+func (c *BolaoController) CriarBolao(ctx *gin.Context) {
+	var dados model.Bolao
+	if err := ctx.ShouldBindJSON(&dados); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Bad Request",
+			Message: "Invalid pool: " + err.Error(),
+		})
+		return
+	}
+
+	bolao, err := c.bolaoService.Criar(dados)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, bolao)
+}
+
+// GetBolao retorna um bolão com seus bilhetes
+//
+//	@Summary		Busca um bolão
+//	@Description	Retorna o bolão, seus participantes e os bilhetes com a conferência de cada concurso já apurado.
+//	@Tags			Bolões
+//	@Produce		json
+//	@Param			id	path		string	true	"ID do bolão"
+//	@Success		200	{object}	model.BolaoDetalhado
+//	@Failure		404	{object}	ErrorResponse
+//	@Router			/boloes/{id} [get]
+func (c *BolaoController) GetBolao(ctx *gin.Context) {
+	bolao, err := c.bolaoService.Buscar(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, bolao)
+}
+
+// AtualizarBolao altera nome e faixa de concursos de um bolão
+//
+//	@Summary		Atualiza um bolão
+//	@Description	Altera o nome e a faixa de concursos do bolão. Os bilhetes são conferidos novamente quando a faixa muda. A loteria não pode ser alterada.
+//	@Tags			Bolões
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string		true	"ID do bolão"
+//	@Param			bolao	body		model.Bolao	true	"Nome e faixa de concursos"
+//	@Success		200		{object}	model.Bolao
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		404		{object}	ErrorResponse
+//	@Router			/boloes/{id} [put]
+func (c *BolaoController) AtualizarBolao(ctx *gin.Context) {
+	var dados model.Bolao
+	if err := ctx.ShouldBindJSON(&dados); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Bad Request",
+			Message: "Invalid pool: " + err.Error(),
+		})
+		return
+	}
+
+	bolao, err := c.bolaoService.Atualizar(ctx.Param("id"), dados)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, bolao)
+}
+
+// ExcluirBolao remove um bolão
+//
+//	@Summary		Exclui um bolão
+//	@Description	Remove o bolão e todos os seus bilhetes.
+//	@Tags			Bolões
+//	@Param			id	path	string	true	"ID do bolão"
+//	@Success		204
+//	@Failure		404	{object}	ErrorResponse
+//	@Router			/boloes/{id} [delete]
+func (c *BolaoController) ExcluirBolao(ctx *gin.Context) {
+	if err := c.bolaoService.Excluir(ctx.Param("id")); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// AdicionarParticipante inclui um participante no bolão
+//
+//	@Summary		Adiciona um participante
+//	@Description	Inclui um participante com a quantidade de cotas informada (1 se omitida).
+//	@Tags			Bolões
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		string				true	"ID do bolão"
+//	@Param			participante	body		model.Participante	true	"Nome e cotas"
+//	@Success		201				{object}	model.Bolao
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		404				{object}	ErrorResponse
+//	@Router			/boloes/{id}/participantes [post]
+func (c *BolaoController) AdicionarParticipante(ctx *gin.Context) {
+	var participante model.Participante
+	if err := ctx.ShouldBindJSON(&participante); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Bad Request",
+			Message: "Invalid participant: " + err.Error(),
+		})
+		return
+	}
+
+	bolao, err := c.bolaoService.AdicionarParticipante(ctx.Param("id"), participante)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, bolao)
+}
+
+// AtualizarParticipante altera nome e cotas de um participante
+//
+//	@Summary		Atualiza um participante
+//	@Description	Altera o nome e as cotas de um participante do bolão.
+//	@Tags			Bolões
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		string				true	"ID do bolão"
+//	@Param			participanteId	path		string				true	"ID do participante"
+//	@Param			participante	body		model.Participante	true	"Nome e cotas"
+//	@Success		200				{object}	model.Bolao
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		404				{object}	ErrorResponse
+//	@Router			/boloes/{id}/participantes/{participanteId} [put]
+func (c *BolaoController) AtualizarParticipante(ctx *gin.Context) {
+	var participante model.Participante
+	if err := ctx.ShouldBindJSON(&participante); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Bad Request",
+			Message: "Invalid participant: " + err.Error(),
+		})
+		return
+	}
+
+	bolao, err := c.bolaoService.AtualizarParticipante(ctx.Param("id"), ctx.Param("participanteId"), participante)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, bolao)
+}
+
+// RemoverParticipante retira um participante do bolão
+//
+//	@Summary		Remove um participante
+//	@Description	Retira o participante do bolão. As cotas restantes passam a dividir custo e prêmios.
+//	@Tags			Bolões
+//	@Produce		json
+//	@Param			id				path		string	true	"ID do bolão"
+//	@Param			participanteId	path		string	true	"ID do participante"
+//	@Success		200				{object}	model.Bolao
+//	@Failure		404				{object}	ErrorResponse
+//	@Router			/boloes/{id}/participantes/{participanteId} [delete]
+func (c *BolaoController) RemoverParticipante(ctx *gin.Context) {
+	bolao, err := c.bolaoService.RemoverParticipante(ctx.Param("id"), ctx.Param("participanteId"))
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, bolao)
+}
+
+// AdicionarBilhete inclui uma aposta no bolão
+//
+//	@Summary		Adiciona um bilhete
+//	@Description	Valida a aposta contra as regras da loteria do bolão e já a confere nos concursos da faixa que estão armazenados. Novos concursos são conferidos automaticamente a cada atualização.
+//	@Tags			Bolões
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string			true	"ID do bolão"
+//	@Param			aposta	body		model.Aposta	true	"Aposta do bilhete"
+//	@Success		201		{object}	model.BilheteBolao
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		404		{object}	ErrorResponse
+//	@Router			/boloes/{id}/bilhetes [post]
+func (c *BolaoController) AdicionarBilhete(ctx *gin.Context) {
+	var aposta model.Aposta
+	if err := ctx.ShouldBindJSON(&aposta); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Bad Request",
+			Message: "Invalid bet: " + err.Error(),
+		})
+		return
+	}
+
+	bilhete, err := c.bolaoService.AdicionarBilhete(ctx.Param("id"), aposta)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, bilhete)
+}
+
+// RemoverBilhete retira um bilhete do bolão
+//
+//	@Summary		Remove um bilhete
+//	@Description	Retira o bilhete e suas conferências do bolão.
+//	@Tags			Bolões
+//	@Param			id			path	string	true	"ID do bolão"
+//	@Param			bilheteId	path	string	true	"ID do bilhete"
+//	@Success		204
+//	@Failure		404	{object}	ErrorResponse
+//	@Router			/boloes/{id}/bilhetes/{bilheteId} [delete]
+func (c *BolaoController) RemoverBilhete(ctx *gin.Context) {
+	if err := c.bolaoService.RemoverBilhete(ctx.Param("id"), ctx.Param("bilheteId")); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// GetRelatorio retorna o resumo financeiro do bolão
+//
+//	@Summary		Relatório do bolão
+//	@Description	Soma o custo dos bilhetes nos concursos da faixa já apurados e os prêmios, por concurso, e divide os valores entre os participantes na proporção das cotas. Concursos ainda não sorteados não entram no custo.
+//	@Tags			Bolões
+//	@Produce		json
+//	@Param			id	path		string	true	"ID do bolão"
+//	@Success		200	{object}	model.RelatorioBolao
+//	@Failure		404	{object}	ErrorResponse
+//	@Router			/boloes/{id}/relatorio [get]
+func (c *BolaoController) GetRelatorio(ctx *gin.Context) {
+	relatorio, err := c.bolaoService.Relatorio(ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, relatorio)
+}
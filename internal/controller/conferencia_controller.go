@@ -0,0 +1,177 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"loterias-api-golang/internal/model"
+	"loterias-api-golang/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ConferenciaController struct {
+	conferenciaService *service.ConferenciaService
+}
+
+func NewConferenciaController(conferenciaService *service.ConferenciaService) *ConferenciaController {
+	return &ConferenciaController{
+		conferenciaService: conferenciaService,
+	}
+}
+
+// ConferirAposta confere uma aposta contra um concurso
+//
+//	@Summary		Confere uma aposta
+//	@Description	Confere os números jogados contra o concurso informado e retorna acertos, faixas atingidas e valor dos prêmios. Para a Super Sete informe as colunas; para a +Milionária, os trevos.
+//	@Tags			Conferência
+//	@Accept			json
+//	@Produce		json
+//	@Param			loteria		path		string			true	"ID da Loteria"	Enums(maismilionaria, megasena, lotofacil, quina, lotomania, timemania, duplasena, diadesorte, supersete)
+//	@Param			concurso	path		int				true	"Número do Concurso"
+//	@Param			aposta		body		model.Aposta	true	"Aposta a conferir"
+//	@Success		200			{object}	model.Conferencia
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		404			{object}	ErrorResponse
+//	@Router			/{loteria}/{concurso}/conferir [post]
+func (c *ConferenciaController) ConferirAposta(ctx *gin.Context) {
+	loteria := ctx.Param("loteria")
+
+	if !model.IsValid(loteria) {
+		ctx.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Resource Not Found",
+			Message: invalidLotteryMessage(loteria),
+		})
+		return
+	}
+
+	concurso, err := strconv.Atoi(ctx.Param("concurso"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Bad Request",
+			Message: "Invalid contest number",
+		})
+		return
+	}
+
+	var aposta model.Aposta
+	if err := ctx.ShouldBindJSON(&aposta); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Bad Request",
+			Message: "Invalid bet: " + err.Error(),
+		})
+		return
+	}
+
+	conferencia, err := c.conferenciaService.Conferir(loteria, concurso, aposta)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, conferencia)
+}
+
+// ConferirHistorico confere uma aposta contra todo o histórico da loteria
+//
+//	@Summary		Confere uma aposta em todo o histórico
+//	@Description	Confere a mesma aposta em todos os concursos armazenados (ou na janela informada) e retorna quantas vezes cada faixa foi atingida, os concursos premiados e o total que a aposta teria recebido.
+//	@Tags			Conferência
+//	@Accept			json
+//	@Produce		json
+//	@Param			loteria			path		string			true	"ID da Loteria"	Enums(maismilionaria, megasena, lotofacil, quina, lotomania, timemania, duplasena, diadesorte, supersete)
+//	@Param			aposta			body		model.Aposta	true	"Aposta a conferir"
+//	@Param			concursoInicio	query		int				false	"Primeiro concurso considerado"
+//	@Param			concursoFim		query		int				false	"Último concurso considerado"
+//	@Param			dataInicio		query		string			false	"Data inicial (dd/mm/aaaa ou aaaa-mm-dd)"
+//	@Param			dataFim			query		string			false	"Data final (dd/mm/aaaa ou aaaa-mm-dd)"
+//	@Param			ultimos			query		int				false	"Considerar apenas os N concursos mais recentes"
+//	@Success		200				{object}	model.ConferenciaHistorico
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		404				{object}	ErrorResponse
+//	@Router			/{loteria}/conferir-historico [post]
+func (c *ConferenciaController) ConferirHistorico(ctx *gin.Context) {
+	loteria := ctx.Param("loteria")
+
+	if !model.IsValid(loteria) {
+		ctx.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Resource Not Found",
+			Message: invalidLotteryMessage(loteria),
+		})
+		return
+	}
+
+	filtro, err := parseFiltroConcursos(ctx)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	var aposta model.Aposta
+	if err := ctx.ShouldBindJSON(&aposta); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Bad Request",
+			Message: "Invalid bet: " + err.Error(),
+		})
+		return
+	}
+
+	historico, err := c.conferenciaService.ConferirHistorico(loteria, aposta, filtro)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, historico)
+}
+
+// ConferirBilhete confere um bilhete da Loteria Federal
+//
+//	@Summary		Confere um bilhete da Federal
+//	@Description	Confere número e série de um bilhete contra a extração informada e retorna todos os prêmios recebidos: prêmios principais, aproximações ao 1º prêmio e terminações (milhar, centena e dezena). O valor dos prêmios derivados só é preenchido quando consta no rateio publicado pela Caixa.
+//	@Tags			Conferência
+//	@Produce		json
+//	@Param			loteria		path		string	true	"ID da Loteria"	Enums(federal)
+//	@Param			concurso	path		int		true	"Número do Concurso"
+//	@Param			numero		query		string	true	"Número do bilhete (até 5 dígitos)"
+//	@Param			serie		query		string	true	"Série do bilhete"
+//	@Success		200			{object}	model.ConferenciaFederal
+//	@Failure		400			{object}	ErrorResponse
+//	@Failure		404			{object}	ErrorResponse
+//	@Router			/{loteria}/{concurso}/bilhete [get]
+func (c *ConferenciaController) ConferirBilhete(ctx *gin.Context) {
+	loteria := ctx.Param("loteria")
+
+	if !model.IsValid(loteria) {
+		ctx.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Resource Not Found",
+			Message: invalidLotteryMessage(loteria),
+		})
+		return
+	}
+
+	if loteria != string(model.Federal) {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Bad Request",
+			Message: "Conferência de bilhete disponível apenas para a loteria federal",
+		})
+		return
+	}
+
+	concurso, err := strconv.Atoi(ctx.Param("concurso"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Bad Request",
+			Message: "Invalid contest number",
+		})
+		return
+	}
+
+	conferencia, err := c.conferenciaService.ConferirBilhete(concurso, ctx.Query("numero"), ctx.Query("serie"))
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, conferencia)
+}
@@ -0,0 +1,467 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"loterias-api-golang/internal/model"
+	"loterias-api-golang/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type EstatisticaController struct {
+	estatisticaService *service.EstatisticaService
+}
+
+func NewEstatisticaController(estatisticaService *service.EstatisticaService) *EstatisticaController {
+	return &EstatisticaController{
+		estatisticaService: estatisticaService,
+	}
+}
+
+// GetFrequencia retorna a frequência de cada número da loteria
+//
+//	@Summary		Frequência dos números
+//	@Description	Retorna quantas vezes cada número foi sorteado, incluindo os que nunca saíram, ordenados do mais para o menos frequente. Na Super Sete a frequência é calculada por coluna.
+//	@Tags			Estatísticas
+//	@Produce		json
+//	@Param			loteria			path		string	true	"ID da Loteria"	Enums(maismilionaria, megasena, lotofacil, quina, lotomania, timemania, duplasena, diadesorte, supersete)
+//	@Param			concursoInicio	query		int		false	"Primeiro concurso considerado"
+//	@Param			concursoFim		query		int		false	"Último concurso considerado"
+//	@Param			dataInicio		query		string	false	"Data inicial (dd/mm/aaaa ou aaaa-mm-dd)"
+//	@Param			dataFim			query		string	false	"Data final (dd/mm/aaaa ou aaaa-mm-dd)"
+//	@Param			ultimos			query		int		false	"Considerar apenas os N concursos mais recentes"
+//	@Success		200				{object}	model.Frequencia
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		404				{object}	ErrorResponse
+//	@Router			/{loteria}/estatisticas/frequencia [get]
+func (c *EstatisticaController) GetFrequencia(ctx *gin.Context) {
+	loteria := ctx.Param("loteria")
+
+	if !model.IsValid(loteria) {
+		ctx.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Resource Not Found",
+			Message: invalidLotteryMessage(loteria),
+		})
+		return
+	}
+
+	filtro, err := parseFiltroConcursos(ctx)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	frequencia, err := c.estatisticaService.Frequencia(loteria, filtro)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, frequencia)
+}
+
+// GetAtraso retorna o atraso de cada número da loteria
+//
+//	@Summary		Atraso dos números
+//	@Description	Retorna, para cada número, quantos concursos se passaram desde a última vez que foi sorteado, o maior atraso já registrado e o atraso médio, ordenados do mais para o menos atrasado. Os trevos da +Milionária são calculados à parte.
+//	@Tags			Estatísticas
+//	@Produce		json
+//	@Param			loteria			path		string	true	"ID da Loteria"	Enums(maismilionaria, megasena, lotofacil, quina, lotomania, timemania, duplasena, diadesorte, supersete)
+//	@Param			concursoInicio	query		int		false	"Primeiro concurso considerado"
+//	@Param			concursoFim		query		int		false	"Último concurso considerado"
+//	@Param			dataInicio		query		string	false	"Data inicial (dd/mm/aaaa ou aaaa-mm-dd)"
+//	@Param			dataFim			query		string	false	"Data final (dd/mm/aaaa ou aaaa-mm-dd)"
+//	@Param			ultimos			query		int		false	"Considerar apenas os N concursos mais recentes"
+//	@Success		200				{object}	model.Atraso
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		404				{object}	ErrorResponse
+//	@Router			/{loteria}/estatisticas/atraso [get]
+func (c *EstatisticaController) GetAtraso(ctx *gin.Context) {
+	loteria := ctx.Param("loteria")
+
+	if !model.IsValid(loteria) {
+		ctx.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Resource Not Found",
+			Message: invalidLotteryMessage(loteria),
+		})
+		return
+	}
+
+	filtro, err := parseFiltroConcursos(ctx)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	atraso, err := c.estatisticaService.Atraso(loteria, filtro)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, atraso)
+}
+
+// GetCoocorrencia retorna os pares ou trios que mais saem juntos
+//
+//	@Summary		Pares e trios mais frequentes
+//	@Description	Conta quantas vezes cada par ou trio de números saiu no mesmo sorteio e retorna os mais frequentes com a quantidade esperada em sorteios uniformes. Na Dupla Sena os dois sorteios são analisados separadamente.
+//	@Tags			Estatísticas
+//	@Produce		json
+//	@Param			loteria			path		string	true	"ID da Loteria"	Enums(maismilionaria, megasena, lotofacil, quina, lotomania, timemania, duplasena, diadesorte)
+//	@Param			tamanho			query		int		false	"2 para pares, 3 para trios"	default(2)
+//	@Param			top				query		int		false	"Quantidade de combinações retornadas (máx. 500)"	default(20)
+//	@Param			concursoInicio	query		int		false	"Primeiro concurso considerado"
+//	@Param			concursoFim		query		int		false	"Último concurso considerado"
+//	@Param			dataInicio		query		string	false	"Data inicial (dd/mm/aaaa ou aaaa-mm-dd)"
+//	@Param			dataFim			query		string	false	"Data final (dd/mm/aaaa ou aaaa-mm-dd)"
+//	@Param			ultimos			query		int		false	"Considerar apenas os N concursos mais recentes"
+//	@Success		200				{object}	model.Coocorrencia
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		404				{object}	ErrorResponse
+//	@Router			/{loteria}/estatisticas/combinacoes [get]
+func (c *EstatisticaController) GetCoocorrencia(ctx *gin.Context) {
+	loteria := ctx.Param("loteria")
+
+	if !model.IsValid(loteria) {
+		ctx.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Resource Not Found",
+			Message: invalidLotteryMessage(loteria),
+		})
+		return
+	}
+
+	filtro, err := parseFiltroConcursos(ctx)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	tamanho, err := queryIntDefault(ctx, "tamanho", 2)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	top, err := queryIntDefault(ctx, "top", 20)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	coocorrencia, err := c.estatisticaService.Coocorrencia(loteria, filtro, tamanho, top)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, coocorrencia)
+}
+
+// GetAcumulacoes retorna as sequências de concursos acumulados
+//
+//	@Summary		Sequências de acumulação
+//	@Description	Analisa as sequências de concursos seguidos sem ganhador na faixa principal: a sequência atual, a maior, todas as registradas com o crescimento do prêmio e o concurso que a encerrou, e a distribuição dos tamanhos das sequências encerradas.
+//	@Tags			Estatísticas
+//	@Produce		json
+//	@Param			loteria			path		string	true	"ID da Loteria"	Enums(maismilionaria, megasena, lotofacil, quina, lotomania, timemania, duplasena, diadesorte, supersete)
+//	@Param			concursoInicio	query		int		false	"Primeiro concurso considerado"
+//	@Param			concursoFim		query		int		false	"Último concurso considerado"
+//	@Param			dataInicio		query		string	false	"Data inicial (dd/mm/aaaa ou aaaa-mm-dd)"
+//	@Param			dataFim			query		string	false	"Data final (dd/mm/aaaa ou aaaa-mm-dd)"
+//	@Param			ultimos			query		int		false	"Considerar apenas os N concursos mais recentes"
+//	@Success		200				{object}	model.Acumulacoes
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		404				{object}	ErrorResponse
+//	@Router			/{loteria}/estatisticas/acumulacoes [get]
+func (c *EstatisticaController) GetAcumulacoes(ctx *gin.Context) {
+	loteria := ctx.Param("loteria")
+
+	if !model.IsValid(loteria) {
+		ctx.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Resource Not Found",
+			Message: invalidLotteryMessage(loteria),
+		})
+		return
+	}
+
+	filtro, err := parseFiltroConcursos(ctx)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	acumulacoes, err := c.estatisticaService.Acumulacoes(loteria, filtro)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, acumulacoes)
+}
+
+// GetComposicao retorna os histogramas da composição dos sorteios
+//
+//	@Summary		Composição dos sorteios
+//	@Description	Distribuição, nos concursos selecionados, dos atributos calculados para cada sorteio: soma dos números (agrupada em intervalos), pares, primos, números de Fibonacci, números seguidos, maior sequência e quantidade de números em cada década, linha e coluna do volante. A composição de cada concurso também vem no campo composicao do resultado. Na Super Sete apenas soma, pares, primos e Fibonacci se aplicam. No Dia de Sorte, cujo volante de 31 números não é uma grade regular, linhas e colunas são omitidas.
+//	@Tags			Estatísticas
+//	@Produce		json
+//	@Param			loteria			path		string	true	"ID da Loteria"	Enums(maismilionaria, megasena, lotofacil, quina, lotomania, timemania, duplasena, diadesorte, supersete)
+//	@Param			intervalo		query		int		false	"Tamanho dos intervalos da soma (padrão: 10)"
+//	@Param			concursoInicio	query		int		false	"Primeiro concurso considerado"
+//	@Param			concursoFim		query		int		false	"Último concurso considerado"
+//	@Param			dataInicio		query		string	false	"Data inicial (dd/mm/aaaa ou aaaa-mm-dd)"
+//	@Param			dataFim			query		string	false	"Data final (dd/mm/aaaa ou aaaa-mm-dd)"
+//	@Param			ultimos			query		int		false	"Considerar apenas os N concursos mais recentes"
+//	@Success		200				{object}	model.DistribuicaoComposicao
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		404				{object}	ErrorResponse
+//	@Router			/{loteria}/estatisticas/composicao [get]
+func (c *EstatisticaController) GetComposicao(ctx *gin.Context) {
+	loteria := ctx.Param("loteria")
+
+	if !model.IsValid(loteria) {
+		ctx.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Resource Not Found",
+			Message: invalidLotteryMessage(loteria),
+		})
+		return
+	}
+
+	filtro, err := parseFiltroConcursos(ctx)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	intervalo, err := queryIntDefault(ctx, "intervalo", 10)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	composicao, err := c.estatisticaService.Composicao(loteria, filtro, intervalo)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, composicao)
+}
+
+// GetAleatoriedade aplica testes de aleatoriedade ao histórico da loteria
+//
+//	@Summary		Testes de aleatoriedade
+//	@Description	Aplica às dezenas dos concursos selecionados, em ordem de concurso, quatro testes estatísticos: qui-quadrado das frequências contra a distribuição uniforme, sequências acima e abaixo da mediana da soma (Wald-Wolfowitz), intervalos entre aparições de cada número contra a distribuição geométrica e correlação serial da soma. Retorna a estatística, o valor esperado e o p-valor de cada teste, além de um veredito geral com correção de Bonferroni para a quantidade de testes.
+//	@Tags			Estatísticas
+//	@Produce		json
+//	@Param			loteria			path		string	true	"ID da Loteria"	Enums(maismilionaria, megasena, lotofacil, quina, lotomania, timemania, duplasena, diadesorte, supersete)
+//	@Param			significancia	query		number	false	"Nível de significância dos testes (padrão: 0.05)"
+//	@Param			concursoInicio	query		int		false	"Primeiro concurso considerado"
+//	@Param			concursoFim		query		int		false	"Último concurso considerado"
+//	@Param			dataInicio		query		string	false	"Data inicial (dd/mm/aaaa ou aaaa-mm-dd)"
+//	@Param			dataFim			query		string	false	"Data final (dd/mm/aaaa ou aaaa-mm-dd)"
+//	@Param			ultimos			query		int		false	"Considerar apenas os N concursos mais recentes"
+//	@Success		200				{object}	model.Aleatoriedade
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		404				{object}	ErrorResponse
+//	@Router			/{loteria}/estatisticas/aleatoriedade [get]
+func (c *EstatisticaController) GetAleatoriedade(ctx *gin.Context) {
+	loteria := ctx.Param("loteria")
+
+	if !model.IsValid(loteria) {
+		ctx.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Resource Not Found",
+			Message: invalidLotteryMessage(loteria),
+		})
+		return
+	}
+
+	filtro, err := parseFiltroConcursos(ctx)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	significancia, err := queryFloatDefault(ctx, "significancia", 0.05)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	aleatoriedade, err := c.estatisticaService.Aleatoriedade(loteria, filtro, significancia)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, aleatoriedade)
+}
+
+// GetProbabilidades retorna as chances de cada faixa e o valor esperado de uma aposta
+//
+//	@Summary		Probabilidades e valor esperado da aposta
+//	@Description	Calcula com inteiros exatos a chance de uma aposta com N números atingir cada faixa de premiação em um sorteio, o preço da aposta e o valor esperado. A faixa principal usa o prêmio estimado para o próximo concurso, como se houvesse um único ganhador; as demais usam a média paga por ganhador nos concursos selecionados. Na Super Sete os números são distribuídos entre as colunas o mais igualmente possível.
+//	@Tags			Estatísticas
+//	@Produce		json
+//	@Param			loteria			path		string	true	"ID da Loteria"	Enums(maismilionaria, megasena, lotofacil, quina, lotomania, timemania, duplasena, diadesorte, supersete)
+//	@Param			numeros			query		int		false	"Quantidade de números da aposta (padrão: aposta simples)"
+//	@Param			trevos			query		int		false	"Quantidade de trevos da aposta (apenas +Milionária)"
+//	@Param			concursoInicio	query		int		false	"Primeiro concurso considerado na média dos prêmios"
+//	@Param			concursoFim		query		int		false	"Último concurso considerado na média dos prêmios"
+//	@Param			dataInicio		query		string	false	"Data inicial (dd/mm/aaaa ou aaaa-mm-dd)"
+//	@Param			dataFim			query		string	false	"Data final (dd/mm/aaaa ou aaaa-mm-dd)"
+//	@Param			ultimos			query		int		false	"Considerar na média apenas os N concursos mais recentes"
+//	@Success		200				{object}	model.Probabilidades
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		404				{object}	ErrorResponse
+//	@Router			/{loteria}/probabilidades [get]
+func (c *EstatisticaController) GetProbabilidades(ctx *gin.Context) {
+	loteria := ctx.Param("loteria")
+
+	if !model.IsValid(loteria) {
+		ctx.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Resource Not Found",
+			Message: invalidLotteryMessage(loteria),
+		})
+		return
+	}
+
+	filtro, err := parseFiltroConcursos(ctx)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	numeros, err := queryInt(ctx, "numeros")
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	trevos, err := queryInt(ctx, "trevos")
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	probabilidades, err := c.estatisticaService.Probabilidades(loteria, numeros, trevos, filtro)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, probabilidades)
+}
+
+// GetSerie retorna a evolução de um valor da loteria ao longo do tempo
+//
+//	@Summary		Série histórica de arrecadação e prêmios
+//	@Description	Agrupa arrecadação, prêmio acumulado ou estimativa do próximo concurso por concurso, mês ou ano, com soma, média e máximo de cada período. Concursos sem o valor informado ficam fora da soma, da média e do máximo, que ficam nulos nos períodos em que nenhum concurso tem o valor.
+//	@Tags			Estatísticas
+//	@Produce		json
+//	@Param			loteria			path		string	true	"ID da Loteria"	Enums(maismilionaria, megasena, lotofacil, quina, lotomania, timemania, duplasena, federal, diadesorte, supersete)
+//	@Param			campo			query		string	true	"Valor acompanhado"	Enums(valorArrecadado, valorAcumuladoProximoConcurso, valorEstimadoProximoConcurso, valorAcumuladoConcursoEspecial, valorAcumuladoConcurso_0_5)
+//	@Param			agrupar			query		string	false	"Período de agrupamento"	Enums(concurso, mes, ano)	default(concurso)
+//	@Param			concursoInicio	query		int		false	"Primeiro concurso considerado"
+//	@Param			concursoFim		query		int		false	"Último concurso considerado"
+//	@Param			dataInicio		query		string	false	"Data inicial (dd/mm/aaaa ou aaaa-mm-dd)"
+//	@Param			dataFim			query		string	false	"Data final (dd/mm/aaaa ou aaaa-mm-dd)"
+//	@Param			ultimos			query		int		false	"Considerar apenas os N concursos mais recentes"
+//	@Success		200				{object}	model.Serie
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		404				{object}	ErrorResponse
+//	@Router			/{loteria}/series [get]
+func (c *EstatisticaController) GetSerie(ctx *gin.Context) {
+	loteria := ctx.Param("loteria")
+
+	if !model.IsValid(loteria) {
+		ctx.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Resource Not Found",
+			Message: invalidLotteryMessage(loteria),
+		})
+		return
+	}
+
+	filtro, err := parseFiltroConcursos(ctx)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	serie, err := c.estatisticaService.Serie(loteria, filtro, ctx.Query("campo"), ctx.Query("agrupar"))
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, serie)
+}
+
+// parseFiltroConcursos lê a janela de concursos ou datas da query string.
+func parseFiltroConcursos(ctx *gin.Context) (model.FiltroConcursos, error) {
+	var filtro model.FiltroConcursos
+	var err error
+
+	if filtro.ConcursoInicio, err = queryInt(ctx, "concursoInicio"); err != nil {
+		return filtro, err
+	}
+	if filtro.ConcursoFim, err = queryInt(ctx, "concursoFim"); err != nil {
+		return filtro, err
+	}
+	if filtro.Ultimos, err = queryInt(ctx, "ultimos"); err != nil {
+		return filtro, err
+	}
+	if filtro.DataInicio, err = queryDate(ctx, "dataInicio"); err != nil {
+		return filtro, err
+	}
+	if filtro.DataFim, err = queryDate(ctx, "dataFim"); err != nil {
+		return filtro, err
+	}
+
+	return filtro, nil
+}
+
+func queryInt(ctx *gin.Context, nome string) (int, error) {
+	valor := ctx.Query(nome)
+	if valor == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(valor)
+	if err != nil || n < 0 {
+		return 0, &model.ParametroInvalidoException{Message: "Parâmetro '" + nome + "' deve ser um número inteiro positivo"}
+	}
+	return n, nil
+}
+
+func queryIntDefault(ctx *gin.Context, nome string, padrao int) (int, error) {
+	if ctx.Query(nome) == "" {
+		return padrao, nil
+	}
+	return queryInt(ctx, nome)
+}
+
+func queryFloatDefault(ctx *gin.Context, nome string, padrao float64) (float64, error) {
+	valor := ctx.Query(nome)
+	if valor == "" {
+		return padrao, nil
+	}
+	n, err := strconv.ParseFloat(valor, 64)
+	if err != nil || n < 0 {
+		return 0, &model.ParametroInvalidoException{Message: "Parâmetro '" + nome + "' deve ser um número positivo"}
+	}
+	return n, nil
+}
+
+func queryDate(ctx *gin.Context, nome string) (*time.Time, error) {
+	valor := ctx.Query(nome)
+	if valor == "" {
+		return nil, nil
+	}
+	data, ok := model.ParseDataConsulta(valor)
+	if !ok {
+		return nil, &model.ParametroInvalidoException{Message: "Parâmetro '" + nome + "' deve estar no formato dd/mm/aaaa, aaaa-mm-dd ou dd-mm-aaaa"}
+	}
+	return data, nil
+}
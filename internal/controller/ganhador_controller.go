@@ -0,0 +1,216 @@
+package controller
+
+import (
+	"net/http"
+
+	"loterias-api-golang/internal/model"
+	"loterias-api-golang/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GanhadorController struct {
+	ganhadorService *service.GanhadorService
+}
+
+func NewGanhadorController(ganhadorService *service.GanhadorService) *GanhadorController {
+	return &GanhadorController{
+		ganhadorService: ganhadorService,
+	}
+}
+
+// ListarGanhadores retorna os concursos com ganhadores em um local
+//
+//	@Summary		Concursos com ganhadores na UF ou município
+//	@Description	Lista, do mais recente para o mais antigo, os concursos com ganhadores na UF ou no município informados, com a faixa e o valor pago a cada ganhador. Maiúsculas e acentos são ignorados na comparação.
+//	@Tags			Ganhadores
+//	@Produce		json
+//	@Param			loteria			path		string	true	"ID da Loteria"	Enums(maismilionaria, megasena, lotofacil, quina, lotomania, timemania, duplasena, federal, diadesorte, supersete)
+//	@Param			uf				query		string	false	"UF dos ganhadores (ex.: SP)"
+//	@Param			municipio		query		string	false	"Município dos ganhadores"
+//	@Param			faixa			query		int		false	"Considerar apenas esta faixa de premiação"
+//	@Param			limit			query		int		false	"Quantidade de concursos retornados (máx. 500)"	default(50)
+//	@Param			concursoInicio	query		int		false	"Primeiro concurso considerado"
+//	@Param			concursoFim		query		int		false	"Último concurso considerado"
+//	@Param			dataInicio		query		string	false	"Data inicial (dd/mm/aaaa ou aaaa-mm-dd)"
+//	@Param			dataFim			query		string	false	"Data final (dd/mm/aaaa ou aaaa-mm-dd)"
+//	@Param			ultimos			query		int		false	"Considerar apenas os N concursos mais recentes"
+//	@Success		200				{object}	model.ConcursosComGanhadores
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		404				{object}	ErrorResponse
+//	@Router			/{loteria}/ganhadores [get]
+func (c *GanhadorController) ListarGanhadores(ctx *gin.Context) {
+	loteria := ctx.Param("loteria")
+
+	if !model.IsValid(loteria) {
+		ctx.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Resource Not Found",
+			Message: invalidLotteryMessage(loteria),
+		})
+		return
+	}
+
+	filtro, err := parseFiltroConcursos(ctx)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ganhadores := model.FiltroGanhadores{UF: ctx.Query("uf"), Municipio: ctx.Query("municipio")}
+	if ganhadores.Faixa, err = queryInt(ctx, "faixa"); err != nil {
+		respondError(ctx, err)
+		return
+	}
+	if ganhadores.Limite, err = queryInt(ctx, "limit"); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	lista, err := c.ganhadorService.ListarConcursos(loteria, filtro, ganhadores)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, lista)
+}
+
+// GetRankingEstados retorna ganhadores e prêmios somados por estado
+//
+//	@Summary		Ganhadores por estado
+//	@Description	Soma ganhadores e prêmios pagos em cada estado, separando as apostas feitas pela internet (Canal Eletrônico) das feitas em lotéricas. Ganhadores sem UF conhecida entram apenas nos totais.
+//	@Tags			Ganhadores
+//	@Produce		json
+//	@Param			loteria			path		string	true	"ID da Loteria"	Enums(maismilionaria, megasena, lotofacil, quina, lotomania, timemania, duplasena, federal, diadesorte, supersete)
+//	@Param			faixa			query		int		false	"Considerar apenas esta faixa de premiação"
+//	@Param			ordenar			query		string	false	"Critério do ranking"	Enums(ganhadores, valor)	default(ganhadores)
+//	@Param			top				query		int		false	"Quantidade de estados retornados (0 para todos)"
+//	@Param			concursoInicio	query		int		false	"Primeiro concurso considerado"
+//	@Param			concursoFim		query		int		false	"Último concurso considerado"
+//	@Param			dataInicio		query		string	false	"Data inicial (dd/mm/aaaa ou aaaa-mm-dd)"
+//	@Param			dataFim			query		string	false	"Data final (dd/mm/aaaa ou aaaa-mm-dd)"
+//	@Param			ultimos			query		int		false	"Considerar apenas os N concursos mais recentes"
+//	@Success		200				{object}	model.RankingGanhadores
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		404				{object}	ErrorResponse
+//	@Router			/{loteria}/ganhadores/estados [get]
+func (c *GanhadorController) GetRankingEstados(ctx *gin.Context) {
+	c.responderRanking(ctx, model.AgrupamentoEstado)
+}
+
+// GetRankingMunicipios retorna ganhadores e prêmios somados por município
+//
+//	@Summary		Ganhadores por município
+//	@Description	Soma ganhadores e prêmios pagos em cada município, do mais para o menos premiado, separando as apostas feitas pela internet das feitas em lotéricas. Apostas do Canal Eletrônico sem município entram apenas nos totais.
+//	@Tags			Ganhadores
+//	@Produce		json
+//	@Param			loteria			path		string	true	"ID da Loteria"	Enums(maismilionaria, megasena, lotofacil, quina, lotomania, timemania, duplasena, federal, diadesorte, supersete)
+//	@Param			uf				query		string	false	"Considerar apenas os municípios desta UF"
+//	@Param			faixa			query		int		false	"Considerar apenas esta faixa de premiação"
+//	@Param			ordenar			query		string	false	"Critério do ranking"	Enums(ganhadores, valor)	default(ganhadores)
+//	@Param			top				query		int		false	"Quantidade de municípios retornados (0 para todos)"	default(50)
+//	@Param			concursoInicio	query		int		false	"Primeiro concurso considerado"
+//	@Param			concursoFim		query		int		false	"Último concurso considerado"
+//	@Param			dataInicio		query		string	false	"Data inicial (dd/mm/aaaa ou aaaa-mm-dd)"
+//	@Param			dataFim			query		string	false	"Data final (dd/mm/aaaa ou aaaa-mm-dd)"
+//	@Param			ultimos			query		int		false	"Considerar apenas os N concursos mais recentes"
+//	@Success		200				{object}	model.RankingGanhadores
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		404				{object}	ErrorResponse
+//	@Router			/{loteria}/ganhadores/municipios [get]
+func (c *GanhadorController) GetRankingMunicipios(ctx *gin.Context) {
+	c.responderRanking(ctx, model.AgrupamentoMunicipio)
+}
+
+func (c *GanhadorController) responderRanking(ctx *gin.Context, agrupamento string) {
+	loteria := ctx.Param("loteria")
+
+	if !model.IsValid(loteria) {
+		ctx.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Resource Not Found",
+			Message: invalidLotteryMessage(loteria),
+		})
+		return
+	}
+
+	filtro, err := parseFiltroConcursos(ctx)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	var ganhadores model.FiltroGanhadores
+	if agrupamento == model.AgrupamentoMunicipio {
+		ganhadores.UF = ctx.Query("uf")
+	}
+	if ganhadores.Faixa, err = queryInt(ctx, "faixa"); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	topPadrao := 0
+	if agrupamento == model.AgrupamentoMunicipio {
+		topPadrao = 50
+	}
+	top, err := queryIntDefault(ctx, "top", topPadrao)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ranking, err := c.ganhadorService.Ranking(loteria, filtro, agrupamento, ganhadores, ctx.Query("ordenar"), top)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ranking)
+}
+
+// GetRankingPremios retorna os maiores prêmios individuais já pagos
+//
+//	@Summary		Maiores prêmios pagos
+//	@Description	Ranking dos maiores prêmios pagos por aposta vencedora, em todas as loterias ou em uma delas, com concurso, data, faixa, total pago e municípios dos ganhadores.
+//	@Tags			Ganhadores
+//	@Produce		json
+//	@Param			loteria	query		string	false	"ID da Loteria (todas quando omitido)"	Enums(maismilionaria, megasena, lotofacil, quina, lotomania, timemania, duplasena, federal, diadesorte, supersete)
+//	@Param			faixa	query		int		false	"Considerar apenas esta faixa de premiação"
+//	@Param			ano		query		int		false	"Considerar apenas os sorteios deste ano"
+//	@Param			top		query		int		false	"Quantidade de prêmios retornados (máx. 500)"	default(20)
+//	@Success		200		{object}	model.RankingPremios
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		404		{object}	ErrorResponse
+//	@Router			/ranking/premios [get]
+func (c *GanhadorController) GetRankingPremios(ctx *gin.Context) {
+	filtro := model.FiltroPremios{Loteria: ctx.Query("loteria")}
+
+	if filtro.Loteria != "" && !model.IsValid(filtro.Loteria) {
+		ctx.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Resource Not Found",
+			Message: invalidLotteryMessage(filtro.Loteria),
+		})
+		return
+	}
+
+	var err error
+	if filtro.Faixa, err = queryInt(ctx, "faixa"); err != nil {
+		respondError(ctx, err)
+		return
+	}
+	if filtro.Ano, err = queryInt(ctx, "ano"); err != nil {
+		respondError(ctx, err)
+		return
+	}
+	if filtro.Limite, err = queryInt(ctx, "top"); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ranking, err := c.ganhadorService.RankingPremios(filtro)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ranking)
+}
@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"net/http"
+
+	"loterias-api-golang/internal/model"
+	"loterias-api-golang/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GeradorController struct {
+	geradorService *service.GeradorService
+}
+
+func NewGeradorController(geradorService *service.GeradorService) *GeradorController {
+	return &GeradorController{
+		geradorService: geradorService,
+	}
+}
+
+// GerarApostas gera apostas aleatórias (surpresinha)
+//
+//	@Summary		Gera apostas aleatórias
+//	@Description	Gera apostas válidas seguindo as regras da loteria, com filtros opcionais de dezenas fixas, dezenas excluídas, quantidade de pares, faixa de soma e apostas cujo prêmio máximo nunca saiu. Na +Milionária também sorteia os trevos, na Timemania o time do coração, entre os clubes que já saíram nos resultados, e no Dia de Sorte o mês da sorte.
+//	@Tags			Gerador
+//	@Accept			json
+//	@Produce		json
+//	@Param			loteria	path		string				true	"ID da Loteria"	Enums(maismilionaria, megasena, lotofacil, quina, lotomania, timemania, duplasena, diadesorte, supersete)
+//	@Param			pedido	body		model.PedidoGeracao	false	"Quantidade, tamanho e filtros das apostas"
+//	@Success		200		{object}	model.ApostasGeradas
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		404		{object}	ErrorResponse
+//	@Router			/{loteria}/gerar [post]
+func (c *GeradorController) GerarApostas(ctx *gin.Context) {
+	loteria := ctx.Param("loteria")
+
+	if !model.IsValid(loteria) {
+		ctx.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Resource Not Found",
+			Message: invalidLotteryMessage(loteria),
+		})
+		return
+	}
+
+	var pedido model.PedidoGeracao
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&pedido); err != nil {
+			ctx.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Bad Request",
+				Message: "Invalid request: " + err.Error(),
+			})
+			return
+		}
+	}
+
+	apostas, err := c.geradorService.Gerar(loteria, pedido)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, apostas)
+}
+
+// GerarDesdobramento gera um desdobramento com garantia de acertos
+//
+//	@Summary		Gera um desdobramento (fechamento)
+//	@Description	Gera um conjunto reduzido de apostas com as dezenas escolhidas que garante a quantidade de acertos pedida sempre que a condição for atendida, por exemplo uma quadra se 6 das dezenas escolhidas forem sorteadas. A busca (construção gulosa seguida de busca local) roda até o tempo máximo, e as apostas retornadas são conferidas contra todas as combinações possíveis. Pedidos com mais de 250.000 combinações a cobrir ou cuja garantia exija mais de 2.000 apostas são recusados. Na Timemania e no Dia de Sorte, timeCoracao e mesSorte são obrigatórios e repetidos em todas as apostas.
+//	@Tags			Gerador
+//	@Accept			json
+//	@Produce		json
+//	@Param			loteria	path		string						true	"ID da Loteria"	Enums(maismilionaria, megasena, lotofacil, quina, lotomania, timemania, duplasena, diadesorte)
+//	@Param			pedido	body		model.PedidoDesdobramento	true	"Dezenas escolhidas, tamanho das apostas e garantia"
+//	@Success		200		{object}	model.Desdobramento
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		404		{object}	ErrorResponse
+//	@Router			/{loteria}/desdobramento [post]
+func (c *GeradorController) GerarDesdobramento(ctx *gin.Context) {
+	loteria := ctx.Param("loteria")
+
+	if !model.IsValid(loteria) {
+		ctx.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Resource Not Found",
+			Message: invalidLotteryMessage(loteria),
+		})
+		return
+	}
+
+	var pedido model.PedidoDesdobramento
+	if err := ctx.ShouldBindJSON(&pedido); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Bad Request",
+			Message: "Invalid request: " + err.Error(),
+		})
+		return
+	}
+
+	desdobramento, err := c.geradorService.Desdobrar(loteria, pedido)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, desdobramento)
+}
@@ -27,10 +27,39 @@ func (c *RootController) Root(ctx *gin.Context) {
 		"description": "API para consulta de resultados de loterias da Caixa Econômica Federal",
 		"swagger":     "/swagger/index.html",
 		"endpoints": gin.H{
-			"lotteries":  "/api",
-			"by_lottery": "/api/{loteria}",
-			"by_contest": "/api/{loteria}/{concurso}",
-			"latest":     "/api/{loteria}/latest",
+			"lotteries":    "/api",
+			"by_lottery":   "/api/{loteria}?de=&ate=&limit=&cursor=&ordem=&fields=",
+			"by_date":      "/api/sorteios/{data}",
+			"upcoming":     "/api/proximos",
+			"calendar":     "/api/calendario.ics",
+			"lottery_ics":  "/api/{loteria}/calendario.ics",
+			"by_contest":   "/api/{loteria}/{concurso}",
+			"latest":       "/api/{loteria}/latest",
+			"rules":        "/api/{loteria}/regras",
+			"search":       "/api/{loteria}/busca?dezenas=&min=",
+			"specials":     "/api/{loteria}/especiais",
+			"special_fund": "/api/{loteria}/especiais/reserva",
+			"check_bet":    "POST /api/{loteria}/{concurso}/conferir",
+			"check_all":    "POST /api/{loteria}/conferir-historico",
+			"backtest":     "POST /api/{loteria}/backtest",
+			"check_ticket": "/api/federal/{concurso}/bilhete?numero=&serie=",
+			"frequency":    "/api/{loteria}/estatisticas/frequencia",
+			"delay":        "/api/{loteria}/estatisticas/atraso",
+			"pairs":        "/api/{loteria}/estatisticas/combinacoes",
+			"streaks":      "/api/{loteria}/estatisticas/acumulacoes",
+			"composition":  "/api/{loteria}/estatisticas/composicao?intervalo=10",
+			"randomness":   "/api/{loteria}/estatisticas/aleatoriedade?significancia=0.05",
+			"series":       "/api/{loteria}/series?campo=valorArrecadado&agrupar=mes",
+			"odds":         "/api/{loteria}/probabilidades?numeros=",
+			"generate":     "POST /api/{loteria}/gerar",
+			"wheel":        "POST /api/{loteria}/desdobramento",
+			"winners":      "/api/{loteria}/ganhadores?uf=&municipio=",
+			"by_state":     "/api/{loteria}/ganhadores/estados",
+			"by_city":      "/api/{loteria}/ganhadores/municipios?uf=",
+			"top_prizes":   "/api/ranking/premios?loteria=&faixa=&ano=",
+			"pools":        "/api/boloes",
+			"pool":         "/api/boloes/{id}",
+			"pool_report":  "/api/boloes/{id}/relatorio",
 		},
 	})
 }
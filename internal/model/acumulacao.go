@@ -0,0 +1,58 @@
+package model
+
+// AcumulacaoConcurso é a projeção de um resultado usada na análise de
+// acumulações: se a faixa principal ficou sem ganhador e quanto foi pago ou
+// acumulado. Especial marca os concursos especiais, que não acumulam.
+type AcumulacaoConcurso struct {
+	Concurso                      int     `bson:"concurso"`
+	Data                          string  `bson:"data"`
+	Acumulou                      bool    `bson:"acumulou"`
+	ValorAcumuladoProximoConcurso float64 `bson:"valorAcumuladoProximoConcurso"`
+	GanhadoresPrincipal           int     `bson:"ganhadoresPrincipal"`
+	PremioPrincipal               float64 `bson:"premioPrincipal"`
+	Especial                      bool    `bson:"especial"`
+}
+
+// SequenciaAcumulacao é uma série de concursos seguidos sem ganhador na faixa
+// principal. ValorInicial é o prêmio acumulado informado pelo concurso
+// anterior à sequência (zero quando ele não está entre os selecionados) e
+// ValorFinal o acumulado para o concurso seguinte ao último. ConcursoPremiado
+// é o concurso que a encerrou, com o prêmio pago a cada ganhador; fica zerado
+// enquanto a sequência está em andamento. ConcursosEspeciais lista os
+// concursos especiais sorteados durante a sequência, que não a interrompem.
+type SequenciaAcumulacao struct {
+	ConcursoInicio     int     `json:"concursoInicio"`
+	DataInicio         string  `json:"dataInicio"`
+	ConcursoFim        int     `json:"concursoFim"`
+	DataFim            string  `json:"dataFim"`
+	Concursos          int     `json:"concursos"`
+	ValorInicial       float64 `json:"valorInicial"`
+	ValorFinal         float64 `json:"valorFinal"`
+	Crescimento        float64 `json:"crescimento"`
+	EmAndamento        bool    `json:"emAndamento"`
+	ConcursoPremiado   int     `json:"concursoPremiado,omitempty"`
+	Ganhadores         int     `json:"ganhadores,omitempty"`
+	PremioPago         float64 `json:"premioPago,omitempty"`
+	ConcursosEspeciais []int   `json:"concursosEspeciais,omitempty"`
+}
+
+// DistribuicaoSequencia conta quantas sequências encerradas tiveram cada
+// quantidade de concursos.
+type DistribuicaoSequencia struct {
+	Concursos  int     `json:"concursos"`
+	Quantidade int     `json:"quantidade"`
+	Percentual float64 `json:"percentual"`
+}
+
+// Acumulacoes resume as sequências de concursos acumulados de uma loteria.
+// Atual é nula quando o último concurso teve ganhador na faixa principal.
+type Acumulacoes struct {
+	Loteria             string                  `json:"loteria"`
+	Concursos           int                     `json:"concursos"`
+	ConcursosAcumulados int                     `json:"concursosAcumulados"`
+	Atual               *SequenciaAcumulacao    `json:"atual"`
+	Maior               *SequenciaAcumulacao    `json:"maior"`
+	MediaConcursos      float64                 `json:"mediaConcursos"`
+	Sequencias          []SequenciaAcumulacao   `json:"sequencias"`
+	Distribuicao        []DistribuicaoSequencia `json:"distribuicao"`
+}
@@ -0,0 +1,47 @@
+package model
+
+// Testes de aleatoriedade aplicados ao histórico.
+const (
+	TesteQuiQuadrado      = "quiQuadrado"
+	TesteSequencias       = "sequencias"
+	TesteIntervalos       = "intervalos"
+	TesteCorrelacaoSerial = "correlacaoSerial"
+)
+
+// Vereditos dos testes de aleatoriedade.
+const (
+	VereditoConsistente  = "consistente"
+	VereditoDesvio       = "desvio"
+	VereditoInsuficiente = "insuficiente"
+)
+
+// Aleatoriedade reúne os testes estatísticos aplicados aos sorteios
+// selecionados. Cada teste compara sua estatística com a esperada para
+// sorteios uniformes e independentes; o veredito geral corrige a
+// significância pela quantidade de testes (Bonferroni).
+type Aleatoriedade struct {
+	Loteria        string               `json:"loteria"`
+	Concursos      int                  `json:"concursos"`
+	ConcursoInicio int                  `json:"concursoInicio,omitempty"`
+	ConcursoFim    int                  `json:"concursoFim,omitempty"`
+	Sorteios       int                  `json:"sorteios"`
+	Significancia  float64              `json:"significancia"`
+	Testes         []TesteAleatoriedade `json:"testes"`
+	Veredito       string               `json:"veredito"`
+	Conclusao      string               `json:"conclusao"`
+}
+
+// TesteAleatoriedade traz a estatística de um teste, o valor esperado dela
+// sob a hipótese de aleatoriedade e o p-valor. O veredito é "desvio" quando
+// o p-valor fica abaixo da significância e "insuficiente" quando não há
+// sorteios bastantes para a aproximação usada pelo teste.
+type TesteAleatoriedade struct {
+	Teste          string  `json:"teste"`
+	Nome           string  `json:"nome"`
+	Descricao      string  `json:"descricao"`
+	Estatistica    float64 `json:"estatistica"`
+	Esperado       float64 `json:"esperado"`
+	GrausLiberdade int     `json:"grausLiberdade,omitempty"`
+	PValor         float64 `json:"pValor"`
+	Veredito       string  `json:"veredito"`
+}
@@ -0,0 +1,56 @@
+package model
+
+// Estratégias de aposta disponíveis no backtest.
+const (
+	EstrategiaFixas     = "fixas"
+	EstrategiaQuentes   = "quentes"
+	EstrategiaFrias     = "frias"
+	EstrategiaAleatoria = "aleatoria"
+)
+
+// PedidoBacktest descreve a estratégia repetida em cada concurso. Aposta é
+// a aposta jogada pela estratégia "fixas"; nas demais, apenas o time do
+// coração e o mês da sorte são aproveitados. Quentes e frias escolhem os
+// números mais e menos sorteados nos Janela concursos anteriores. Semente
+// torna a estratégia aleatória reproduzível.
+type PedidoBacktest struct {
+	Estrategia string `json:"estrategia"`
+	Aposta     Aposta `json:"aposta"`
+	Numeros    int    `json:"numeros"`
+	Trevos     int    `json:"trevos"`
+	Janela     int    `json:"janela"`
+	Semente    int64  `json:"semente"`
+}
+
+// Backtest resume o desempenho de uma estratégia nos concursos simulados.
+// ROI é o lucro (ou prejuízo) em percentual do custo. Rodadas traz a aposta
+// e o resultado de cada concurso, com o saldo acumulado.
+type Backtest struct {
+	Loteria             string            `json:"loteria"`
+	Estrategia          string            `json:"estrategia"`
+	Janela              int               `json:"janela,omitempty"`
+	Semente             int64             `json:"semente,omitempty"`
+	Concursos           int               `json:"concursos"`
+	ConcursoInicio      int               `json:"concursoInicio,omitempty"`
+	ConcursoFim         int               `json:"concursoFim,omitempty"`
+	Custo               float64           `json:"custo"`
+	ValorTotal          float64           `json:"valorTotal"`
+	Saldo               float64           `json:"saldo"`
+	ROI                 float64           `json:"roi"`
+	ConcursosPremiados  int               `json:"concursosPremiados"`
+	Faixas              []ResumoFaixa     `json:"faixas"`
+	DistribuicaoAcertos []ContagemAcertos `json:"distribuicaoAcertos"`
+	Rodadas             []RodadaBacktest  `json:"rodadas"`
+}
+
+// RodadaBacktest é a aposta feita em um concurso. Acertos traz os acertos
+// em cada sorteio do concurso.
+type RodadaBacktest struct {
+	Concurso int     `json:"concurso"`
+	Data     string  `json:"data"`
+	Aposta   Aposta  `json:"aposta"`
+	Acertos  []int   `json:"acertos"`
+	Custo    float64 `json:"custo"`
+	Premio   float64 `json:"premio"`
+	Saldo    float64 `json:"saldo"`
+}
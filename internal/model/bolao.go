@@ -0,0 +1,77 @@
+package model
+
+import "time"
+
+// Bolao é um grupo de participantes que divide, por cotas, o custo e os
+// prêmios de um conjunto de bilhetes jogados em uma faixa de concursos.
+type Bolao struct {
+	ID             string         `bson:"_id" json:"id"`
+	Nome           string         `bson:"nome" json:"nome"`
+	Loteria        string         `bson:"loteria" json:"loteria"`
+	ConcursoInicio int            `bson:"concursoInicio" json:"concursoInicio"`
+	ConcursoFim    int            `bson:"concursoFim" json:"concursoFim"`
+	Participantes  []Participante `bson:"participantes" json:"participantes"`
+	CriadoEm       time.Time      `bson:"criadoEm" json:"criadoEm"`
+	AtualizadoEm   time.Time      `bson:"atualizadoEm" json:"atualizadoEm"`
+}
+
+type Participante struct {
+	ID    string `bson:"id" json:"id"`
+	Nome  string `bson:"nome" json:"nome"`
+	Cotas int    `bson:"cotas" json:"cotas"`
+}
+
+// BilheteBolao é uma aposta do bolão com a conferência de cada concurso já
+// apurado.
+type BilheteBolao struct {
+	ID           string               `bson:"_id" json:"id"`
+	BolaoID      string               `bson:"bolaoId" json:"bolaoId"`
+	Aposta       Aposta               `bson:"aposta" json:"aposta"`
+	Preco        float64              `bson:"preco" json:"preco"`
+	Conferencias []ConferenciaBilhete `bson:"conferencias" json:"conferencias"`
+	CriadoEm     time.Time            `bson:"criadoEm" json:"criadoEm"`
+}
+
+type ConferenciaBilhete struct {
+	Concurso    int            `bson:"concurso" json:"concurso"`
+	Data        string         `bson:"data" json:"data"`
+	Acertos     []int          `bson:"acertos" json:"acertos"`
+	Premios     []PremioAposta `bson:"premios" json:"premios"`
+	Valor       float64        `bson:"valor" json:"valor"`
+	ConferidoEm time.Time      `bson:"conferidoEm" json:"conferidoEm"`
+}
+
+type BolaoDetalhado struct {
+	Bolao
+	Bilhetes []BilheteBolao `json:"bilhetes"`
+}
+
+type RelatorioBolao struct {
+	Bolao         Bolao                 `json:"bolao"`
+	Bilhetes      int                   `json:"bilhetes"`
+	TotalCotas    int                   `json:"totalCotas"`
+	Custo         float64               `json:"custo"`
+	ValorTotal    float64               `json:"valorTotal"`
+	Saldo         float64               `json:"saldo"`
+	Concursos     []ResumoConcursoBolao `json:"concursos"`
+	Participantes []ParticipacaoBolao   `json:"participantes"`
+}
+
+type ResumoConcursoBolao struct {
+	Concurso          int     `json:"concurso"`
+	Data              string  `json:"data"`
+	BilhetesPremiados int     `json:"bilhetesPremiados"`
+	Valor             float64 `json:"valor"`
+}
+
+// ParticipacaoBolao traz a parte do custo e dos prêmios de um participante,
+// proporcional às suas cotas.
+type ParticipacaoBolao struct {
+	ParticipanteID string  `json:"participanteId"`
+	Nome           string  `json:"nome"`
+	Cotas          int     `json:"cotas"`
+	Percentual     float64 `json:"percentual"`
+	Custo          float64 `json:"custo"`
+	Premio         float64 `json:"premio"`
+	Saldo          float64 `json:"saldo"`
+}
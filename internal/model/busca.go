@@ -0,0 +1,61 @@
+package model
+
+// PedidoBusca traz os números procurados como informados na consulta.
+// Colunas usa o formato "coluna:número" e só vale para a Super Sete. Min e
+// MinTrevos zerados exigem todos os números informados.
+type PedidoBusca struct {
+	Dezenas   []string
+	Trevos    []string
+	Colunas   []string
+	Min       int
+	MinTrevos int
+	Limite    int
+}
+
+// CriterioBusca é o pedido de busca já validado contra as regras do jogo.
+// Colunas mapeia a posição da coluna (a partir de 0) para o número procurado.
+type CriterioBusca struct {
+	Dezenas   []int
+	Trevos    []int
+	Colunas   map[int]int
+	Min       int
+	MinTrevos int
+	Limite    int
+}
+
+type Busca struct {
+	Loteria   string               `json:"loteria"`
+	Dezenas   []string             `json:"dezenas,omitempty"`
+	Trevos    []string             `json:"trevos,omitempty"`
+	Colunas   []ColunaBuscada      `json:"colunas,omitempty"`
+	Min       int                  `json:"min"`
+	MinTrevos int                  `json:"minTrevos,omitempty"`
+	Total     int                  `json:"total"`
+	Concursos []ConcursoEncontrado `json:"concursos"`
+}
+
+type ColunaBuscada struct {
+	Coluna int    `json:"coluna"`
+	Numero string `json:"numero"`
+}
+
+// ConcursoEncontrado traz um concurso que atende à busca e quais dos
+// números procurados foram sorteados nele. Na Super Sete os acertos são
+// contados por coluna.
+type ConcursoEncontrado struct {
+	Concurso           int      `bson:"concurso" json:"concurso"`
+	Data               string   `bson:"data" json:"data"`
+	Dezenas            []string `bson:"dezenas" json:"dezenas"`
+	Trevos             []string `bson:"trevos,omitempty" json:"trevos,omitempty"`
+	Acertos            int      `bson:"acertos" json:"acertos"`
+	DezenasEncontradas []string `bson:"dezenasEncontradas,omitempty" json:"dezenasEncontradas,omitempty"`
+	ColunasEncontradas []int    `bson:"colunasEncontradas,omitempty" json:"colunasEncontradas,omitempty"`
+	AcertosTrevos      int      `bson:"acertosTrevos" json:"acertosTrevos,omitempty"`
+	TrevosEncontrados  []string `bson:"trevosEncontrados,omitempty" json:"trevosEncontrados,omitempty"`
+}
+
+// ConcursosEncontrados é o que a agregação de busca retorna.
+type ConcursosEncontrados struct {
+	Total     int
+	Concursos []ConcursoEncontrado
+}
@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+// HorarioSorteio é o horário de Brasília dos sorteios da Caixa, contado a
+// partir da meia-noite do dia; DuracaoSorteio é a duração dos eventos no
+// calendário.
+const (
+	HorarioSorteio = 20 * time.Hour
+	DuracaoSorteio = time.Hour
+)
+
+// SorteioPrevisto é um sorteio futuro de uma loteria. O primeiro de cada
+// loteria vem do último resultado armazenado e traz o prêmio estimado; os
+// seguintes são projetados pelos dias de sorteio do jogo e não têm
+// estimativa (Previsto).
+type SorteioPrevisto struct {
+	Loteria       string
+	Nome          string
+	Concurso      int
+	Data          time.Time
+	ValorEstimado float64
+	Previsto      bool
+}
@@ -0,0 +1,90 @@
+package model
+
+// Composicao descreve os números de um sorteio do concurso. É calculada na
+// ingestão e gravada no resultado, com um item por sorteio. Decadas conta os
+// números em cada grupo de dez a partir do menor número do volante; Linhas e
+// Colunas, em cada linha e coluna da grade do volante. Consecutivos é a
+// quantidade de pares de números seguidos e MaiorSequencia o tamanho da
+// maior sequência. Na Super Sete os grupos e as sequências não se aplicam.
+type Composicao struct {
+	Sorteio        int   `bson:"sorteio" json:"sorteio"`
+	Soma           int   `bson:"soma" json:"soma"`
+	Pares          int   `bson:"pares" json:"pares"`
+	Impares        int   `bson:"impares" json:"impares"`
+	Primos         int   `bson:"primos" json:"primos"`
+	Fibonacci      int   `bson:"fibonacci" json:"fibonacci"`
+	Decadas        []int `bson:"decadas,omitempty" json:"decadas,omitempty"`
+	Linhas         []int `bson:"linhas,omitempty" json:"linhas,omitempty"`
+	Colunas        []int `bson:"colunas,omitempty" json:"colunas,omitempty"`
+	Consecutivos   int   `bson:"consecutivos" json:"consecutivos"`
+	MaiorSequencia int   `bson:"maiorSequencia" json:"maiorSequencia"`
+}
+
+// ContagemValor é a contagem bruta de um valor retornada pela agregação.
+// Grupo é a posição da década, linha ou coluna nos histogramas por grupo.
+type ContagemValor struct {
+	Grupo      int `bson:"grupo"`
+	Valor      int `bson:"valor"`
+	Quantidade int `bson:"quantidade"`
+}
+
+type ContagemComposicao struct {
+	Concursos      int             `bson:"concursos"`
+	ConcursoInicio int             `bson:"concursoInicio"`
+	ConcursoFim    int             `bson:"concursoFim"`
+	Soma           []ContagemValor `bson:"soma"`
+	Pares          []ContagemValor `bson:"pares"`
+	Primos         []ContagemValor `bson:"primos"`
+	Fibonacci      []ContagemValor `bson:"fibonacci"`
+	Consecutivos   []ContagemValor `bson:"consecutivos"`
+	MaiorSequencia []ContagemValor `bson:"maiorSequencia"`
+	Decadas        []ContagemValor `bson:"decadas"`
+	Linhas         []ContagemValor `bson:"linhas"`
+	Colunas        []ContagemValor `bson:"colunas"`
+}
+
+// DistribuicaoComposicao traz os histogramas da composição dos sorteios.
+// Sorteios pode ser maior que Concursos na Dupla Sena. A soma é agrupada em
+// intervalos de IntervaloSoma; SomaEsperada é a média teórica de um sorteio
+// aleatório.
+type DistribuicaoComposicao struct {
+	Loteria        string            `json:"loteria"`
+	Concursos      int               `json:"concursos"`
+	ConcursoInicio int               `json:"concursoInicio,omitempty"`
+	ConcursoFim    int               `json:"concursoFim,omitempty"`
+	Sorteios       int               `json:"sorteios"`
+	SomaMedia      float64           `json:"somaMedia"`
+	SomaEsperada   float64           `json:"somaEsperada"`
+	IntervaloSoma  int               `json:"intervaloSoma"`
+	Soma           []IntervaloSoma   `json:"soma"`
+	Pares          []Histograma      `json:"pares"`
+	Primos         []Histograma      `json:"primos"`
+	Fibonacci      []Histograma      `json:"fibonacci"`
+	Consecutivos   []Histograma      `json:"consecutivos,omitempty"`
+	MaiorSequencia []Histograma      `json:"maiorSequencia,omitempty"`
+	Decadas        []HistogramaGrupo `json:"decadas,omitempty"`
+	Linhas         []HistogramaGrupo `json:"linhas,omitempty"`
+	Colunas        []HistogramaGrupo `json:"colunas,omitempty"`
+}
+
+// Histograma traz em quantos sorteios o atributo teve o valor informado.
+type Histograma struct {
+	Valor      int     `json:"valor"`
+	Sorteios   int     `json:"sorteios"`
+	Percentual float64 `json:"percentual"`
+}
+
+// IntervaloSoma traz em quantos sorteios a soma ficou entre Inicio e Fim.
+type IntervaloSoma struct {
+	Inicio     int     `json:"inicio"`
+	Fim        int     `json:"fim"`
+	Sorteios   int     `json:"sorteios"`
+	Percentual float64 `json:"percentual"`
+}
+
+// HistogramaGrupo traz, para uma década, linha ou coluna do volante, quantos
+// sorteios tiveram cada quantidade de números no grupo.
+type HistogramaGrupo struct {
+	Grupo      string       `json:"grupo"`
+	Histograma []Histograma `json:"histograma"`
+}
@@ -0,0 +1,82 @@
+package model
+
+// Aposta representa os números jogados em um bilhete. Trevos, time do
+// coração, mês da sorte e colunas são usados apenas pelas loterias que
+// possuem esses elementos.
+type Aposta struct {
+	Dezenas     []string   `json:"dezenas,omitempty"`
+	Trevos      []string   `json:"trevos,omitempty"`
+	TimeCoracao string     `json:"timeCoracao,omitempty"`
+	MesSorte    string     `json:"mesSorte,omitempty"`
+	Colunas     [][]string `json:"colunas,omitempty"`
+}
+
+type Conferencia struct {
+	Loteria    string               `json:"loteria"`
+	Concurso   int                  `json:"concurso"`
+	Data       string               `json:"data"`
+	Sorteios   []ConferenciaSorteio `json:"sorteios"`
+	Premiado   bool                 `json:"premiado"`
+	ValorTotal float64              `json:"valorTotal"`
+}
+
+// ConferenciaSorteio traz os acertos em um sorteio do concurso. Apenas a
+// Dupla Sena possui mais de um sorteio.
+type ConferenciaSorteio struct {
+	Sorteio            int            `json:"sorteio"`
+	Dezenas            []string       `json:"dezenas"`
+	Acertos            int            `json:"acertos"`
+	DezenasAcertadas   []string       `json:"dezenasAcertadas"`
+	TrevosAcertados    []string       `json:"trevosAcertados,omitempty"`
+	AcertouTimeCoracao bool           `json:"acertouTimeCoracao,omitempty"`
+	AcertouMesSorte    bool           `json:"acertouMesSorte,omitempty"`
+	Premios            []PremioAposta `json:"premios"`
+}
+
+// PremioAposta indica uma faixa atingida pela aposta. Apostas com mais
+// números que a aposta simples podem atingir a mesma faixa várias vezes.
+type PremioAposta struct {
+	Faixa         int     `json:"faixa"`
+	Descricao     string  `json:"descricao"`
+	Quantidade    int64   `json:"quantidade"`
+	ValorUnitario float64 `json:"valorUnitario"`
+	Valor         float64 `json:"valor"`
+}
+
+// ConferenciaHistorico resume o desempenho de uma aposta repetida em todos
+// os concursos selecionados. ConcursosIgnorados conta os resultados gravados
+// que não puderam ser conferidos, como os da Super Sete sem as sete colunas.
+type ConferenciaHistorico struct {
+	Loteria             string             `json:"loteria"`
+	Concursos           int                `json:"concursos"`
+	ConcursosIgnorados  int                `json:"concursosIgnorados,omitempty"`
+	ConcursoInicio      int                `json:"concursoInicio,omitempty"`
+	ConcursoFim         int                `json:"concursoFim,omitempty"`
+	Custo               float64            `json:"custo"`
+	ValorTotal          float64            `json:"valorTotal"`
+	Saldo               float64            `json:"saldo"`
+	Faixas              []ResumoFaixa      `json:"faixas"`
+	DistribuicaoAcertos []ContagemAcertos  `json:"distribuicaoAcertos"`
+	ConcursosPremiados  []ConcursoPremiado `json:"concursosPremiados"`
+}
+
+type ResumoFaixa struct {
+	Faixa      int     `json:"faixa"`
+	Descricao  string  `json:"descricao"`
+	Quantidade int64   `json:"quantidade"`
+	Valor      float64 `json:"valor"`
+}
+
+// ContagemAcertos indica em quantos sorteios a aposta fez a quantidade de
+// acertos informada.
+type ContagemAcertos struct {
+	Acertos  int `json:"acertos"`
+	Sorteios int `json:"sorteios"`
+}
+
+type ConcursoPremiado struct {
+	Concurso int            `json:"concurso"`
+	Data     string         `json:"data"`
+	Premios  []PremioAposta `json:"premios"`
+	Valor    float64        `json:"valor"`
+}
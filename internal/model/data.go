@@ -0,0 +1,53 @@
+package model
+
+import (
+	"strings"
+	"time"
+	_ "time/tzdata"
+)
+
+// FormatoDataCaixa é o formato em que a Caixa publica as datas dos sorteios.
+const FormatoDataCaixa = "02/01/2006"
+
+// FusoBrasilia é o fuso dos sorteios. A base de fusos é embutida no binário
+// porque a imagem de produção não mantém o zoneinfo.
+var FusoBrasilia = carregarFusoBrasilia()
+
+func carregarFusoBrasilia() *time.Location {
+	fuso, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		return time.FixedZone("BRT", -3*60*60)
+	}
+	return fuso
+}
+
+// ParseDataCaixa converte uma data "dd/mm/aaaa" para a meia-noite do dia no
+// fuso de Brasília. Retorna nil para datas vazias ou inválidas.
+func ParseDataCaixa(valor string) *time.Time {
+	data, err := time.ParseInLocation(FormatoDataCaixa, strings.TrimSpace(valor), FusoBrasilia)
+	if err != nil {
+		return nil
+	}
+	return &data
+}
+
+// ParseDataConsulta aceita as datas informadas em consultas: dd/mm/aaaa,
+// aaaa-mm-dd ou dd-mm-aaaa, sempre no fuso de Brasília.
+func ParseDataConsulta(valor string) (*time.Time, bool) {
+	for _, layout := range []string{FormatoDataCaixa, "2006-01-02", "02-01-2006"} {
+		if data, err := time.ParseInLocation(layout, strings.TrimSpace(valor), FusoBrasilia); err == nil {
+			return &data, true
+		}
+	}
+	return nil, false
+}
+
+// noFusoBrasilia devolve a data no fuso de Brasília. O driver do MongoDB
+// decodifica datas em UTC.
+func noFusoBrasilia(data *time.Time) *time.Time {
+	if data == nil {
+		return nil
+	}
+	local := data.In(FusoBrasilia)
+	return &local
+}
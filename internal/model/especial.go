@@ -0,0 +1,50 @@
+package model
+
+// IndicadorConcursoEspecial é o valor de indicadorConcursoEspecial com que a
+// Caixa marca os concursos especiais; os regulares vêm com 1.
+const IndicadorConcursoEspecial = 2
+
+// MarcarEspecial define se o resultado é de um concurso especial e, nesse
+// caso, o nome do concurso segundo as regras da loteria.
+func (r *Resultado) MarcarEspecial(especial bool) {
+	r.Especial = especial
+	r.TipoEspecial = ""
+	if especial {
+		if regras, ok := GetRegras(r.ID.Loteria); ok {
+			r.TipoEspecial = regras.ConcursoEspecial
+		}
+	}
+}
+
+// ReservaConcurso é a projeção usada para acompanhar a reserva do concurso
+// especial. Especial é nil nos documentos gravados antes da marcação.
+type ReservaConcurso struct {
+	Concurso int     `bson:"concurso" json:"concurso"`
+	Data     string  `bson:"data" json:"data"`
+	Valor    float64 `bson:"valorAcumuladoConcursoEspecial" json:"valor"`
+	Especial *bool   `bson:"especial" json:"-"`
+}
+
+// ConcursoEspecial resume um concurso especial já realizado.
+type ConcursoEspecial struct {
+	Concurso        int      `json:"concurso"`
+	Data            string   `json:"data"`
+	TipoEspecial    string   `json:"tipoEspecial"`
+	Dezenas         []string `json:"dezenas"`
+	Ganhadores      int      `json:"ganhadores"`
+	PremioPrincipal float64  `json:"premioPrincipal"`
+	ValorArrecadado float64  `json:"valorArrecadado,omitempty"`
+}
+
+// ReservaEspecial mostra quanto já foi reservado para o próximo concurso
+// especial e como a reserva evoluiu desde o último. CrescimentoMedio é o
+// aumento médio por concurso.
+type ReservaEspecial struct {
+	Loteria          string            `json:"loteria"`
+	TipoEspecial     string            `json:"tipoEspecial"`
+	UltimoEspecial   *ConcursoEspecial `json:"ultimoEspecial"`
+	ConcursosDesde   int               `json:"concursosDesde"`
+	ValorAtual       float64           `json:"valorAtual"`
+	CrescimentoMedio float64           `json:"crescimentoMedio"`
+	Evolucao         []ReservaConcurso `json:"evolucao"`
+}
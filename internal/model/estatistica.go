@@ -0,0 +1,117 @@
+package model
+
+import "time"
+
+// FiltroConcursos delimita os concursos considerados em uma estatística.
+// Campos zerados não restringem a consulta; Ultimos limita aos N concursos
+// mais recentes que atendem aos demais filtros.
+type FiltroConcursos struct {
+	ConcursoInicio int
+	ConcursoFim    int
+	DataInicio     *time.Time
+	DataFim        *time.Time
+	Ultimos        int
+}
+
+// ContagemNumero é a contagem bruta de um número retornada pela agregação.
+// Posicao só é preenchida quando a contagem é feita por coluna.
+type ContagemNumero struct {
+	Numero     string `bson:"numero"`
+	Posicao    int    `bson:"posicao"`
+	Quantidade int    `bson:"quantidade"`
+}
+
+type ContagemFrequencia struct {
+	Concursos      int              `bson:"concursos"`
+	ConcursoInicio int              `bson:"concursoInicio"`
+	ConcursoFim    int              `bson:"concursoFim"`
+	Dezenas        []ContagemNumero `bson:"dezenas"`
+	Trevos         []ContagemNumero `bson:"trevos"`
+}
+
+type Frequencia struct {
+	Loteria        string               `json:"loteria"`
+	Concursos      int                  `json:"concursos"`
+	ConcursoInicio int                  `json:"concursoInicio,omitempty"`
+	ConcursoFim    int                  `json:"concursoFim,omitempty"`
+	Dezenas        []FrequenciaNumero   `json:"dezenas,omitempty"`
+	Trevos         []FrequenciaNumero   `json:"trevos,omitempty"`
+	Colunas        [][]FrequenciaNumero `json:"colunas,omitempty"`
+}
+
+// FrequenciaNumero traz quantas vezes um número saiu e o percentual de
+// sorteios em que apareceu.
+type FrequenciaNumero struct {
+	Numero     string  `json:"numero"`
+	Frequencia int     `json:"frequencia"`
+	Percentual float64 `json:"percentual"`
+}
+
+// AparicoesNumero lista os concursos em que um número foi sorteado.
+type AparicoesNumero struct {
+	Numero    string `bson:"numero"`
+	Posicao   int    `bson:"posicao"`
+	Concursos []int  `bson:"concursos"`
+}
+
+type AparicoesConcursos struct {
+	Concursos      int               `bson:"concursos"`
+	ConcursoInicio int               `bson:"concursoInicio"`
+	ConcursoFim    int               `bson:"concursoFim"`
+	Dezenas        []AparicoesNumero `bson:"dezenas"`
+	Trevos         []AparicoesNumero `bson:"trevos"`
+}
+
+type Atraso struct {
+	Loteria        string           `json:"loteria"`
+	Concursos      int              `json:"concursos"`
+	ConcursoInicio int              `json:"concursoInicio,omitempty"`
+	ConcursoFim    int              `json:"concursoFim,omitempty"`
+	Dezenas        []AtrasoNumero   `json:"dezenas,omitempty"`
+	Trevos         []AtrasoNumero   `json:"trevos,omitempty"`
+	Colunas        [][]AtrasoNumero `json:"colunas,omitempty"`
+}
+
+// AtrasoNumero traz há quantos concursos o número não sai, o maior intervalo
+// já registrado e o intervalo médio entre duas aparições.
+type AtrasoNumero struct {
+	Numero         string  `json:"numero"`
+	AtrasoAtual    int     `json:"atrasoAtual"`
+	AtrasoMaximo   int     `json:"atrasoMaximo"`
+	AtrasoMedio    float64 `json:"atrasoMedio"`
+	Frequencia     int     `json:"frequencia"`
+	UltimoConcurso int     `json:"ultimoConcurso,omitempty"`
+}
+
+// DezenasConcurso é a projeção mínima de um resultado usada pelas análises
+// que percorrem o histórico.
+type DezenasConcurso struct {
+	Concurso int      `bson:"concurso" json:"concurso"`
+	Data     string   `bson:"data" json:"data"`
+	Dezenas  []string `bson:"dezenas" json:"dezenas"`
+	Trevos   []string `bson:"trevos,omitempty" json:"trevos,omitempty"`
+}
+
+type Coocorrencia struct {
+	Loteria        string                `json:"loteria"`
+	Tamanho        int                   `json:"tamanho"`
+	Concursos      int                   `json:"concursos"`
+	ConcursoInicio int                   `json:"concursoInicio,omitempty"`
+	ConcursoFim    int                   `json:"concursoFim,omitempty"`
+	Sorteios       []CoocorrenciaSorteio `json:"sorteios"`
+}
+
+// CoocorrenciaSorteio traz as combinações mais frequentes de um sorteio.
+// Esperado é quantas vezes qualquer combinação deveria sair se os sorteios
+// fossem uniformes.
+type CoocorrenciaSorteio struct {
+	Sorteio     int                   `json:"sorteio"`
+	Esperado    float64               `json:"esperado"`
+	Combinacoes []CombinacaoFrequente `json:"combinacoes"`
+}
+
+type CombinacaoFrequente struct {
+	Numeros     []string `json:"numeros"`
+	Ocorrencias int      `json:"ocorrencias"`
+	Razao       float64  `json:"razao"`
+}
@@ -15,3 +15,19 @@ type ResourceNotFoundException struct {
 func (e *ResourceNotFoundException) Error() string {
 	return e.Message
 }
+
+type ApostaInvalidaException struct {
+	Message string
+}
+
+func (e *ApostaInvalidaException) Error() string {
+	return e.Message
+}
+
+type ParametroInvalidoException struct {
+	Message string
+}
+
+func (e *ParametroInvalidoException) Error() string {
+	return e.Message
+}
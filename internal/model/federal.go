@@ -0,0 +1,219 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Tipos de prêmio da Loteria Federal.
+const (
+	PremioFederalPrincipal   = "principal"
+	PremioFederalAproximacao = "aproximacao"
+	PremioFederalMilhar      = "milhar"
+	PremioFederalCentena     = "centena"
+	PremioFederalDezena      = "dezena"
+)
+
+// ResultadoFederal traz os cinco bilhetes premiados de uma extração da
+// Loteria Federal e os prêmios derivados do 1º prêmio.
+type ResultadoFederal struct {
+	Bilhetes         []BilhetePremiado `bson:"bilhetes" json:"bilhetes"`
+	PremiosDerivados []PremioDerivado  `bson:"premiosDerivados" json:"premiosDerivados"`
+}
+
+type BilhetePremiado struct {
+	Premio  int          `bson:"premio" json:"premio"`
+	Bilhete string       `bson:"bilhete" json:"bilhete"`
+	Valor   float64      `bson:"valor" json:"valor"`
+	Locais  []LocalVenda `bson:"locais,omitempty" json:"locais,omitempty"`
+}
+
+// LocalVenda é a cidade e a lotérica onde uma série do bilhete foi vendida.
+type LocalVenda struct {
+	Serie           string `bson:"serie,omitempty" json:"serie,omitempty"`
+	Municipio       string `bson:"municipio" json:"municipio"`
+	UF              string `bson:"uf" json:"uf"`
+	Estabelecimento string `bson:"estabelecimento,omitempty" json:"estabelecimento,omitempty"`
+}
+
+// PremioDerivado descreve os bilhetes premiados por terminação ou por
+// aproximação ao 1º prêmio. Terminacoes traz os finais que recebem o prêmio:
+// um só na milhar e na centena, os do 1º ao 3º prêmio na dezena. Valor fica
+// zerado quando a Caixa não publica o rateio da faixa.
+type PremioDerivado struct {
+	Tipo        string   `bson:"tipo" json:"tipo"`
+	Descricao   string   `bson:"descricao" json:"descricao"`
+	Terminacoes []string `bson:"terminacoes,omitempty" json:"terminacoes,omitempty"`
+	Bilhetes    []string `bson:"bilhetes,omitempty" json:"bilhetes,omitempty"`
+	Valor       float64  `bson:"valor" json:"valor"`
+}
+
+type ConferenciaFederal struct {
+	Concurso   int             `json:"concurso"`
+	Data       string          `json:"data"`
+	Bilhete    string          `json:"bilhete"`
+	Serie      string          `json:"serie"`
+	Premiado   bool            `json:"premiado"`
+	Premios    []PremioFederal `json:"premios"`
+	ValorTotal float64         `json:"valorTotal"`
+}
+
+type PremioFederal struct {
+	Tipo      string       `json:"tipo"`
+	Descricao string       `json:"descricao"`
+	Valor     float64      `json:"valor"`
+	Locais    []LocalVenda `json:"locais,omitempty"`
+}
+
+// terminacoesFederal são os prêmios por terminação, do mais valioso para o
+// menos valioso, com a quantidade de prêmios principais cujos finais são
+// premiados. Um bilhete recebe apenas o primeiro que atingir.
+var terminacoesFederal = []struct {
+	tipo      string
+	nome      string
+	descricao string
+	digitos   int
+	premios   int
+}{
+	{PremioFederalMilhar, "Milhar", "Milhar do 1º prêmio", 4, 1},
+	{PremioFederalCentena, "Centena", "Centena do 1º prêmio", 3, 1},
+	{PremioFederalDezena, "Dezena", "Dezena do 1º ao 3º prêmio", 2, 3},
+}
+
+// FormatarBilhete normaliza um número de bilhete da Federal com cinco
+// dígitos.
+func FormatarBilhete(valor string) (string, error) {
+	n, err := strconv.Atoi(strings.TrimSpace(valor))
+	if err != nil || n < 0 || n > 99999 {
+		return "", fmt.Errorf("'%s' não é um bilhete válido", valor)
+	}
+	return fmt.Sprintf("%05d", n), nil
+}
+
+// MontarFederal monta a estrutura da Federal a partir dos campos genéricos:
+// Dezenas traz os bilhetes na ordem dos prêmios e LocalGanhadores a série e o
+// local de venda de cada um.
+func (r *Resultado) MontarFederal() {
+	if r.ID.Loteria != string(Federal) && r.Loteria != string(Federal) {
+		return
+	}
+
+	federal := &ResultadoFederal{Bilhetes: []BilhetePremiado{}, PremiosDerivados: []PremioDerivado{}}
+	for i, d := range r.Dezenas {
+		bilhete, err := FormatarBilhete(d)
+		if err != nil {
+			continue
+		}
+		premiado := BilhetePremiado{Premio: i + 1, Bilhete: bilhete}
+		for _, p := range r.Premiacoes {
+			if p.Faixa == i+1 {
+				premiado.Valor = p.Valor
+			}
+		}
+		for _, l := range r.LocalGanhadores {
+			if l.Posicao == i+1 {
+				premiado.Locais = append(premiado.Locais, LocalVenda{
+					Serie:           l.Serie,
+					Municipio:       l.Municipio,
+					UF:              l.UF,
+					Estabelecimento: l.Estabelecimento,
+				})
+			}
+		}
+		federal.Bilhetes = append(federal.Bilhetes, premiado)
+	}
+	if len(federal.Bilhetes) == 0 {
+		return
+	}
+
+	primeiro, _ := strconv.Atoi(federal.Bilhetes[0].Bilhete)
+	federal.PremiosDerivados = append(federal.PremiosDerivados, PremioDerivado{
+		Tipo:      PremioFederalAproximacao,
+		Descricao: "Aproximação ao 1º prêmio",
+		Bilhetes:  []string{fmt.Sprintf("%05d", (primeiro+99999)%100000), fmt.Sprintf("%05d", (primeiro+1)%100000)},
+		Valor:     r.valorPremioFederal("aproxima"),
+	})
+	for _, t := range terminacoesFederal {
+		derivado := PremioDerivado{
+			Tipo:        t.tipo,
+			Descricao:   t.descricao,
+			Terminacoes: []string{},
+			Valor:       r.valorPremioFederal(strings.ToLower(t.nome)),
+		}
+		for _, b := range federal.Bilhetes {
+			if b.Premio > t.premios {
+				continue
+			}
+			terminacao := b.Bilhete[5-t.digitos:]
+			if !slices.Contains(derivado.Terminacoes, terminacao) {
+				derivado.Terminacoes = append(derivado.Terminacoes, terminacao)
+			}
+		}
+		federal.PremiosDerivados = append(federal.PremiosDerivados, derivado)
+	}
+
+	r.Federal = federal
+}
+
+// valorPremioFederal procura nas faixas publicadas além dos cinco prêmios
+// principais uma cuja descrição contenha o termo.
+func (r *Resultado) valorPremioFederal(termo string) float64 {
+	for _, p := range r.Premiacoes {
+		if p.Faixa > 5 && strings.Contains(strings.ToLower(p.Descricao), termo) {
+			return p.Valor
+		}
+	}
+	return 0
+}
+
+// PremiosDoBilhete retorna todos os prêmios que o bilhete recebe na
+// extração. Os prêmios principais não acumulam os prêmios de terminação, que
+// são exclusivos entre si.
+func (f *ResultadoFederal) PremiosDoBilhete(bilhete, serie string) []PremioFederal {
+	premios := []PremioFederal{}
+	principal := false
+
+	for _, b := range f.Bilhetes {
+		if b.Bilhete != bilhete {
+			continue
+		}
+		premio := PremioFederal{
+			Tipo:      PremioFederalPrincipal,
+			Descricao: fmt.Sprintf("%dº prêmio", b.Premio),
+			Valor:     b.Valor,
+		}
+		for _, l := range b.Locais {
+			if serie == "" || strings.TrimLeft(l.Serie, "0") == strings.TrimLeft(serie, "0") {
+				premio.Locais = append(premio.Locais, l)
+			}
+		}
+		premios = append(premios, premio)
+		principal = true
+	}
+
+	terminacaoPaga := false
+	for _, d := range f.PremiosDerivados {
+		ganhou := false
+		switch d.Tipo {
+		case PremioFederalAproximacao:
+			for _, b := range d.Bilhetes {
+				ganhou = ganhou || b == bilhete
+			}
+		default:
+			if principal || terminacaoPaga {
+				break
+			}
+			for _, t := range d.Terminacoes {
+				ganhou = ganhou || strings.HasSuffix(bilhete, t)
+			}
+			terminacaoPaga = ganhou
+		}
+		if ganhou {
+			premios = append(premios, PremioFederal{Tipo: d.Tipo, Descricao: d.Descricao, Valor: d.Valor})
+		}
+	}
+
+	return premios
+}
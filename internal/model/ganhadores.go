@@ -0,0 +1,149 @@
+package model
+
+import "strings"
+
+const (
+	AgrupamentoEstado    = "estado"
+	AgrupamentoMunicipio = "municipio"
+
+	OrdenarPorGanhadores = "ganhadores"
+	OrdenarPorValor      = "valor"
+)
+
+// CanalEletronico indica se a aposta premiada foi feita pela internet. A
+// Caixa informa essas apostas com o município "CANAL ELETRONICO".
+func (m MunicipioUFGanhadores) CanalEletronico() bool {
+	return strings.Contains(strings.ToUpper(m.Municipio), "CANAL ELETR")
+}
+
+// FaixaDoGanhador retorna a faixa premiada de um item de localGanhadores. A
+// Caixa só informa o local dos ganhadores da faixa principal e Posicao é
+// apenas a ordem do item; na Federal, Posicao é o prêmio do bilhete.
+func (m MunicipioUFGanhadores) FaixaDoGanhador(loteria string) int {
+	if loteria == string(Federal) {
+		return m.Posicao
+	}
+	return 1
+}
+
+var semAcentos = strings.NewReplacer(
+	"Á", "A", "À", "A", "Â", "A", "Ã", "A", "É", "E", "Ê", "E", "Í", "I",
+	"Ó", "O", "Ô", "O", "Õ", "O", "Ú", "U", "Ü", "U", "Ç", "C",
+)
+
+// NormalizarLocal coloca nomes de município e UF em maiúsculas e sem
+// acentos, como a Caixa costuma publicar.
+func NormalizarLocal(valor string) string {
+	return semAcentos.Replace(strings.ToUpper(strings.TrimSpace(valor)))
+}
+
+// FiltroGanhadores seleciona os ganhadores por local. UF e Municipio são
+// comparados sem diferenciar maiúsculas e acentos; Faixa zerada considera
+// todas as faixas.
+type FiltroGanhadores struct {
+	UF        string
+	Municipio string
+	Faixa     int
+	Limite    int
+}
+
+type ConcursosComGanhadores struct {
+	Loteria   string                  `json:"loteria"`
+	UF        string                  `json:"uf,omitempty"`
+	Municipio string                  `json:"municipio,omitempty"`
+	Total     int                     `json:"total"`
+	Concursos []ConcursoComGanhadores `json:"concursos"`
+}
+
+// ConcursoComGanhadores traz os ganhadores de um concurso no local
+// procurado, com o valor pago a cada um.
+type ConcursoComGanhadores struct {
+	Concurso   int             `json:"concurso"`
+	Data       string          `json:"data"`
+	Ganhadores []GanhadorLocal `json:"ganhadores"`
+}
+
+type GanhadorLocal struct {
+	Faixa           int     `json:"faixa"`
+	Municipio       string  `json:"municipio"`
+	UF              string  `json:"uf"`
+	Ganhadores      int     `json:"ganhadores"`
+	ValorUnitario   float64 `json:"valorUnitario"`
+	CanalEletronico bool    `json:"canalEletronico"`
+	Estabelecimento string  `json:"estabelecimento,omitempty"`
+}
+
+// AgregadoGanhadores é a soma por local retornada pela agregação. Os campos
+// de canal são a parte dos ganhadores que apostou pela internet; Concursos
+// lista os concursos com ganhadores no local.
+type AgregadoGanhadores struct {
+	UF              string  `bson:"uf"`
+	Municipio       string  `bson:"municipio"`
+	Ganhadores      int     `bson:"ganhadores"`
+	Valor           float64 `bson:"valor"`
+	GanhadoresCanal int     `bson:"ganhadoresCanal"`
+	ValorCanal      float64 `bson:"valorCanal"`
+	Concursos       []int   `bson:"concursos"`
+}
+
+// RankingGanhadores soma ganhadores e prêmios por estado ou município.
+// Apostas pela internet sem UF entram apenas nos totais.
+type RankingGanhadores struct {
+	Loteria         string          `json:"loteria"`
+	Agrupamento     string          `json:"agrupamento"`
+	Faixa           int             `json:"faixa,omitempty"`
+	Total           TotalGanhadores `json:"total"`
+	CanalEletronico TotalGanhadores `json:"canalEletronico"`
+	Lotericas       TotalGanhadores `json:"lotericas"`
+	Locais          []LocalPremiado `json:"locais"`
+}
+
+type TotalGanhadores struct {
+	Ganhadores int     `json:"ganhadores"`
+	Valor      float64 `json:"valor"`
+}
+
+type LocalPremiado struct {
+	UF              string          `json:"uf"`
+	Municipio       string          `json:"municipio,omitempty"`
+	Ganhadores      int             `json:"ganhadores"`
+	Valor           float64         `json:"valor"`
+	Concursos       int             `json:"concursos"`
+	CanalEletronico TotalGanhadores `json:"canalEletronico"`
+	Lotericas       TotalGanhadores `json:"lotericas"`
+}
+
+// FiltroPremios seleciona os prêmios do ranking. Loteria vazia considera
+// todas as loterias; Faixa e Ano zerados não restringem.
+type FiltroPremios struct {
+	Loteria string
+	Faixa   int
+	Ano     int
+	Limite  int
+}
+
+// RankingPremios lista os maiores prêmios individuais pagos, do maior para
+// o menor.
+type RankingPremios struct {
+	Loteria string       `json:"loteria,omitempty"`
+	Faixa   int          `json:"faixa,omitempty"`
+	Ano     int          `json:"ano,omitempty"`
+	Premios []PremioPago `json:"premios"`
+}
+
+// PremioPago é uma faixa premiada de um concurso. ValorPorGanhador é o que
+// cada aposta vencedora recebeu e ValorTotal a soma paga na faixa; Locais
+// são os municípios das apostas vencedoras, quando a Caixa os informa.
+type PremioPago struct {
+	Loteria          string          `bson:"loteria" json:"loteria"`
+	Concurso         int             `bson:"concurso" json:"concurso"`
+	Data             string          `bson:"data" json:"data"`
+	Faixa            int             `bson:"faixa" json:"faixa"`
+	Descricao        string          `bson:"descricao" json:"descricao"`
+	Ganhadores       int             `bson:"ganhadores" json:"ganhadores"`
+	ValorPorGanhador float64         `bson:"valorPorGanhador" json:"valorPorGanhador"`
+	ValorTotal       float64         `bson:"valorTotal" json:"valorTotal"`
+	Especial         bool            `bson:"especial" json:"especial"`
+	TipoEspecial     string          `bson:"tipoEspecial" json:"tipoEspecial,omitempty"`
+	Locais           []GanhadorLocal `bson:"locais" json:"locais"`
+}
@@ -0,0 +1,80 @@
+package model
+
+// PedidoGeracao configura a geração de apostas aleatórias. Números e trevos
+// zerados usam a aposta simples da loteria. Os filtros se aplicam às dezenas
+// e não estão disponíveis para a Super Sete.
+type PedidoGeracao struct {
+	Quantidade int      `json:"quantidade"`
+	Numeros    int      `json:"numeros"`
+	Trevos     int      `json:"trevos"`
+	Fixos      []string `json:"fixos"`
+	Excluidos  []string `json:"excluidos"`
+	Pares      *int     `json:"pares"`
+	SomaMinima int      `json:"somaMinima"`
+	SomaMaxima int      `json:"somaMaxima"`
+	Inedita    bool     `json:"inedita"`
+}
+
+type ApostaGerada struct {
+	Aposta
+	Preco float64 `json:"preco"`
+}
+
+type ApostasGeradas struct {
+	Loteria    string         `json:"loteria"`
+	Apostas    []ApostaGerada `json:"apostas"`
+	ValorTotal float64        `json:"valorTotal"`
+}
+
+// PedidoDesdobramento configura um desdobramento (fechamento): apostas de
+// Numeros números, formadas apenas pelas Dezenas escolhidas, que garantem
+// Garantia acertos em alguma aposta sempre que Condicao das dezenas
+// escolhidas forem sorteadas. Trevos, time do coração e mês da sorte são
+// repetidos em todas as apostas. TempoMaximo limita a busca, em segundos.
+type PedidoDesdobramento struct {
+	Dezenas     []string `json:"dezenas"`
+	Numeros     int      `json:"numeros"`
+	Garantia    int      `json:"garantia"`
+	Condicao    int      `json:"condicao"`
+	Trevos      []string `json:"trevos"`
+	TimeCoracao string   `json:"timeCoracao"`
+	MesSorte    string   `json:"mesSorte"`
+	TempoMaximo int      `json:"tempoMaximo"`
+}
+
+// Desdobramento traz as apostas geradas, o custo e a verificação da
+// garantia. CombinacaoCompleta é o custo de jogar todas as apostas possíveis
+// com as dezenas escolhidas, para comparação.
+type Desdobramento struct {
+	Loteria            string                 `json:"loteria"`
+	Dezenas            []string               `json:"dezenas"`
+	Garantia           int                    `json:"garantia"`
+	Condicao           int                    `json:"condicao"`
+	Faixa              string                 `json:"faixa"`
+	Apostas            []ApostaGerada         `json:"apostas"`
+	ValorTotal         float64                `json:"valorTotal"`
+	CombinacaoCompleta CombinacaoCompleta     `json:"combinacaoCompleta"`
+	Cobertura          CoberturaDesdobramento `json:"cobertura"`
+}
+
+type CombinacaoCompleta struct {
+	Apostas int64   `json:"apostas"`
+	Valor   float64 `json:"valor"`
+}
+
+// CoberturaDesdobramento confere as apostas contra todas as combinações de
+// Condicao dezenas escolhidas que podem ser sorteadas. Garantida indica que
+// todas dão ao menos a quantidade de acertos garantida; Distribuicao conta
+// as combinações pelo maior número de acertos obtido em alguma aposta.
+type CoberturaDesdobramento struct {
+	Combinacoes  int                `json:"combinacoes"`
+	Cobertas     int                `json:"cobertas"`
+	Percentual   float64            `json:"percentual"`
+	Garantida    bool               `json:"garantida"`
+	Distribuicao []AcertosCobertura `json:"distribuicao"`
+}
+
+type AcertosCobertura struct {
+	Acertos     int `json:"acertos"`
+	Combinacoes int `json:"combinacoes"`
+}
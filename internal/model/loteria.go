@@ -31,10 +31,6 @@ func AllLoterias() []string {
 }
 
 func IsValid(loteria string) bool {
-	for _, l := range AllLoterias() {
-		if l == loteria {
-			return true
-		}
-	}
-	return false
+	_, ok := registroRegras[Loteria(loteria)]
+	return ok
 }
@@ -0,0 +1,165 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+)
+
+const (
+	OrdemCrescente   = "asc"
+	OrdemDecrescente = "desc"
+)
+
+// Paginacao configura a listagem de resultados de uma loteria. Limite zerado
+// retorna todos os concursos, como a listagem faz quando a requisição não
+// traz limit nem cursor. Campos traz os
+// nomes gravados no banco dos campos a projetar; vazio retorna o documento
+// completo.
+type Paginacao struct {
+	Limite int
+	Cursor *Cursor
+	Ordem  string
+	Campos []string
+}
+
+// Cursor marca a borda de uma página: a página seguinte começa depois do
+// concurso, ou termina antes dele quando Anterior é verdadeiro.
+type Cursor struct {
+	Concurso int  `json:"c"`
+	Anterior bool `json:"a,omitempty"`
+}
+
+// String codifica o cursor no formato opaco usado nos links de paginação.
+func (c Cursor) String() string {
+	dados, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(dados)
+}
+
+// ParseCursor decodifica um cursor gerado por Cursor.String.
+func ParseCursor(valor string) (*Cursor, error) {
+	dados, err := base64.RawURLEncoding.DecodeString(valor)
+	if err != nil {
+		return nil, errors.New("cursor inválido")
+	}
+	var cursor Cursor
+	if err := json.Unmarshal(dados, &cursor); err != nil || cursor.Concurso < 1 {
+		return nil, errors.New("cursor inválido")
+	}
+	return &cursor, nil
+}
+
+// PaginaConcursos é o que o repositório retorna para uma página: os
+// resultados na ordem pedida, o total de concursos que atendem ao filtro e
+// se há páginas antes e depois desta.
+type PaginaConcursos struct {
+	Resultados  []Resultado
+	Total       int64
+	TemAnterior bool
+	TemProximo  bool
+}
+
+// Sentido retorna a ordem de leitura no banco: 1 para crescente e -1 para
+// decrescente. A página anterior é lida no sentido inverso ao pedido, a
+// partir do cursor, e depois revertida.
+func (p Paginacao) Sentido() int {
+	ordem := -1
+	if p.Ordem == OrdemCrescente {
+		ordem = 1
+	}
+	if p.Cursor != nil && p.Cursor.Anterior {
+		return -ordem
+	}
+	return ordem
+}
+
+// MontarPagina monta a página a partir dos resultados lidos no sentido de
+// leitura, com até um concurso além do limite: esse concurso extra só indica
+// que existe outra página no sentido percorrido e é descartado.
+func (p Paginacao) MontarPagina(lidos []Resultado, total int64) *PaginaConcursos {
+	pagina := &PaginaConcursos{Total: total}
+	mais := p.Limite > 0 && len(lidos) > p.Limite
+	if mais {
+		lidos = lidos[:p.Limite]
+	}
+	if p.Cursor != nil && p.Cursor.Anterior {
+		for i, j := 0, len(lidos)-1; i < j; i, j = i+1, j-1 {
+			lidos[i], lidos[j] = lidos[j], lidos[i]
+		}
+		pagina.TemAnterior = mais
+		pagina.TemProximo = len(lidos) > 0
+	} else {
+		pagina.TemProximo = mais
+		pagina.TemAnterior = p.Cursor != nil && len(lidos) > 0
+	}
+	pagina.Resultados = lidos
+	return pagina
+}
+
+// PaginaResultados é a resposta paginada da listagem de resultados.
+// Proximo e Anterior são links para as páginas vizinhas e ficam vazios nas
+// pontas.
+type PaginaResultados struct {
+	Total      int64  `json:"total"`
+	Limite     int    `json:"limite"`
+	Ordem      string `json:"ordem"`
+	Proximo    string `json:"proximo,omitempty"`
+	Anterior   string `json:"anterior,omitempty"`
+	Resultados any    `json:"resultados" swaggertype:"array,object"`
+}
+
+// camposResultado mapeia o nome de cada campo de Resultado na API para o
+// nome gravado no banco. Loteria e concurso vêm sempre do _id.
+var camposResultado = mapearCamposResultado()
+
+func mapearCamposResultado() map[string]string {
+	campos := map[string]string{"loteria": "_id", "concurso": "_id"}
+	tipo := reflect.TypeOf(Resultado{})
+	for i := 0; i < tipo.NumField(); i++ {
+		campo := tipo.Field(i)
+		nomeJSON := strings.Split(campo.Tag.Get("json"), ",")[0]
+		nomeBSON := strings.Split(campo.Tag.Get("bson"), ",")[0]
+		if nomeJSON == "" || nomeJSON == "-" || nomeBSON == "" || nomeBSON == "-" {
+			continue
+		}
+		campos[nomeJSON] = nomeBSON
+	}
+	return campos
+}
+
+// CampoResultado retorna o nome no banco de um campo de Resultado informado
+// pelo nome usado na API.
+func CampoResultado(nome string) (string, bool) {
+	campo, ok := camposResultado[nome]
+	return campo, ok
+}
+
+// ProjetarResultado mantém apenas os campos informados, pelo nome usado na
+// API, além de loteria e concurso. O resultado deve vir da projeção no banco
+// sem AfterFind: os campos derivados seriam montados a partir de um
+// documento incompleto. Loteria e concurso são lidos do _id.
+func ProjetarResultado(resultado Resultado, campos []string) (map[string]json.RawMessage, error) {
+	resultado.Loteria = resultado.ID.Loteria
+	resultado.Concurso = resultado.ID.Concurso
+	dados, err := json.Marshal(resultado)
+	if err != nil {
+		return nil, err
+	}
+	var completo map[string]json.RawMessage
+	if err := json.Unmarshal(dados, &completo); err != nil {
+		return nil, err
+	}
+
+	projetado := map[string]json.RawMessage{
+		"loteria":  completo["loteria"],
+		"concurso": completo["concurso"],
+	}
+	for _, campo := range campos {
+		if valor, ok := completo[campo]; ok {
+			projetado[campo] = valor
+		}
+	}
+	return projetado, nil
+}
@@ -0,0 +1,50 @@
+package model
+
+// Origens do prêmio usado no valor esperado de cada faixa.
+const (
+	FontePremioEstimativa = "estimativa"
+	FontePremioMedia      = "media"
+)
+
+// PremioMedio é a média paga por ganhador em uma faixa, considerando apenas
+// os concursos em que a faixa teve ganhadores.
+type PremioMedio struct {
+	Faixa     int     `bson:"faixa" json:"faixa"`
+	Valor     float64 `bson:"valor" json:"valor"`
+	Concursos int     `bson:"concursos" json:"concursos"`
+}
+
+// Probabilidades traz as chances exatas de cada faixa de premiação para uma
+// aposta e o valor esperado da aposta. Retorno é o percentual do preço que
+// volta, em média, como prêmio.
+type Probabilidades struct {
+	Loteria         string               `json:"loteria"`
+	Numeros         int                  `json:"numeros"`
+	Trevos          int                  `json:"trevos,omitempty"`
+	Colunas         []int                `json:"colunas,omitempty"`
+	ApostasSimples  int64                `json:"apostasSimples"`
+	Preco           float64              `json:"preco"`
+	ProximoConcurso int                  `json:"proximoConcurso,omitempty"`
+	PremioEstimado  float64              `json:"premioEstimado"`
+	Faixas          []ProbabilidadeFaixa `json:"faixas"`
+	ValorEsperado   float64              `json:"valorEsperado"`
+	Retorno         float64              `json:"retorno"`
+}
+
+// ProbabilidadeFaixa traz a chance de a aposta atingir a faixa em um
+// sorteio. Casos e Total são inteiros exatos em texto, já que podem passar
+// de 2^63: a probabilidade é Casos/Total e UmEm é Total/Casos. Como apostas
+// com mais números podem atingir a faixa várias vezes, PremiosEsperados é a
+// quantidade média de prêmios da faixa por sorteio.
+type ProbabilidadeFaixa struct {
+	Faixa            int     `json:"faixa"`
+	Descricao        string  `json:"descricao"`
+	Casos            string  `json:"casos"`
+	Total            string  `json:"total"`
+	UmEm             string  `json:"umEm,omitempty"`
+	Probabilidade    float64 `json:"probabilidade"`
+	PremiosEsperados float64 `json:"premiosEsperados"`
+	Premio           float64 `json:"premio"`
+	FontePremio      string  `json:"fontePremio,omitempty"`
+	ValorEsperado    float64 `json:"valorEsperado"`
+}
@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+// ToleranciaProximoSorteio é quanto tempo após a meia-noite do dia do
+// sorteio o resultado pode levar para ser importado. As atualizações das
+// 21h, 22h, 01h e 12h do dia seguinte devem trazê-lo antes disso.
+const ToleranciaProximoSorteio = 36 * time.Hour
+
+// ProximoSorteio é o próximo concurso de uma loteria segundo o último
+// resultado armazenado. Desatualizado indica que esse resultado é antigo
+// demais para que as informações sejam confiáveis, com o motivo.
+type ProximoSorteio struct {
+	Loteria            string     `json:"loteria"`
+	Nome               string     `json:"nome"`
+	Concurso           int        `json:"concurso,omitempty"`
+	Data               string     `json:"data,omitempty"`
+	DataSorteio        *time.Time `json:"dataSorteio,omitempty"`
+	ValorEstimado      float64    `json:"valorEstimado"`
+	Acumulado          bool       `json:"acumulado"`
+	ValorAcumulado     float64    `json:"valorAcumulado,omitempty"`
+	UltimoConcurso     int        `json:"ultimoConcurso,omitempty"`
+	DataUltimoConcurso string     `json:"dataUltimoConcurso,omitempty"`
+	Desatualizado      bool       `json:"desatualizado"`
+	Motivo             string     `json:"motivo,omitempty"`
+}
@@ -0,0 +1,316 @@
+package model
+
+import (
+	"strconv"
+	"time"
+)
+
+type TipoJogo string
+
+const (
+	// TipoDezenas são os jogos em que se escolhem números de um único volante.
+	TipoDezenas TipoJogo = "dezenas"
+	// TipoColunas são os jogos em que cada coluna tem seu próprio sorteio (Super Sete).
+	TipoColunas TipoJogo = "colunas"
+	// TipoBilhete são os jogos de bilhete pré-numerado (Federal).
+	TipoBilhete TipoJogo = "bilhete"
+)
+
+const (
+	EspecialTimeCoracao = "timeCoracao"
+	EspecialMesSorte    = "mesSorte"
+)
+
+// Regras descreve um jogo: volante, quantidade de números por aposta, preços,
+// faixas de premiação, elementos extras e dias de sorteio. NumerosPorLinha é
+// a largura da grade do volante, zero quando o volante não é uma grade, como
+// os 31 números do Dia de Sorte.
+// MesSorte indica que a aposta escolhe um mês; o Time do Coração da
+// Timemania aparece só como faixa especial, já que a lista de clubes não
+// faz parte das regras.
+type Regras struct {
+	Loteria              Loteria       `json:"loteria"`
+	Nome                 string        `json:"nome"`
+	Tipo                 TipoJogo      `json:"tipo"`
+	MenorNumero          int           `json:"menorNumero"`
+	MaiorNumero          int           `json:"maiorNumero"`
+	NumerosSorteados     int           `json:"numerosSorteados"`
+	Sorteios             int           `json:"sorteios"`
+	NumerosApostaSimples int           `json:"numerosApostaSimples"`
+	MinNumeros           int           `json:"minNumeros"`
+	MaxNumeros           int           `json:"maxNumeros"`
+	NumerosPorLinha      int           `json:"numerosPorLinha,omitempty"`
+	PrecoApostaSimples   float64       `json:"precoApostaSimples,omitempty"`
+	Precos               []PrecoAposta `json:"precos,omitempty"`
+	Faixas               []Faixa       `json:"faixas"`
+	Trevos               *RegraTrevos  `json:"trevos,omitempty"`
+	Colunas              *RegraColunas `json:"colunas,omitempty"`
+	MesSorte             bool          `json:"mesSorte,omitempty"`
+	DiasSorteio          []DiaSemana   `json:"diasSorteio" swaggertype:"array,string"`
+	ConcursoEspecial     string        `json:"concursoEspecial,omitempty"`
+}
+
+// Faixa indica os acertos necessários para uma faixa de premiação. Trevos
+// vazio significa que a quantidade de trevos acertados não importa.
+type Faixa struct {
+	Faixa     int    `json:"faixa"`
+	Descricao string `json:"descricao"`
+	Sorteio   int    `json:"sorteio"`
+	Acertos   int    `json:"acertos"`
+	Trevos    []int  `json:"trevos,omitempty"`
+	Especial  string `json:"especial,omitempty"`
+}
+
+type PrecoAposta struct {
+	Numeros        int     `json:"numeros"`
+	Trevos         int     `json:"trevos,omitempty"`
+	ApostasSimples int64   `json:"apostasSimples"`
+	Valor          float64 `json:"valor"`
+}
+
+type RegraTrevos struct {
+	MenorNumero          int `json:"menorNumero"`
+	MaiorNumero          int `json:"maiorNumero"`
+	Sorteados            int `json:"sorteados"`
+	NumerosApostaSimples int `json:"numerosApostaSimples"`
+	MinNumeros           int `json:"minNumeros"`
+	MaxNumeros           int `json:"maxNumeros"`
+}
+
+type RegraColunas struct {
+	Quantidade   int `json:"quantidade"`
+	MinPorColuna int `json:"minPorColuna"`
+	MaxPorColuna int `json:"maxPorColuna"`
+}
+
+// DiaSemana é serializado pelo nome do dia em português.
+type DiaSemana time.Weekday
+
+var nomesDiasSemana = []string{"domingo", "segunda", "terca", "quarta", "quinta", "sexta", "sabado"}
+
+func (d DiaSemana) String() string {
+	return nomesDiasSemana[d]
+}
+
+func (d DiaSemana) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+var (
+	segundaASabado     = []DiaSemana{DiaSemana(time.Monday), DiaSemana(time.Tuesday), DiaSemana(time.Wednesday), DiaSemana(time.Thursday), DiaSemana(time.Friday), DiaSemana(time.Saturday)}
+	segundaQuartaSexta = []DiaSemana{DiaSemana(time.Monday), DiaSemana(time.Wednesday), DiaSemana(time.Friday)}
+	tercaQuintaSabado  = []DiaSemana{DiaSemana(time.Tuesday), DiaSemana(time.Thursday), DiaSemana(time.Saturday)}
+	quartaSabado       = []DiaSemana{DiaSemana(time.Wednesday), DiaSemana(time.Saturday)}
+)
+
+var registroRegras = map[Loteria]Regras{
+	MaisMilionaria: {
+		Nome: "+Milionária", Tipo: TipoDezenas,
+		MenorNumero: 1, MaiorNumero: 50, NumerosSorteados: 6, Sorteios: 1,
+		NumerosApostaSimples: 6, MinNumeros: 6, MaxNumeros: 12, PrecoApostaSimples: 6.00, NumerosPorLinha: 10,
+		Trevos: &RegraTrevos{MenorNumero: 1, MaiorNumero: 6, Sorteados: 2, NumerosApostaSimples: 2, MinNumeros: 2, MaxNumeros: 6},
+		Faixas: []Faixa{
+			{Faixa: 1, Descricao: "6 acertos + 2 trevos", Sorteio: 1, Acertos: 6, Trevos: []int{2}},
+			{Faixa: 2, Descricao: "6 acertos + 1 ou nenhum trevo", Sorteio: 1, Acertos: 6, Trevos: []int{0, 1}},
+			{Faixa: 3, Descricao: "5 acertos + 2 trevos", Sorteio: 1, Acertos: 5, Trevos: []int{2}},
+			{Faixa: 4, Descricao: "5 acertos + 1 ou nenhum trevo", Sorteio: 1, Acertos: 5, Trevos: []int{0, 1}},
+			{Faixa: 5, Descricao: "4 acertos + 2 trevos", Sorteio: 1, Acertos: 4, Trevos: []int{2}},
+			{Faixa: 6, Descricao: "4 acertos + 1 ou nenhum trevo", Sorteio: 1, Acertos: 4, Trevos: []int{0, 1}},
+			{Faixa: 7, Descricao: "3 acertos + 2 trevos", Sorteio: 1, Acertos: 3, Trevos: []int{2}},
+			{Faixa: 8, Descricao: "3 acertos + 1 trevo", Sorteio: 1, Acertos: 3, Trevos: []int{1}},
+			{Faixa: 9, Descricao: "2 acertos + 2 trevos", Sorteio: 1, Acertos: 2, Trevos: []int{2}},
+			{Faixa: 10, Descricao: "2 acertos + 1 trevo", Sorteio: 1, Acertos: 2, Trevos: []int{1}},
+		},
+		DiasSorteio: quartaSabado,
+	},
+	MegaSena: {
+		Nome: "Mega-Sena", Tipo: TipoDezenas, ConcursoEspecial: "Mega da Virada",
+		MenorNumero: 1, MaiorNumero: 60, NumerosSorteados: 6, Sorteios: 1,
+		NumerosApostaSimples: 6, MinNumeros: 6, MaxNumeros: 20, PrecoApostaSimples: 6.00, NumerosPorLinha: 10,
+		Faixas:      faixasPorAcertos(1, 1, "", 6, 5, 4),
+		DiasSorteio: tercaQuintaSabado,
+	},
+	Lotofacil: {
+		Nome: "Lotofácil", Tipo: TipoDezenas, ConcursoEspecial: "Lotofácil da Independência",
+		MenorNumero: 1, MaiorNumero: 25, NumerosSorteados: 15, Sorteios: 1,
+		NumerosApostaSimples: 15, MinNumeros: 15, MaxNumeros: 20, PrecoApostaSimples: 3.50, NumerosPorLinha: 5,
+		Faixas:      faixasPorAcertos(1, 1, "", 15, 14, 13, 12, 11),
+		DiasSorteio: segundaASabado,
+	},
+	Quina: {
+		Nome: "Quina", Tipo: TipoDezenas, ConcursoEspecial: "Quina de São João",
+		MenorNumero: 1, MaiorNumero: 80, NumerosSorteados: 5, Sorteios: 1,
+		NumerosApostaSimples: 5, MinNumeros: 5, MaxNumeros: 15, PrecoApostaSimples: 3.00, NumerosPorLinha: 10,
+		Faixas:      faixasPorAcertos(1, 1, "", 5, 4, 3, 2),
+		DiasSorteio: segundaASabado,
+	},
+	Lotomania: {
+		Nome: "Lotomania", Tipo: TipoDezenas,
+		MenorNumero: 0, MaiorNumero: 99, NumerosSorteados: 20, Sorteios: 1,
+		NumerosApostaSimples: 50, MinNumeros: 50, MaxNumeros: 50, PrecoApostaSimples: 3.00, NumerosPorLinha: 10,
+		Faixas:      faixasPorAcertos(1, 1, "", 20, 19, 18, 17, 16, 15, 0),
+		DiasSorteio: segundaQuartaSexta,
+	},
+	Timemania: {
+		Nome: "Timemania", Tipo: TipoDezenas,
+		MenorNumero: 1, MaiorNumero: 80, NumerosSorteados: 7, Sorteios: 1,
+		NumerosApostaSimples: 10, MinNumeros: 10, MaxNumeros: 10, PrecoApostaSimples: 3.50, NumerosPorLinha: 10,
+		Faixas: append(faixasPorAcertos(1, 1, "", 7, 6, 5, 4, 3),
+			Faixa{Faixa: 6, Descricao: "Time do Coração", Sorteio: 1, Especial: EspecialTimeCoracao}),
+		DiasSorteio: tercaQuintaSabado,
+	},
+	DuplaSena: {
+		Nome: "Dupla Sena", Tipo: TipoDezenas, ConcursoEspecial: "Dupla de Páscoa",
+		MenorNumero: 1, MaiorNumero: 50, NumerosSorteados: 6, Sorteios: 2,
+		NumerosApostaSimples: 6, MinNumeros: 6, MaxNumeros: 15, PrecoApostaSimples: 3.00, NumerosPorLinha: 10,
+		Faixas: append(faixasPorAcertos(1, 1, " - 1º sorteio", 6, 5, 4, 3),
+			faixasPorAcertos(2, 5, " - 2º sorteio", 6, 5, 4, 3)...),
+		DiasSorteio: segundaQuartaSexta,
+	},
+	Federal: {
+		Nome: "Federal", Tipo: TipoBilhete,
+		MenorNumero: 0, MaiorNumero: 99999, NumerosSorteados: 5, Sorteios: 1,
+		Faixas: []Faixa{
+			{Faixa: 1, Descricao: "1º prêmio", Sorteio: 1},
+			{Faixa: 2, Descricao: "2º prêmio", Sorteio: 1},
+			{Faixa: 3, Descricao: "3º prêmio", Sorteio: 1},
+			{Faixa: 4, Descricao: "4º prêmio", Sorteio: 1},
+			{Faixa: 5, Descricao: "5º prêmio", Sorteio: 1},
+		},
+		DiasSorteio: quartaSabado,
+	},
+	DiaDeSorte: {
+		Nome: "Dia de Sorte", Tipo: TipoDezenas,
+		MenorNumero: 1, MaiorNumero: 31, NumerosSorteados: 7, Sorteios: 1,
+		NumerosApostaSimples: 7, MinNumeros: 7, MaxNumeros: 15, PrecoApostaSimples: 2.50,
+		Faixas: append(faixasPorAcertos(1, 1, "", 7, 6, 5, 4),
+			Faixa{Faixa: 5, Descricao: "Mês da Sorte", Sorteio: 1, Especial: EspecialMesSorte}),
+		MesSorte:    true,
+		DiasSorteio: tercaQuintaSabado,
+	},
+	SuperSete: {
+		Nome: "Super Sete", Tipo: TipoColunas,
+		MenorNumero: 0, MaiorNumero: 9, NumerosSorteados: 7, Sorteios: 1,
+		NumerosApostaSimples: 7, MinNumeros: 7, MaxNumeros: 21, PrecoApostaSimples: 3.00,
+		Colunas:     &RegraColunas{Quantidade: 7, MinPorColuna: 1, MaxPorColuna: 3},
+		Faixas:      faixasPorAcertos(1, 1, "", 7, 6, 5, 4, 3),
+		DiasSorteio: segundaQuartaSexta,
+	},
+}
+
+func init() {
+	for loteria, regras := range registroRegras {
+		regras.Loteria = loteria
+		regras.Precos = regras.calcularPrecos()
+		registroRegras[loteria] = regras
+	}
+}
+
+// Regras retorna as regras do jogo. O segundo valor é false para loterias
+// desconhecidas.
+func (l Loteria) Regras() (Regras, bool) {
+	regras, ok := registroRegras[l]
+	return regras, ok
+}
+
+// GetRegras é um atalho para Loteria(loteria).Regras().
+func GetRegras(loteria string) (Regras, bool) {
+	return Loteria(loteria).Regras()
+}
+
+// ApostasSimples retorna quantas apostas simples compõem uma aposta com a
+// quantidade de números e trevos informada.
+func (r Regras) ApostasSimples(numeros, trevos int) int64 {
+	if r.Tipo != TipoDezenas {
+		return 1
+	}
+	apostas := Combinacoes(numeros, r.NumerosApostaSimples)
+	if r.Trevos != nil {
+		apostas *= Combinacoes(trevos, r.Trevos.NumerosApostaSimples)
+	}
+	return apostas
+}
+
+// PrecoColunas calcula o preço de uma aposta da Super Sete a partir da
+// quantidade de números marcados em cada coluna.
+func (r Regras) PrecoColunas(porColuna []int) float64 {
+	apostas := int64(1)
+	for _, n := range porColuna {
+		apostas *= int64(n)
+	}
+	return float64(apostas) * r.PrecoApostaSimples
+}
+
+// TemEspecial indica se alguma faixa premia o elemento especial informado,
+// como o Time do Coração.
+func (r Regras) TemEspecial(especial string) bool {
+	for _, f := range r.Faixas {
+		if f.Especial == especial {
+			return true
+		}
+	}
+	return false
+}
+
+// FaixasDoSorteio retorna as faixas de premiação de um sorteio do concurso.
+func (r Regras) FaixasDoSorteio(sorteio int) []Faixa {
+	var faixas []Faixa
+	for _, f := range r.Faixas {
+		if f.Sorteio == sorteio {
+			faixas = append(faixas, f)
+		}
+	}
+	return faixas
+}
+
+func (r Regras) SorteiaNoDia(dia time.Weekday) bool {
+	for _, d := range r.DiasSorteio {
+		if time.Weekday(d) == dia {
+			return true
+		}
+	}
+	return false
+}
+
+func (r Regras) calcularPrecos() []PrecoAposta {
+	if r.Tipo != TipoDezenas || r.PrecoApostaSimples == 0 {
+		return nil
+	}
+
+	var precos []PrecoAposta
+	for n := r.MinNumeros; n <= r.MaxNumeros; n++ {
+		if r.Trevos == nil {
+			apostas := r.ApostasSimples(n, 0)
+			precos = append(precos, PrecoAposta{Numeros: n, ApostasSimples: apostas, Valor: float64(apostas) * r.PrecoApostaSimples})
+			continue
+		}
+		for t := r.Trevos.MinNumeros; t <= r.Trevos.MaxNumeros; t++ {
+			apostas := r.ApostasSimples(n, t)
+			precos = append(precos, PrecoAposta{Numeros: n, Trevos: t, ApostasSimples: apostas, Valor: float64(apostas) * r.PrecoApostaSimples})
+		}
+	}
+	return precos
+}
+
+func faixasPorAcertos(sorteio, primeiraFaixa int, sufixo string, acertos ...int) []Faixa {
+	faixas := make([]Faixa, len(acertos))
+	for i, a := range acertos {
+		faixas[i] = Faixa{Faixa: primeiraFaixa + i, Descricao: strconv.Itoa(a) + " acertos" + sufixo, Sorteio: sorteio, Acertos: a}
+	}
+	return faixas
+}
+
+// Combinacoes retorna o coeficiente binomial C(n, k).
+func Combinacoes(n, k int) int64 {
+	if k < 0 || n < 0 || k > n {
+		return 0
+	}
+	if k > n-k {
+		k = n - k
+	}
+	var resultado int64 = 1
+	for i := 1; i <= k; i++ {
+		resultado = resultado * int64(n-k+i) / int64(i)
+	}
+	return resultado
+}
@@ -1,6 +1,6 @@
 package model
 
-import _ "time"
+import "time"
 
 type ResultadoID struct {
 	Loteria  string `bson:"loteria" json:"loteria"`
@@ -12,19 +12,27 @@ type Resultado struct {
 	Loteria                        string                  `bson:"-" json:"loteria"`
 	Concurso                       int                     `bson:"-" json:"concurso"`
 	Data                           string                  `bson:"data" json:"data"`
+	DataSorteio                    *time.Time              `bson:"dataSorteio,omitempty" json:"dataSorteio,omitempty"`
 	Local                          string                  `bson:"local" json:"local,omitempty"`
 	DezenasOrdemSorteio            []string                `bson:"dezenasOrdemSorteio,omitempty" json:"dezenasOrdemSorteio,omitempty"`
 	Dezenas                        []string                `bson:"dezenas" json:"dezenas"`
+	Sorteios                       []SorteioResultado      `bson:"sorteios,omitempty" json:"sorteios,omitempty"`
+	Federal                        *ResultadoFederal       `bson:"federal,omitempty" json:"federal,omitempty"`
 	Trevos                         []string                `bson:"trevos,omitempty" json:"trevos,omitempty"`
 	TimeCoracao                    string                  `bson:"timeCoracao,omitempty" json:"timeCoracao,omitempty"`
 	MesSorte                       string                  `bson:"mesSorte,omitempty" json:"mesSorte,omitempty"`
 	Premiacoes                     []Premiacao             `bson:"premiacoes" json:"premiacoes"`
+	MaiorPremio                    float64                 `bson:"maiorPremio" json:"-"`
+	Composicao                     []Composicao            `bson:"composicao,omitempty" json:"composicao,omitempty"`
 	LocalGanhadores                []MunicipioUFGanhadores `bson:"localGanhadores,omitempty" json:"municipiosUFGanhadores,omitempty"`
 	EstadosPremiados               []Estado                `bson:"estadosPremiados,omitempty" json:"estadosPremiados,omitempty"`
 	Observacao                     string                  `bson:"observacao,omitempty" json:"observacao,omitempty"`
+	Especial                       bool                    `bson:"especial" json:"especial"`
+	TipoEspecial                   string                  `bson:"tipoEspecial,omitempty" json:"tipoEspecial,omitempty"`
 	Acumulou                       bool                    `bson:"acumulou" json:"acumulou"`
 	ProximoConcurso                int                     `bson:"proximoConcurso,omitempty" json:"proximoConcurso,omitempty"`
 	DataProximoConcurso            string                  `bson:"dataProximoConcurso,omitempty" json:"dataProximoConcurso,omitempty"`
+	DataProximoSorteio             *time.Time              `bson:"dataProximoSorteio,omitempty" json:"dataProximoSorteio,omitempty"`
 	ValorArrecadado                float64                 `bson:"valorArrecadado,omitempty" json:"valorArrecadado,omitempty"`
 	ValorAcumuladoConcurso_0_5     float64                 `bson:"valorAcumuladoConcurso_0_5,omitempty" json:"valorAcumuladoConcurso_0_5,omitempty"`
 	ValorAcumuladoConcursoEspecial float64                 `bson:"valorAcumuladoConcursoEspecial,omitempty" json:"valorAcumuladoConcursoEspecial,omitempty"`
@@ -39,13 +47,23 @@ type Premiacao struct {
 	Valor              float64 `bson:"valor" json:"valor"`
 }
 
+// SorteioResultado traz as dezenas e as faixas de premiação de um dos
+// sorteios do concurso. Apenas a Dupla Sena tem mais de um sorteio.
+type SorteioResultado struct {
+	Sorteio             int         `bson:"sorteio" json:"sorteio"`
+	DezenasOrdemSorteio []string    `bson:"dezenasOrdemSorteio,omitempty" json:"dezenasOrdemSorteio,omitempty"`
+	Dezenas             []string    `bson:"dezenas" json:"dezenas"`
+	Premiacoes          []Premiacao `bson:"premiacoes" json:"premiacoes"`
+}
+
 type MunicipioUFGanhadores struct {
-	Ganhadores    int    `bson:"ganhadores" json:"ganhadores"`
-	Municipio     string `bson:"municipio" json:"municipio"`
-	Posicao       int    `bson:"posicao" json:"posicao"`
-	UF            string `bson:"uf" json:"uf"`
-	Serie         string `bson:"serie,omitempty" json:"serie,omitempty"`
-	NumeroBilhete string `bson:"numeroBilhete,omitempty" json:"numeroBilhete,omitempty"`
+	Ganhadores      int    `bson:"ganhadores" json:"ganhadores"`
+	Municipio       string `bson:"municipio" json:"municipio"`
+	Posicao         int    `bson:"posicao" json:"posicao"`
+	UF              string `bson:"uf" json:"uf"`
+	Serie           string `bson:"serie,omitempty" json:"serie,omitempty"`
+	NumeroBilhete   string `bson:"numeroBilhete,omitempty" json:"numeroBilhete,omitempty"`
+	Estabelecimento string `bson:"estabelecimento,omitempty" json:"estabelecimento,omitempty"`
 }
 
 type Estado struct {
@@ -57,9 +75,102 @@ type Estado struct {
 func (r *Resultado) BeforeSave() {
 	r.Loteria = r.ID.Loteria
 	r.Concurso = r.ID.Concurso
+
+	// As datas da Caixa continuam sendo a fonte; as convertidas permitem
+	// ordenar e filtrar por data no banco.
+	r.DataSorteio = ParseDataCaixa(r.Data)
+	r.DataProximoSorteio = ParseDataCaixa(r.DataProximoConcurso)
+
+	// O maior prêmio pago ordena os concursos no ranking de prêmios sem
+	// desmembrar as premiações de todos eles.
+	r.MaiorPremio = 0
+	for _, p := range r.Premiacoes {
+		if p.NumeroDeGanhadores > 0 && p.Valor > r.MaiorPremio {
+			r.MaiorPremio = p.Valor
+		}
+	}
 }
 
 func (r *Resultado) AfterFind() {
 	r.Loteria = r.ID.Loteria
 	r.Concurso = r.ID.Concurso
+
+	// Documentos gravados antes da conversão das datas
+	if r.DataSorteio == nil {
+		r.DataSorteio = ParseDataCaixa(r.Data)
+	}
+	if r.DataProximoSorteio == nil {
+		r.DataProximoSorteio = ParseDataCaixa(r.DataProximoConcurso)
+	}
+	r.DataSorteio = noFusoBrasilia(r.DataSorteio)
+	r.DataProximoSorteio = noFusoBrasilia(r.DataProximoSorteio)
+
+	// Documentos gravados antes da separação dos sorteios
+	if len(r.Sorteios) == 0 {
+		r.MontarSorteios()
+	}
+	if r.Federal == nil {
+		r.MontarFederal()
+	}
+}
+
+// MontarSorteios separa dezenas, ordem de sorteio e faixas de premiação de
+// cada sorteio a partir dos campos planos. Não faz nada em loterias de um
+// único sorteio.
+func (r *Resultado) MontarSorteios() {
+	loteria := r.Loteria
+	if loteria == "" {
+		loteria = r.ID.Loteria
+	}
+	regras, ok := GetRegras(loteria)
+	if !ok || regras.Sorteios < 2 {
+		return
+	}
+	tamanho := regras.NumerosSorteados
+	if len(r.Dezenas) != tamanho*regras.Sorteios {
+		return
+	}
+
+	r.Sorteios = make([]SorteioResultado, 0, regras.Sorteios)
+	for i := 0; i < regras.Sorteios; i++ {
+		inicio, fim := i*tamanho, (i+1)*tamanho
+		sorteio := SorteioResultado{
+			Sorteio:    i + 1,
+			Dezenas:    append([]string{}, r.Dezenas[inicio:fim]...),
+			Premiacoes: []Premiacao{},
+		}
+		if len(r.DezenasOrdemSorteio) >= fim {
+			sorteio.DezenasOrdemSorteio = append([]string{}, r.DezenasOrdemSorteio[inicio:fim]...)
+		}
+		for _, faixa := range regras.FaixasDoSorteio(i + 1) {
+			for _, p := range r.Premiacoes {
+				if p.Faixa == faixa.Faixa {
+					sorteio.Premiacoes = append(sorteio.Premiacoes, p)
+				}
+			}
+		}
+		r.Sorteios = append(r.Sorteios, sorteio)
+	}
+}
+
+// DezenasDoSorteio retorna as dezenas do sorteio informado (a partir de 1).
+// Resultados sem sorteios separados têm um único sorteio com todas as
+// dezenas.
+func (r *Resultado) DezenasDoSorteio(sorteio int) []string {
+	for _, s := range r.Sorteios {
+		if s.Sorteio == sorteio {
+			return s.Dezenas
+		}
+	}
+	return r.Dezenas
+}
+
+// PremiacoesDoSorteio retorna as faixas de premiação do sorteio informado.
+func (r *Resultado) PremiacoesDoSorteio(sorteio int) []Premiacao {
+	for _, s := range r.Sorteios {
+		if s.Sorteio == sorteio {
+			return s.Premiacoes
+		}
+	}
+	return r.Premiacoes
 }
@@ -0,0 +1,52 @@
+package model
+
+const (
+	AgruparPorConcurso = "concurso"
+	AgruparPorMes      = "mes"
+	AgruparPorAno      = "ano"
+)
+
+// CamposSerie são os valores de Resultado que podem ser acompanhados ao
+// longo do tempo, pelo nome usado na API.
+var CamposSerie = []string{
+	"valorArrecadado",
+	"valorAcumuladoProximoConcurso",
+	"valorEstimadoProximoConcurso",
+	"valorAcumuladoConcursoEspecial",
+	"valorAcumuladoConcurso_0_5",
+}
+
+// CampoSerie retorna o nome no banco de um campo aceito em séries.
+func CampoSerie(nome string) (string, bool) {
+	for _, campo := range CamposSerie {
+		if campo == nome {
+			return CampoResultado(nome)
+		}
+	}
+	return "", false
+}
+
+// PontoSerie resume um período da série. Periodo é o número do concurso,
+// o mês (aaaa-mm) ou o ano, conforme o agrupamento; Data só é preenchida
+// no agrupamento por concurso. Soma, Media e Maximo consideram apenas os
+// ConcursosComValor e ficam nulos quando nenhum concurso tem o valor.
+type PontoSerie struct {
+	Periodo           string   `bson:"_id" json:"periodo"`
+	Data              string   `bson:"data" json:"data,omitempty"`
+	ConcursoInicio    int      `bson:"concursoInicio" json:"concursoInicio"`
+	ConcursoFim       int      `bson:"concursoFim" json:"concursoFim"`
+	Concursos         int      `bson:"concursos" json:"concursos"`
+	ConcursosComValor int      `bson:"concursosComValor" json:"concursosComValor"`
+	Soma              *float64 `bson:"soma" json:"soma"`
+	Media             *float64 `bson:"media" json:"media"`
+	Maximo            *float64 `bson:"maximo" json:"maximo"`
+}
+
+// Serie é a evolução de um valor da loteria. Concursos sem o valor, como os
+// anteriores à publicação do campo pela Caixa, não contam como zero.
+type Serie struct {
+	Loteria     string       `json:"loteria"`
+	Campo       string       `json:"campo"`
+	Agrupamento string       `json:"agrupamento"`
+	Pontos      []PontoSerie `json:"pontos"`
+}
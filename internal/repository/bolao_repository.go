@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"loterias-api-golang/internal/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type BolaoRepository struct {
+	boloes   *mongo.Collection
+	bilhetes *mongo.Collection
+}
+
+func NewBolaoRepository(db *mongo.Database) *BolaoRepository {
+	return &BolaoRepository{
+		boloes:   db.Collection("boloes"),
+		bilhetes: db.Collection("boloes_bilhetes"),
+	}
+}
+
+func (r *BolaoRepository) FindAll(loteria string) ([]model.Bolao, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{}
+	if loteria != "" {
+		filter["loteria"] = loteria
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "criadoEm", Value: -1}})
+
+	cursor, err := r.boloes.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	boloes := []model.Bolao{}
+	if err = cursor.All(ctx, &boloes); err != nil {
+		return nil, err
+	}
+
+	return boloes, nil
+}
+
+func (r *BolaoRepository) FindByID(id string) (*model.Bolao, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var bolao model.Bolao
+	err := r.boloes.FindOne(ctx, bson.M{"_id": id}).Decode(&bolao)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &bolao, nil
+}
+
+// FindAtivos retorna os bolões da loteria cuja faixa de concursos inclui o
+// concurso informado.
+func (r *BolaoRepository) FindAtivos(loteria string, concurso int) ([]model.Bolao, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"loteria":        loteria,
+		"concursoInicio": bson.M{"$lte": concurso},
+		"concursoFim":    bson.M{"$gte": concurso},
+	}
+
+	cursor, err := r.boloes.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var boloes []model.Bolao
+	if err = cursor.All(ctx, &boloes); err != nil {
+		return nil, err
+	}
+
+	return boloes, nil
+}
+
+func (r *BolaoRepository) Save(bolao *model.Bolao) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	opts := options.Replace().SetUpsert(true)
+	_, err := r.boloes.ReplaceOne(ctx, bson.M{"_id": bolao.ID}, bolao, opts)
+	return err
+}
+
+// Atualizar grava nome e faixa de concursos do bolão sem tocar nos
+// participantes. Retorna o bolão atualizado, ou nil se ele não existe.
+func (r *BolaoRepository) Atualizar(bolao *model.Bolao) (*model.Bolao, error) {
+	return r.alterar(bson.M{"_id": bolao.ID}, bson.M{"$set": bson.M{
+		"nome":           bolao.Nome,
+		"concursoInicio": bolao.ConcursoInicio,
+		"concursoFim":    bolao.ConcursoFim,
+		"atualizadoEm":   bolao.AtualizadoEm,
+	}})
+}
+
+// AdicionarParticipante inclui o participante no bolão. Retorna o bolão
+// atualizado, ou nil se ele não existe.
+func (r *BolaoRepository) AdicionarParticipante(id string, participante model.Participante, atualizadoEm time.Time) (*model.Bolao, error) {
+	return r.alterar(bson.M{"_id": id}, bson.M{
+		"$push": bson.M{"participantes": participante},
+		"$set":  bson.M{"atualizadoEm": atualizadoEm},
+	})
+}
+
+// AtualizarParticipante substitui os dados do participante de mesmo ID.
+// Retorna o bolão atualizado, ou nil se o participante não está no bolão.
+func (r *BolaoRepository) AtualizarParticipante(id string, participante model.Participante, atualizadoEm time.Time) (*model.Bolao, error) {
+	return r.alterar(bson.M{"_id": id, "participantes.id": participante.ID}, bson.M{
+		"$set": bson.M{"participantes.$": participante, "atualizadoEm": atualizadoEm},
+	})
+}
+
+// RemoverParticipante retira o participante do bolão. Retorna o bolão
+// atualizado, ou nil se o participante não está no bolão.
+func (r *BolaoRepository) RemoverParticipante(id, participanteID string, atualizadoEm time.Time) (*model.Bolao, error) {
+	return r.alterar(bson.M{"_id": id, "participantes.id": participanteID}, bson.M{
+		"$pull": bson.M{"participantes": bson.M{"id": participanteID}},
+		"$set":  bson.M{"atualizadoEm": atualizadoEm},
+	})
+}
+
+// alterar aplica a atualização ao bolão que atende ao filtro em uma única
+// operação, para que alterações simultâneas não se sobrescrevam.
+func (r *BolaoRepository) alterar(filter, update bson.M) (*model.Bolao, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var bolao model.Bolao
+	err := r.boloes.FindOneAndUpdate(ctx, filter, update, opts).Decode(&bolao)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &bolao, nil
+}
+
+// Delete remove o bolão e todos os seus bilhetes.
+func (r *BolaoRepository) Delete(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := r.bilhetes.DeleteMany(ctx, bson.M{"bolaoId": id}); err != nil {
+		return err
+	}
+	_, err := r.boloes.DeleteOne(ctx, bson.M{"_id": id})
+	return err
+}
+
+func (r *BolaoRepository) FindBilhetes(bolaoID string) ([]model.BilheteBolao, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "criadoEm", Value: 1}})
+	cursor, err := r.bilhetes.Find(ctx, bson.M{"bolaoId": bolaoID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	bilhetes := []model.BilheteBolao{}
+	if err = cursor.All(ctx, &bilhetes); err != nil {
+		return nil, err
+	}
+
+	return bilhetes, nil
+}
+
+func (r *BolaoRepository) SaveBilhete(bilhete *model.BilheteBolao) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	opts := options.Replace().SetUpsert(true)
+	_, err := r.bilhetes.ReplaceOne(ctx, bson.M{"_id": bilhete.ID}, bilhete, opts)
+	return err
+}
+
+// DeleteBilhete remove um bilhete do bolão. Retorna false se o bilhete não
+// pertence ao bolão.
+func (r *BolaoRepository) DeleteBilhete(bolaoID, bilheteID string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := r.bilhetes.DeleteOne(ctx, bson.M{"_id": bilheteID, "bolaoId": bolaoID})
+	if err != nil {
+		return false, err
+	}
+	return res.DeletedCount > 0, nil
+}
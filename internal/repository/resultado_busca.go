@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"loterias-api-golang/internal/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// variantesNumero lista as formas em que um número pode estar gravado, já
+// que a Caixa nem sempre usa zero à esquerda.
+func variantesNumero(numeros ...int) bson.A {
+	variantes := bson.A{}
+	for _, n := range numeros {
+		variantes = append(variantes, strconv.Itoa(n))
+		if n < 10 {
+			variantes = append(variantes, fmt.Sprintf("%02d", n))
+		}
+	}
+	return variantes
+}
+
+// BuscarNumeros retorna os concursos em que saíram ao menos Min das dezenas
+// (ou colunas) e MinTrevos dos trevos procurados, do que teve mais acertos
+// para o que teve menos. O primeiro estágio usa os índices de dezenas e
+// trevos.
+func (r *ResultadoRepository) BuscarNumeros(loteria string, criterio model.CriterioBusca) (*model.ConcursosEncontrados, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	match := bson.M{"_id.loteria": loteria}
+	campos := bson.M{}
+
+	if len(criterio.Dezenas) > 0 {
+		dezenas := variantesNumero(criterio.Dezenas...)
+		match["dezenas"] = bson.M{"$in": dezenas}
+		campos["dezenasEncontradas"] = bson.M{"$setIntersection": bson.A{"$dezenas", dezenas}}
+		campos["acertos"] = bson.M{"$size": bson.M{"$setIntersection": bson.A{"$dezenas", dezenas}}}
+	}
+
+	if len(criterio.Colunas) > 0 {
+		posicoes := make([]int, 0, len(criterio.Colunas))
+		for coluna := range criterio.Colunas {
+			posicoes = append(posicoes, coluna)
+		}
+		sort.Ints(posicoes)
+
+		var qualquer, encontradas bson.A
+		for _, coluna := range posicoes {
+			numero := variantesNumero(criterio.Colunas[coluna])
+			qualquer = append(qualquer, bson.M{"dezenas." + strconv.Itoa(coluna): bson.M{"$in": numero}})
+			encontradas = append(encontradas, bson.M{"$cond": bson.A{
+				bson.M{"$in": bson.A{bson.M{"$arrayElemAt": bson.A{"$dezenas", coluna}}, numero}},
+				bson.A{coluna + 1},
+				bson.A{},
+			}})
+		}
+		match["$or"] = qualquer
+		campos["colunasEncontradas"] = bson.M{"$concatArrays": encontradas}
+		campos["acertos"] = bson.M{"$size": bson.M{"$concatArrays": encontradas}}
+	}
+
+	if len(criterio.Trevos) > 0 {
+		trevos := variantesNumero(criterio.Trevos...)
+		if criterio.MinTrevos > 0 {
+			match["trevos"] = bson.M{"$in": trevos}
+		}
+		encontrados := bson.M{"$setIntersection": bson.A{bson.M{"$ifNull": bson.A{"$trevos", bson.A{}}}, trevos}}
+		campos["trevosEncontrados"] = encontrados
+		campos["acertosTrevos"] = bson.M{"$size": encontrados}
+	}
+
+	filtroAcertos := bson.M{}
+	if criterio.Min > 0 {
+		filtroAcertos["acertos"] = bson.M{"$gte": criterio.Min}
+	}
+	if criterio.MinTrevos > 0 {
+		filtroAcertos["acertosTrevos"] = bson.M{"$gte": criterio.MinTrevos}
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$addFields", Value: campos}},
+		{{Key: "$match", Value: filtroAcertos}},
+		{{Key: "$facet", Value: bson.M{
+			"total": bson.A{bson.M{"$count": "total"}},
+			"concursos": bson.A{
+				bson.M{"$sort": bson.D{
+					{Key: "acertos", Value: -1},
+					{Key: "acertosTrevos", Value: -1},
+					{Key: "_id.concurso", Value: -1},
+				}},
+				bson.M{"$limit": criterio.Limite},
+				bson.M{"$project": bson.M{
+					"_id":                0,
+					"concurso":           "$_id.concurso",
+					"data":               1,
+					"dezenas":            1,
+					"trevos":             1,
+					"acertos":            1,
+					"dezenasEncontradas": 1,
+					"colunasEncontradas": 1,
+					"acertosTrevos":      1,
+					"trevosEncontrados":  1,
+				}},
+			},
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var facetas []struct {
+		Total []struct {
+			Total int `bson:"total"`
+		} `bson:"total"`
+		Concursos []model.ConcursoEncontrado `bson:"concursos"`
+	}
+	if err = cursor.All(ctx, &facetas); err != nil {
+		return nil, err
+	}
+
+	encontrados := &model.ConcursosEncontrados{Concursos: []model.ConcursoEncontrado{}}
+	if len(facetas) == 0 {
+		return encontrados, nil
+	}
+	if len(facetas[0].Total) > 0 {
+		encontrados.Total = facetas[0].Total[0].Total
+	}
+	if facetas[0].Concursos != nil {
+		encontrados.Concursos = facetas[0].Concursos
+	}
+
+	return encontrados, nil
+}
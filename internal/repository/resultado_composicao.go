@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"loterias-api-golang/internal/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FindSemComposicao retorna as dezenas dos resultados da loteria gravados
+// antes do cálculo da composição.
+func (r *ResultadoRepository) FindSemComposicao(loteria string) ([]model.Resultado, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	filter := bson.M{"_id.loteria": loteria, "composicao": bson.M{"$exists": false}}
+	opts := options.Find().SetProjection(bson.M{"dezenas": 1, "sorteios": 1})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var resultados []model.Resultado
+	if err = cursor.All(ctx, &resultados); err != nil {
+		return nil, err
+	}
+
+	for i := range resultados {
+		resultados[i].AfterFind()
+	}
+
+	return resultados, nil
+}
+
+// GravarComposicoes grava apenas a composição dos resultados informados.
+func (r *ResultadoRepository) GravarComposicoes(resultados []model.Resultado) error {
+	if len(resultados) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	operations := make([]mongo.WriteModel, 0, len(resultados))
+	for _, resultado := range resultados {
+		operations = append(operations, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": resultado.ID}).
+			SetUpdate(bson.M{"$set": bson.M{"composicao": resultado.Composicao}}))
+	}
+
+	_, err := r.collection.BulkWrite(ctx, operations)
+	return err
+}
+
+// estagiosHistograma conta os sorteios por valor de um campo da composição.
+func estagiosHistograma(campo string) bson.A {
+	return bson.A{
+		bson.M{"$unwind": "$composicao"},
+		bson.M{"$group": bson.M{"_id": "$composicao." + campo, "quantidade": bson.M{"$sum": 1}}},
+		bson.M{"$project": bson.M{"_id": 0, "valor": "$_id", "quantidade": 1}},
+	}
+}
+
+// estagiosHistogramaGrupos conta, para cada posição de um campo array da
+// composição, os sorteios por valor.
+func estagiosHistogramaGrupos(campo string) bson.A {
+	return bson.A{
+		bson.M{"$unwind": "$composicao"},
+		bson.M{"$unwind": bson.M{"path": "$composicao." + campo, "includeArrayIndex": "grupo"}},
+		bson.M{"$group": bson.M{
+			"_id":        bson.M{"grupo": "$grupo", "valor": "$composicao." + campo},
+			"quantidade": bson.M{"$sum": 1},
+		}},
+		bson.M{"$project": bson.M{"_id": 0, "grupo": "$_id.grupo", "valor": "$_id.valor", "quantidade": 1}},
+	}
+}
+
+// ContarComposicao conta os sorteios por valor de cada atributo da
+// composição gravada nos concursos selecionados.
+func (r *ResultadoRepository) ContarComposicao(loteria string, filtro model.FiltroConcursos) (*model.ContagemComposicao, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pipeline := append(pipelineFiltro(loteria, filtro), bson.D{{Key: "$facet", Value: bson.M{
+		"resumo":         estagiosResumo(),
+		"soma":           estagiosHistograma("soma"),
+		"pares":          estagiosHistograma("pares"),
+		"primos":         estagiosHistograma("primos"),
+		"fibonacci":      estagiosHistograma("fibonacci"),
+		"consecutivos":   estagiosHistograma("consecutivos"),
+		"maiorSequencia": estagiosHistograma("maiorSequencia"),
+		"decadas":        estagiosHistogramaGrupos("decadas"),
+		"linhas":         estagiosHistogramaGrupos("linhas"),
+		"colunas":        estagiosHistogramaGrupos("colunas"),
+	}}})
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var facetas []struct {
+		Resumo                   []model.ContagemComposicao `bson:"resumo"`
+		model.ContagemComposicao `bson:",inline"`
+	}
+	if err = cursor.All(ctx, &facetas); err != nil {
+		return nil, err
+	}
+
+	contagem := &model.ContagemComposicao{}
+	if len(facetas) == 0 {
+		return contagem, nil
+	}
+	*contagem = facetas[0].ContagemComposicao
+	if len(facetas[0].Resumo) > 0 {
+		contagem.Concursos = facetas[0].Resumo[0].Concursos
+		contagem.ConcursoInicio = facetas[0].Resumo[0].ConcursoInicio
+		contagem.ConcursoFim = facetas[0].Resumo[0].ConcursoFim
+	}
+
+	return contagem, nil
+}
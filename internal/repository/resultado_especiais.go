@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"loterias-api-golang/internal/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ListarReservas retorna, em ordem crescente de concurso, a reserva do
+// concurso especial informada em cada resultado da loteria.
+func (r *ResultadoRepository) ListarReservas(loteria string) ([]model.ReservaConcurso, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"_id.loteria": loteria}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":                            0,
+			"concurso":                       "$_id.concurso",
+			"data":                           1,
+			"valorAcumuladoConcursoEspecial": 1,
+			"especial":                       1,
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "concurso", Value: 1}}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var reservas []model.ReservaConcurso
+	if err = cursor.All(ctx, &reservas); err != nil {
+		return nil, err
+	}
+
+	return reservas, nil
+}
+
+// MarcarEspeciais grava a marcação de concurso especial nos resultados da
+// loteria que ainda não a têm: os concursos informados como especiais, com
+// o tipo, e os demais como regulares. Retorna quantos foram alterados.
+func (r *ResultadoRepository) MarcarEspeciais(loteria string, especiais []int, tipo string) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	semMarcacao := func() bson.M {
+		return bson.M{"_id.loteria": loteria, "especial": bson.M{"$exists": false}}
+	}
+
+	var alterados int64
+	if len(especiais) > 0 {
+		filter := semMarcacao()
+		filter["_id.concurso"] = bson.M{"$in": especiais}
+		res, err := r.collection.UpdateMany(ctx, filter, bson.M{"$set": bson.M{"especial": true, "tipoEspecial": tipo}})
+		if err != nil {
+			return 0, err
+		}
+		alterados += res.ModifiedCount
+	}
+
+	res, err := r.collection.UpdateMany(ctx, semMarcacao(), bson.M{"$set": bson.M{"especial": false}})
+	if err != nil {
+		return alterados, err
+	}
+	return alterados + res.ModifiedCount, nil
+}
+
+// FindEspeciais retorna os concursos especiais da loteria, do mais recente
+// para o mais antigo, sem os ganhadores por município.
+func (r *ResultadoRepository) FindEspeciais(loteria string) ([]model.Resultado, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"_id.loteria": loteria, "especial": true}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "_id.concurso", Value: -1}}).
+		SetProjection(bson.M{"localGanhadores": 0, "estadosPremiados": 0})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	resultados := []model.Resultado{}
+	if err = cursor.All(ctx, &resultados); err != nil {
+		return nil, err
+	}
+
+	for i := range resultados {
+		resultados[i].AfterFind()
+	}
+
+	return resultados, nil
+}
@@ -0,0 +1,371 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"loterias-api-golang/internal/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// filtroConcursos seleciona os concursos da loteria que atendem ao filtro.
+func filtroConcursos(loteria string, filtro model.FiltroConcursos) bson.M {
+	match := bson.M{"_id.loteria": loteria}
+
+	concurso := bson.M{}
+	if filtro.ConcursoInicio > 0 {
+		concurso["$gte"] = filtro.ConcursoInicio
+	}
+	if filtro.ConcursoFim > 0 {
+		concurso["$lte"] = filtro.ConcursoFim
+	}
+	if len(concurso) > 0 {
+		match["_id.concurso"] = concurso
+	}
+
+	if data := FiltroPeriodo(filtro.DataInicio, filtro.DataFim); data != nil {
+		match["dataSorteio"] = data
+	}
+
+	return match
+}
+
+// FiltroPeriodo seleciona as datas de sorteio entre inicio e fim, ambos
+// inclusivos. Retorna nil quando nenhum dos limites foi informado.
+func FiltroPeriodo(inicio, fim *time.Time) bson.M {
+	data := bson.M{}
+	if inicio != nil {
+		data["$gte"] = *inicio
+	}
+	if fim != nil {
+		data["$lte"] = *fim
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	return data
+}
+
+// FiltroDia seleciona as datas de sorteio do dia que começa em data, até a
+// meia-noite seguinte no mesmo fuso.
+func FiltroDia(data time.Time) bson.M {
+	return bson.M{"$gte": data, "$lt": data.AddDate(0, 0, 1)}
+}
+
+// pipelineFiltro monta os estágios iniciais de uma agregação, selecionando os
+// concursos da loteria que atendem ao filtro.
+func pipelineFiltro(loteria string, filtro model.FiltroConcursos) mongo.Pipeline {
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: filtroConcursos(loteria, filtro)}}}
+	if filtro.Ultimos > 0 {
+		pipeline = append(pipeline,
+			bson.D{{Key: "$sort", Value: bson.D{{Key: "_id.concurso", Value: -1}}}},
+			bson.D{{Key: "$limit", Value: filtro.Ultimos}},
+		)
+	}
+	return pipeline
+}
+
+// estagiosResumo conta os concursos selecionados e o intervalo que cobrem.
+func estagiosResumo() bson.A {
+	return bson.A{
+		bson.M{"$group": bson.M{
+			"_id":            nil,
+			"concursos":      bson.M{"$sum": 1},
+			"concursoInicio": bson.M{"$min": "$_id.concurso"},
+			"concursoFim":    bson.M{"$max": "$_id.concurso"},
+		}},
+	}
+}
+
+// estagiosContagem conta as ocorrências de cada valor de um campo array.
+// Com porPosicao a contagem é separada pela posição do valor no array.
+func estagiosContagem(campo string, porPosicao bool) bson.A {
+	chave := bson.M{"numero": "$" + campo}
+	if porPosicao {
+		chave["posicao"] = "$posicao"
+	}
+
+	return bson.A{
+		bson.M{"$unwind": bson.M{"path": "$" + campo, "includeArrayIndex": "posicao"}},
+		bson.M{"$group": bson.M{"_id": chave, "quantidade": bson.M{"$sum": 1}}},
+		bson.M{"$project": bson.M{
+			"_id":        0,
+			"numero":     "$_id.numero",
+			"posicao":    "$_id.posicao",
+			"quantidade": 1,
+		}},
+	}
+}
+
+// ContarFrequencia conta quantas vezes cada dezena e cada trevo foram
+// sorteados nos concursos selecionados, sem carregar os documentos.
+func (r *ResultadoRepository) ContarFrequencia(loteria string, filtro model.FiltroConcursos, porPosicao bool) (*model.ContagemFrequencia, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pipeline := append(pipelineFiltro(loteria, filtro), bson.D{{Key: "$facet", Value: bson.M{
+		"resumo":  estagiosResumo(),
+		"dezenas": estagiosContagem("dezenas", porPosicao),
+		"trevos":  estagiosContagem("trevos", false),
+	}}})
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var facetas []struct {
+		Resumo  []model.ContagemFrequencia `bson:"resumo"`
+		Dezenas []model.ContagemNumero     `bson:"dezenas"`
+		Trevos  []model.ContagemNumero     `bson:"trevos"`
+	}
+	if err = cursor.All(ctx, &facetas); err != nil {
+		return nil, err
+	}
+
+	contagem := &model.ContagemFrequencia{}
+	if len(facetas) == 0 {
+		return contagem, nil
+	}
+	if len(facetas[0].Resumo) > 0 {
+		*contagem = facetas[0].Resumo[0]
+	}
+	contagem.Dezenas = facetas[0].Dezenas
+	contagem.Trevos = facetas[0].Trevos
+
+	return contagem, nil
+}
+
+// estagiosAparicoes agrupa, para cada valor de um campo array, os concursos
+// em que ele apareceu. Valores repetidos no mesmo concurso contam uma vez.
+func estagiosAparicoes(campo string, porPosicao bool) bson.A {
+	chave := bson.M{"numero": "$" + campo}
+	if porPosicao {
+		chave["posicao"] = "$posicao"
+	}
+
+	return bson.A{
+		bson.M{"$unwind": bson.M{"path": "$" + campo, "includeArrayIndex": "posicao"}},
+		bson.M{"$group": bson.M{"_id": chave, "concursos": bson.M{"$addToSet": "$_id.concurso"}}},
+		bson.M{"$project": bson.M{
+			"_id":       0,
+			"numero":    "$_id.numero",
+			"posicao":   "$_id.posicao",
+			"concursos": 1,
+		}},
+	}
+}
+
+// ListarAparicoes retorna, para cada dezena e trevo, os concursos em que foi
+// sorteado. Apenas os números de concurso trafegam do banco.
+func (r *ResultadoRepository) ListarAparicoes(loteria string, filtro model.FiltroConcursos, porPosicao bool) (*model.AparicoesConcursos, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pipeline := append(pipelineFiltro(loteria, filtro), bson.D{{Key: "$facet", Value: bson.M{
+		"resumo":  estagiosResumo(),
+		"dezenas": estagiosAparicoes("dezenas", porPosicao),
+		"trevos":  estagiosAparicoes("trevos", false),
+	}}})
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var facetas []struct {
+		Resumo  []model.AparicoesConcursos `bson:"resumo"`
+		Dezenas []model.AparicoesNumero    `bson:"dezenas"`
+		Trevos  []model.AparicoesNumero    `bson:"trevos"`
+	}
+	if err = cursor.All(ctx, &facetas); err != nil {
+		return nil, err
+	}
+
+	aparicoes := &model.AparicoesConcursos{}
+	if len(facetas) == 0 {
+		return aparicoes, nil
+	}
+	if len(facetas[0].Resumo) > 0 {
+		*aparicoes = facetas[0].Resumo[0]
+	}
+	aparicoes.Dezenas = facetas[0].Dezenas
+	aparicoes.Trevos = facetas[0].Trevos
+
+	return aparicoes, nil
+}
+
+// ListarDezenas retorna apenas concurso, data, dezenas e trevos dos concursos
+// selecionados, em ordem crescente de concurso.
+func (r *ResultadoRepository) ListarDezenas(loteria string, filtro model.FiltroConcursos) ([]model.DezenasConcurso, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pipeline := append(pipelineFiltro(loteria, filtro),
+		bson.D{{Key: "$project", Value: bson.M{
+			"_id":      0,
+			"concurso": "$_id.concurso",
+			"data":     1,
+			"dezenas":  1,
+			"trevos":   1,
+		}}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "concurso", Value: 1}}}},
+	)
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var dezenas []model.DezenasConcurso
+	if err = cursor.All(ctx, &dezenas); err != nil {
+		return nil, err
+	}
+
+	return dezenas, nil
+}
+
+// ListarTimesCoracao retorna os times do coração que já saíram nos
+// resultados da loteria, sem repetição.
+func (r *ResultadoRepository) ListarTimesCoracao(loteria string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"_id.loteria": loteria, "timeCoracao": bson.M{"$nin": bson.A{"", nil}}}
+	valores, err := r.collection.Distinct(ctx, "timeCoracao", filter)
+	if err != nil {
+		return nil, err
+	}
+
+	times := make([]string, 0, len(valores))
+	for _, v := range valores {
+		if nome, ok := v.(string); ok {
+			times = append(times, nome)
+		}
+	}
+	return times, nil
+}
+
+// ListarAcumulacoes retorna, em ordem crescente de concurso, se cada concurso
+// acumulou, o prêmio acumulado para o seguinte, o que foi pago na primeira
+// faixa e se é um concurso especial.
+func (r *ResultadoRepository) ListarAcumulacoes(loteria string, filtro model.FiltroConcursos) ([]model.AcumulacaoConcurso, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	principal := bson.M{"$arrayElemAt": bson.A{
+		bson.M{"$filter": bson.M{
+			"input": bson.M{"$ifNull": bson.A{"$premiacoes", bson.A{}}},
+			"cond":  bson.M{"$eq": bson.A{"$$this.faixa", 1}},
+		}},
+		0,
+	}}
+
+	pipeline := append(pipelineFiltro(loteria, filtro),
+		bson.D{{Key: "$project", Value: bson.M{
+			"_id":                           0,
+			"concurso":                      "$_id.concurso",
+			"data":                          1,
+			"acumulou":                      1,
+			"valorAcumuladoProximoConcurso": 1,
+			"especial":                      1,
+			"principal":                     principal,
+		}}},
+		bson.D{{Key: "$project", Value: bson.M{
+			"concurso":                      1,
+			"data":                          1,
+			"acumulou":                      1,
+			"valorAcumuladoProximoConcurso": 1,
+			"especial":                      1,
+			"ganhadoresPrincipal":           "$principal.numeroDeGanhadores",
+			"premioPrincipal":               "$principal.valor",
+		}}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "concurso", Value: 1}}}},
+	)
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var concursos []model.AcumulacaoConcurso
+	if err = cursor.All(ctx, &concursos); err != nil {
+		return nil, err
+	}
+
+	return concursos, nil
+}
+
+// PercorrerResultados entrega os concursos selecionados um a um, em ordem
+// crescente, lendo do cursor sem carregar todo o histórico em memória. Os
+// campos de ganhadores por município e estado não são lidos.
+func (r *ResultadoRepository) PercorrerResultados(loteria string, filtro model.FiltroConcursos, fn func(*model.Resultado) error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	pipeline := append(pipelineFiltro(loteria, filtro),
+		bson.D{{Key: "$project", Value: bson.M{"localGanhadores": 0, "estadosPremiados": 0}}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "_id.concurso", Value: 1}}}},
+	)
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var resultado model.Resultado
+		if err := cursor.Decode(&resultado); err != nil {
+			return err
+		}
+		resultado.AfterFind()
+
+		if err := fn(&resultado); err != nil {
+			return err
+		}
+	}
+
+	return cursor.Err()
+}
+
+// PremiosMedios calcula, por faixa, a média paga por ganhador nos concursos
+// selecionados em que a faixa teve ganhadores.
+func (r *ResultadoRepository) PremiosMedios(loteria string, filtro model.FiltroConcursos) ([]model.PremioMedio, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pipeline := append(pipelineFiltro(loteria, filtro),
+		bson.D{{Key: "$unwind", Value: "$premiacoes"}},
+		bson.D{{Key: "$match", Value: bson.M{
+			"premiacoes.numeroDeGanhadores": bson.M{"$gt": 0},
+			"premiacoes.valor":              bson.M{"$gt": 0},
+		}}},
+		bson.D{{Key: "$group", Value: bson.M{
+			"_id":       "$premiacoes.faixa",
+			"valor":     bson.M{"$avg": "$premiacoes.valor"},
+			"concursos": bson.M{"$sum": 1},
+		}}},
+		bson.D{{Key: "$project", Value: bson.M{"_id": 0, "faixa": "$_id", "valor": 1, "concursos": 1}}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "faixa", Value: 1}}}},
+	)
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var medios []model.PremioMedio
+	if err = cursor.All(ctx, &medios); err != nil {
+		return nil, err
+	}
+
+	return medios, nil
+}
@@ -0,0 +1,266 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"loterias-api-golang/internal/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// collationLocal compara nomes de município e UF sem diferenciar maiúsculas
+// e acentos.
+var collationLocal = &options.Collation{Locale: "pt", Strength: 1}
+
+// condicaoLocal monta a condição sobre um item de localGanhadores. Só na
+// Federal a posição do item indica a faixa; nas demais loterias todos os
+// locais são da faixa principal e o serviço não consulta outras faixas.
+func condicaoLocal(loteria string, ganhadores model.FiltroGanhadores) bson.M {
+	condicao := bson.M{}
+	if ganhadores.UF != "" {
+		condicao["uf"] = ganhadores.UF
+	}
+	if ganhadores.Municipio != "" {
+		condicao["municipio"] = ganhadores.Municipio
+	}
+	if ganhadores.Faixa > 0 && loteria == string(model.Federal) {
+		condicao["posicao"] = ganhadores.Faixa
+	}
+	return condicao
+}
+
+// ListarGanhadores retorna os concursos com ganhadores no local, do mais
+// recente para o mais antigo, e quantos concursos atendem ao filtro. Apenas
+// data, premiações e locais dos ganhadores são lidos.
+func (r *ResultadoRepository) ListarGanhadores(loteria string, filtro model.FiltroConcursos, ganhadores model.FiltroGanhadores) ([]model.Resultado, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	match := filtroConcursos(loteria, filtro)
+	match["localGanhadores"] = bson.M{"$elemMatch": condicaoLocal(loteria, ganhadores)}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$facet", Value: bson.M{
+			"total": bson.A{bson.M{"$count": "total"}},
+			"concursos": bson.A{
+				bson.M{"$sort": bson.D{{Key: "_id.concurso", Value: -1}}},
+				bson.M{"$limit": ganhadores.Limite},
+				bson.M{"$project": bson.M{"data": 1, "premiacoes": 1, "localGanhadores": 1}},
+			},
+		}}},
+	}
+
+	opts := options.Aggregate().SetCollation(collationLocal)
+	cursor, err := r.collection.Aggregate(ctx, pipeline, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var facetas []struct {
+		Total []struct {
+			Total int `bson:"total"`
+		} `bson:"total"`
+		Concursos []model.Resultado `bson:"concursos"`
+	}
+	if err = cursor.All(ctx, &facetas); err != nil {
+		return nil, 0, err
+	}
+	if len(facetas) == 0 {
+		return []model.Resultado{}, 0, nil
+	}
+
+	total := 0
+	if len(facetas[0].Total) > 0 {
+		total = facetas[0].Total[0].Total
+	}
+	resultados := facetas[0].Concursos
+	for i := range resultados {
+		resultados[i].AfterFind()
+	}
+
+	return resultados, total, nil
+}
+
+// AgregarGanhadores soma ganhadores e prêmios pagos por UF, ou por UF e
+// município, separando a parte das apostas pela internet, com os concursos
+// premiados de cada local. O valor de cada ganhador vem da premiação da
+// faixa principal, ou do prêmio do bilhete na Federal.
+func (r *ResultadoRepository) AgregarGanhadores(loteria string, filtro model.FiltroConcursos, porMunicipio bool, ganhadores model.FiltroGanhadores) ([]model.AgregadoGanhadores, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	match := filtroConcursos(loteria, filtro)
+	match["localGanhadores.0"] = bson.M{"$exists": true}
+
+	local := bson.M{}
+	for campo, valor := range condicaoLocal(loteria, ganhadores) {
+		local["localGanhadores."+campo] = valor
+	}
+
+	var faixa interface{} = 1
+	if loteria == string(model.Federal) {
+		faixa = "$localGanhadores.posicao"
+	}
+	valorUnitario := bson.M{"$ifNull": bson.A{
+		bson.M{"$arrayElemAt": bson.A{
+			bson.M{"$map": bson.M{
+				"input": bson.M{"$filter": bson.M{
+					"input": "$premiacoes",
+					"cond":  bson.M{"$eq": bson.A{"$$this.faixa", faixa}},
+				}},
+				"in": "$$this.valor",
+			}},
+			0,
+		}},
+		0,
+	}}
+
+	chave := bson.M{"uf": "$uf"}
+	if porMunicipio {
+		chave["municipio"] = "$municipio"
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$unwind", Value: "$localGanhadores"}},
+		{{Key: "$match", Value: local}},
+		{{Key: "$project", Value: bson.M{
+			"concurso":   "$_id.concurso",
+			"uf":         bson.M{"$toUpper": bson.M{"$trim": bson.M{"input": bson.M{"$ifNull": bson.A{"$localGanhadores.uf", ""}}}}},
+			"municipio":  bson.M{"$toUpper": bson.M{"$trim": bson.M{"input": bson.M{"$ifNull": bson.A{"$localGanhadores.municipio", ""}}}}},
+			"ganhadores": bson.M{"$max": bson.A{"$localGanhadores.ganhadores", 1}},
+			"canal": bson.M{"$regexMatch": bson.M{
+				"input":   bson.M{"$ifNull": bson.A{"$localGanhadores.municipio", ""}},
+				"regex":   "CANAL ELETR",
+				"options": "i",
+			}},
+			"valorUnitario": valorUnitario,
+		}}},
+		{{Key: "$addFields", Value: bson.M{
+			"valor": bson.M{"$multiply": bson.A{"$ganhadores", "$valorUnitario"}},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":             chave,
+			"ganhadores":      bson.M{"$sum": "$ganhadores"},
+			"valor":           bson.M{"$sum": "$valor"},
+			"ganhadoresCanal": bson.M{"$sum": bson.M{"$cond": bson.A{"$canal", "$ganhadores", 0}}},
+			"valorCanal":      bson.M{"$sum": bson.M{"$cond": bson.A{"$canal", "$valor", 0}}},
+			"concursos":       bson.M{"$addToSet": "$concurso"},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":             0,
+			"uf":              "$_id.uf",
+			"municipio":       "$_id.municipio",
+			"ganhadores":      1,
+			"valor":           1,
+			"ganhadoresCanal": 1,
+			"valorCanal":      1,
+			"concursos":       1,
+		}}},
+	}
+
+	opts := options.Aggregate().SetCollation(collationLocal)
+	cursor, err := r.collection.Aggregate(ctx, pipeline, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var agregados []model.AgregadoGanhadores
+	if err = cursor.All(ctx, &agregados); err != nil {
+		return nil, err
+	}
+
+	return agregados, nil
+}
+
+// MaioresPremios retorna as faixas com ganhadores que pagaram os maiores
+// prêmios por aposta vencedora, com os municípios dos ganhadores da faixa.
+// Os locais informados são da faixa principal, exceto na Federal, em que a
+// posição do local é o prêmio do bilhete. Sem faixa, os concursos são lidos
+// pelo índice do maior prêmio pago: os N maiores prêmios estão entre os N
+// concursos de maior prêmio.
+func (r *ResultadoRepository) MaioresPremios(filtro model.FiltroPremios) ([]model.PremioPago, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	match := bson.M{"maiorPremio": bson.M{"$gt": 0}}
+	if filtro.Loteria != "" {
+		match["_id.loteria"] = filtro.Loteria
+	}
+	if filtro.Ano > 0 {
+		inicio := time.Date(filtro.Ano, time.January, 1, 0, 0, 0, 0, model.FusoBrasilia)
+		match["dataSorteio"] = bson.M{"$gte": inicio, "$lt": inicio.AddDate(1, 0, 0)}
+	}
+
+	premiada := bson.M{"premiacoes.numeroDeGanhadores": bson.M{"$gt": 0}}
+	if filtro.Faixa > 0 {
+		premiada["premiacoes.faixa"] = filtro.Faixa
+		match["premiacoes"] = bson.M{"$elemMatch": bson.M{"faixa": filtro.Faixa, "numeroDeGanhadores": bson.M{"$gt": 0}}}
+	}
+
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: match}}}
+	if filtro.Faixa == 0 {
+		pipeline = append(pipeline,
+			bson.D{{Key: "$sort", Value: bson.D{{Key: "maiorPremio", Value: -1}, {Key: "_id.concurso", Value: -1}}}},
+			bson.D{{Key: "$limit", Value: filtro.Limite}},
+		)
+	}
+
+	localDaFaixa := bson.M{"$cond": bson.A{
+		bson.M{"$eq": bson.A{"$_id.loteria", string(model.Federal)}},
+		bson.M{"$eq": bson.A{"$$this.posicao", "$premiacoes.faixa"}},
+		bson.M{"$eq": bson.A{"$premiacoes.faixa", 1}},
+	}}
+
+	pipeline = append(pipeline, mongo.Pipeline{
+		{{Key: "$unwind", Value: "$premiacoes"}},
+		{{Key: "$match", Value: premiada}},
+		{{Key: "$sort", Value: bson.D{{Key: "premiacoes.valor", Value: -1}, {Key: "_id.concurso", Value: -1}}}},
+		{{Key: "$limit", Value: filtro.Limite}},
+		{{Key: "$project", Value: bson.M{
+			"_id":              0,
+			"loteria":          "$_id.loteria",
+			"concurso":         "$_id.concurso",
+			"data":             1,
+			"faixa":            "$premiacoes.faixa",
+			"descricao":        "$premiacoes.descricao",
+			"ganhadores":       "$premiacoes.numeroDeGanhadores",
+			"valorPorGanhador": "$premiacoes.valor",
+			"valorTotal":       bson.M{"$multiply": bson.A{"$premiacoes.valor", "$premiacoes.numeroDeGanhadores"}},
+			"especial":         bson.M{"$ifNull": bson.A{"$especial", false}},
+			"tipoEspecial":     1,
+			"locais": bson.M{"$map": bson.M{
+				"input": bson.M{"$filter": bson.M{
+					"input": bson.M{"$ifNull": bson.A{"$localGanhadores", bson.A{}}},
+					"cond":  localDaFaixa,
+				}},
+				"in": bson.M{
+					"faixa":           "$premiacoes.faixa",
+					"municipio":       "$$this.municipio",
+					"uf":              "$$this.uf",
+					"ganhadores":      "$$this.ganhadores",
+					"estabelecimento": "$$this.estabelecimento",
+				},
+			}},
+		}}},
+	}...)
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	premios := []model.PremioPago{}
+	if err = cursor.All(ctx, &premios); err != nil {
+		return nil, err
+	}
+
+	return premios, nil
+}
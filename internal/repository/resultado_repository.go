@@ -22,12 +22,71 @@ func NewResultadoRepository(db *mongo.Database) *ResultadoRepository {
 	}
 }
 
-func (r *ResultadoRepository) FindByLoteria(loteria string) ([]model.Resultado, error) {
+// FindByLoteria retorna uma página dos concursos da loteria que atendem ao
+// filtro, ordenados por concurso. Busca um concurso além do limite para
+// saber se existe outra página no sentido percorrido. Com campos projetados
+// os resultados não passam por AfterFind.
+func (r *ResultadoRepository) FindByLoteria(loteria string, filtro model.FiltroConcursos, paginacao model.Paginacao) (*model.PaginaConcursos, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	filter := bson.M{"_id.loteria": loteria}
-	opts := options.Find().SetSort(bson.D{{Key: "_id.concurso", Value: -1}})
+	filter := filtroConcursos(loteria, filtro)
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	sentido := paginacao.Sentido()
+	if cursor := paginacao.Cursor; cursor != nil {
+		operador := "$gt"
+		if sentido < 0 {
+			operador = "$lt"
+		}
+		filter = bson.M{"$and": bson.A{filter, bson.M{"_id.concurso": bson.M{operador: cursor.Concurso}}}}
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "_id.concurso", Value: sentido}})
+	if paginacao.Limite > 0 {
+		opts.SetLimit(int64(paginacao.Limite) + 1)
+	}
+	if len(paginacao.Campos) > 0 {
+		projecao := bson.M{}
+		for _, campo := range paginacao.Campos {
+			projecao[campo] = 1
+		}
+		opts.SetProjection(projecao)
+	}
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	resultados := []model.Resultado{}
+	if err = cursor.All(ctx, &resultados); err != nil {
+		return nil, err
+	}
+
+	// Documentos projetados ficam como vieram do banco; ProjetarResultado
+	// lê loteria e concurso do _id.
+	if len(paginacao.Campos) == 0 {
+		for i := range resultados {
+			resultados[i].AfterFind()
+		}
+	}
+
+	return paginacao.MontarPagina(resultados, total), nil
+}
+
+// FindByData retorna os concursos de todas as loterias sorteados no dia
+// informado, que deve ser a meia-noite no fuso de Brasília.
+func (r *ResultadoRepository) FindByData(data time.Time) ([]model.Resultado, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{"dataSorteio": FiltroDia(data)}
+	opts := options.Find().SetSort(bson.D{{Key: "_id.loteria", Value: 1}, {Key: "_id.concurso", Value: 1}})
 
 	cursor, err := r.collection.Find(ctx, filter, opts)
 	if err != nil {
@@ -35,6 +94,106 @@ func (r *ResultadoRepository) FindByLoteria(loteria string) ([]model.Resultado,
 	}
 	defer cursor.Close(ctx)
 
+	resultados := []model.Resultado{}
+	if err = cursor.All(ctx, &resultados); err != nil {
+		return nil, err
+	}
+
+	for i := range resultados {
+		resultados[i].AfterFind()
+	}
+
+	return resultados, nil
+}
+
+// MigrarDatas grava as datas convertidas nos resultados que só têm as datas
+// no formato da Caixa. A conversão é feita no próprio banco.
+func (r *ResultadoRepository) MigrarDatas() (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	converter := func(campo string) bson.M {
+		return bson.M{"$dateFromString": bson.M{
+			"dateString": "$" + campo,
+			"format":     "%d/%m/%Y",
+			"timezone":   "America/Sao_Paulo",
+			"onError":    "$$REMOVE",
+			"onNull":     "$$REMOVE",
+		}}
+	}
+
+	filter := bson.M{"dataSorteio": bson.M{"$exists": false}, "data": bson.M{"$type": "string"}}
+	update := mongo.Pipeline{{{Key: "$set", Value: bson.M{
+		"dataSorteio":        converter("data"),
+		"dataProximoSorteio": converter("dataProximoConcurso"),
+	}}}}
+
+	res, err := r.collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
+
+// MigrarMaiorPremio grava o maior prêmio pago nos resultados gravados antes
+// do ranking de prêmios. Retorna quantos resultados foram migrados.
+func (r *ResultadoRepository) MigrarMaiorPremio() (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	filter := bson.M{"maiorPremio": bson.M{"$exists": false}}
+	update := mongo.Pipeline{{{Key: "$set", Value: bson.M{
+		"maiorPremio": bson.M{"$ifNull": bson.A{
+			bson.M{"$max": bson.M{"$map": bson.M{
+				"input": bson.M{"$filter": bson.M{
+					"input": bson.M{"$ifNull": bson.A{"$premiacoes", bson.A{}}},
+					"cond":  bson.M{"$gt": bson.A{"$$this.numeroDeGanhadores", 0}},
+				}},
+				"in": "$$this.valor",
+			}}},
+			0,
+		}},
+	}}}}
+
+	res, err := r.collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
+
+// CriarIndices cria os índices usados na listagem paginada, nas consultas
+// por data e na busca por números sorteados.
+func (r *ResultadoRepository) CriarIndices() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "_id.loteria", Value: 1}, {Key: "_id.concurso", Value: 1}}},
+		{Keys: bson.D{{Key: "_id.loteria", Value: 1}, {Key: "dataSorteio", Value: 1}}},
+		{Keys: bson.D{{Key: "dataSorteio", Value: 1}}},
+		{Keys: bson.D{{Key: "_id.loteria", Value: 1}, {Key: "dezenas", Value: 1}}},
+		{Keys: bson.D{{Key: "_id.loteria", Value: 1}, {Key: "trevos", Value: 1}}},
+		{Keys: bson.D{{Key: "maiorPremio", Value: -1}, {Key: "_id.concurso", Value: -1}}},
+		{Keys: bson.D{{Key: "_id.loteria", Value: 1}, {Key: "maiorPremio", Value: -1}, {Key: "_id.concurso", Value: -1}}},
+	})
+	return err
+}
+
+// FindSemSorteios retorna os resultados da loteria gravados antes da
+// separação dos sorteios. AfterFind já monta os sorteios de cada um.
+func (r *ResultadoRepository) FindSemSorteios(loteria string) ([]model.Resultado, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	filter := bson.M{"_id.loteria": loteria, "sorteios": bson.M{"$exists": false}}
+
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
 	var resultados []model.Resultado
 	if err = cursor.All(ctx, &resultados); err != nil {
 		return nil, err
@@ -89,6 +248,48 @@ func (r *ResultadoRepository) FindLatest(loteria string) (*model.Resultado, erro
 	return &resultado, nil
 }
 
+// FindUltimos retorna o concurso mais recente de cada loteria em uma única
+// consulta, sem dezenas e ganhadores. Loterias sem resultados não aparecem.
+func (r *ResultadoRepository) FindUltimos() ([]model.Resultado, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$sort", Value: bson.D{{Key: "_id.loteria", Value: 1}, {Key: "_id.concurso", Value: -1}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":                           "$_id.loteria",
+			"id":                            bson.M{"$first": "$_id"},
+			"data":                          bson.M{"$first": "$data"},
+			"dataSorteio":                   bson.M{"$first": "$dataSorteio"},
+			"acumulou":                      bson.M{"$first": "$acumulou"},
+			"proximoConcurso":               bson.M{"$first": "$proximoConcurso"},
+			"dataProximoConcurso":           bson.M{"$first": "$dataProximoConcurso"},
+			"dataProximoSorteio":            bson.M{"$first": "$dataProximoSorteio"},
+			"valorAcumuladoProximoConcurso": bson.M{"$first": "$valorAcumuladoProximoConcurso"},
+			"valorEstimadoProximoConcurso":  bson.M{"$first": "$valorEstimadoProximoConcurso"},
+		}}},
+		{{Key: "$set", Value: bson.M{"_id": "$id"}}},
+		{{Key: "$unset", Value: "id"}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	resultados := []model.Resultado{}
+	if err = cursor.All(ctx, &resultados); err != nil {
+		return nil, err
+	}
+
+	for i := range resultados {
+		resultados[i].AfterFind()
+	}
+
+	return resultados, nil
+}
+
 func (r *ResultadoRepository) Save(resultado *model.Resultado) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
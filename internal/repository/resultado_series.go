@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"loterias-api-golang/internal/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// SerieValores agrupa um campo numérico por concurso, mês ou ano, com soma,
+// média e máximo de cada período.
+func (r *ResultadoRepository) SerieValores(loteria string, filtro model.FiltroConcursos, campo string, agrupamento string) ([]model.PontoSerie, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pipeline := append(pipelineFiltro(loteria, filtro), EstagiosSerie(campo, agrupamento)...)
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	pontos := []model.PontoSerie{}
+	if err = cursor.All(ctx, &pontos); err != nil {
+		return nil, err
+	}
+
+	return pontos, nil
+}
+
+// EstagiosSerie monta os estágios que agrupam o campo por período. Meses e
+// anos seguem o horário de Brasília e exigem a data convertida do sorteio.
+// Concursos sem o valor ficam fora da soma, da média e do máximo, que ficam
+// nulos quando nenhum concurso do período tem o valor.
+func EstagiosSerie(campo string, agrupamento string) mongo.Pipeline {
+	estagios := mongo.Pipeline{}
+
+	var periodo any
+	switch agrupamento {
+	case model.AgruparPorMes, model.AgruparPorAno:
+		formato := "%Y-%m"
+		if agrupamento == model.AgruparPorAno {
+			formato = "%Y"
+		}
+		estagios = append(estagios, bson.D{{Key: "$match", Value: bson.M{"dataSorteio": bson.M{"$type": "date"}}}})
+		periodo = bson.M{"$dateToString": bson.M{
+			"date":     "$dataSorteio",
+			"format":   formato,
+			"timezone": "America/Sao_Paulo",
+		}}
+	default:
+		periodo = bson.M{"$toString": "$_id.concurso"}
+	}
+
+	return append(estagios,
+		bson.D{{Key: "$project", Value: bson.M{
+			"concurso": "$_id.concurso",
+			"data":     1,
+			"periodo":  periodo,
+			"valor":    "$" + campo,
+		}}},
+		bson.D{{Key: "$group", Value: bson.M{
+			"_id":               "$periodo",
+			"data":              bson.M{"$first": "$data"},
+			"concursoInicio":    bson.M{"$min": "$concurso"},
+			"concursoFim":       bson.M{"$max": "$concurso"},
+			"concursos":         bson.M{"$sum": 1},
+			"concursosComValor": bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$isNumber": "$valor"}, 1, 0}}},
+			"soma":              bson.M{"$sum": "$valor"},
+			"media":             bson.M{"$avg": "$valor"},
+			"maximo":            bson.M{"$max": "$valor"},
+		}}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "concursoInicio", Value: 1}}}},
+	)
+}
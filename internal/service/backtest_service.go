@@ -0,0 +1,373 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"loterias-api-golang/internal/model"
+	"loterias-api-golang/internal/repository"
+)
+
+const (
+	janelaPadraoBacktest = 50
+	janelaMaximaBacktest = 1000
+)
+
+type BacktestService struct {
+	repository *repository.ResultadoRepository
+}
+
+func NewBacktestService(repository *repository.ResultadoRepository) *BacktestService {
+	return &BacktestService{
+		repository: repository,
+	}
+}
+
+// Executar repete a estratégia em cada concurso selecionado, em ordem de
+// concurso. Em quentes e frias os concursos anteriores ao início do filtro
+// também são percorridos, para que a janela já esteja completa no primeiro
+// concurso. Com ultimos, são simulados os N concursos que terminam em
+// concursoFim ou no concurso mais recente.
+func (s *BacktestService) Executar(loteria string, pedido model.PedidoBacktest, filtro model.FiltroConcursos) (*model.Backtest, error) {
+	regras, ok := model.GetRegras(loteria)
+	if !ok {
+		return nil, &model.LoteriaInvalidException{Message: fmt.Sprintf("'%s' não é uma loteria suportada", loteria)}
+	}
+	if regras.Tipo == model.TipoBilhete {
+		return nil, &model.ParametroInvalidoException{Message: "Backtest não disponível para a loteria " + loteria}
+	}
+
+	simulacao, err := NovaSimulacao(regras, pedido)
+	if err != nil {
+		return nil, err
+	}
+
+	inicio := filtro.ConcursoInicio
+	if filtro.Ultimos > 0 {
+		fim := filtro.ConcursoFim
+		if fim == 0 {
+			ultimo, err := s.repository.FindLatest(loteria)
+			if err != nil {
+				return nil, err
+			}
+			fim = ultimo.Concurso
+		}
+		if fim-filtro.Ultimos+1 > inicio {
+			inicio = fim - filtro.Ultimos + 1
+		}
+	}
+
+	err = s.repository.PercorrerResultados(loteria, simulacao.FiltroHistorico(filtro, inicio), func(resultado *model.Resultado) error {
+		apostar := resultado.Concurso >= inicio &&
+			(filtro.DataInicio == nil || (resultado.DataSorteio != nil && !resultado.DataSorteio.Before(*filtro.DataInicio)))
+		return simulacao.Processar(resultado, apostar)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return simulacao.Resumo(), nil
+}
+
+// FiltroHistorico retorna os concursos que a simulação precisa percorrer
+// para apostar a partir de inicio: os da janela de quentes e frias antes
+// dele e, nas demais estratégias, apenas os selecionados.
+func (s *Simulacao) FiltroHistorico(filtro model.FiltroConcursos, inicio int) model.FiltroConcursos {
+	historico := model.FiltroConcursos{ConcursoFim: filtro.ConcursoFim, DataFim: filtro.DataFim}
+	if s.pedido.Janela == 0 {
+		historico.ConcursoInicio = inicio
+		historico.DataInicio = filtro.DataInicio
+	} else if inicio > s.pedido.Janela {
+		historico.ConcursoInicio = inicio - s.pedido.Janela
+	}
+	return historico
+}
+
+// Simulacao acompanha uma estratégia ao longo dos concursos. Os concursos
+// devem ser entregues em ordem crescente a Processar, inclusive os que só
+// alimentam a janela de quentes e frias.
+type Simulacao struct {
+	regras       model.Regras
+	pedido       model.PedidoBacktest
+	rng          *rand.Rand
+	janela       [][]int
+	janelaTrevos [][]int
+	backtest     *model.Backtest
+	faixas       map[int]*model.ResumoFaixa
+	acertos      map[int]int
+}
+
+// NovaSimulacao valida o pedido e prepara a simulação. A estratégia "fixas"
+// aceita qualquer aposta válida, inclusive colunas da Super Sete; as demais
+// montam apostas de dezenas. Em todas, o time do coração e o mês da sorte
+// da aposta são repetidos em cada concurso e validados antes da simulação.
+func NovaSimulacao(regras model.Regras, pedido model.PedidoBacktest) (*Simulacao, error) {
+	backtest := &model.Backtest{
+		Loteria:             string(regras.Loteria),
+		Estrategia:          pedido.Estrategia,
+		Faixas:              []model.ResumoFaixa{},
+		DistribuicaoAcertos: []model.ContagemAcertos{},
+		Rodadas:             []model.RodadaBacktest{},
+	}
+
+	switch pedido.Estrategia {
+	case model.EstrategiaFixas:
+		if _, err := prepararAposta(regras, pedido.Aposta); err != nil {
+			return nil, err
+		}
+	case model.EstrategiaQuentes, model.EstrategiaFrias, model.EstrategiaAleatoria:
+		if regras.Tipo != model.TipoDezenas {
+			return nil, &model.ParametroInvalidoException{
+				Message: fmt.Sprintf("A estratégia %s não está disponível para a loteria %s; use a estratégia %s", pedido.Estrategia, regras.Loteria, model.EstrategiaFixas),
+			}
+		}
+		numeros, trevos, err := tamanhoAposta(regras, pedido.Numeros, pedido.Trevos)
+		if err != nil {
+			return nil, err
+		}
+		pedido.Numeros, pedido.Trevos = numeros, trevos
+	case "":
+		return nil, &model.ParametroInvalidoException{Message: "Informe a estratégia: fixas, quentes, frias ou aleatoria"}
+	default:
+		return nil, &model.ParametroInvalidoException{
+			Message: fmt.Sprintf("Estratégia '%s' inválida; use fixas, quentes, frias ou aleatoria", pedido.Estrategia),
+		}
+	}
+
+	if err := validarEspeciais(regras, pedido.Aposta); err != nil {
+		return nil, err
+	}
+
+	if pedido.Estrategia == model.EstrategiaQuentes || pedido.Estrategia == model.EstrategiaFrias {
+		if pedido.Janela == 0 {
+			pedido.Janela = janelaPadraoBacktest
+		}
+		if pedido.Janela < 1 || pedido.Janela > janelaMaximaBacktest {
+			return nil, &model.ParametroInvalidoException{
+				Message: fmt.Sprintf("A janela deve estar entre 1 e %d concursos", janelaMaximaBacktest),
+			}
+		}
+		backtest.Janela = pedido.Janela
+	}
+	if pedido.Estrategia == model.EstrategiaAleatoria {
+		if pedido.Semente == 0 {
+			pedido.Semente = time.Now().UnixNano()
+		}
+		backtest.Semente = pedido.Semente
+	}
+
+	return &Simulacao{
+		regras:   regras,
+		pedido:   pedido,
+		rng:      rand.New(rand.NewSource(pedido.Semente)),
+		backtest: backtest,
+		faixas:   make(map[int]*model.ResumoFaixa),
+		acertos:  make(map[int]int),
+	}, nil
+}
+
+// validarEspeciais exige o time do coração e o mês da sorte nas loterias
+// que os premiam e os recusa nas demais.
+func validarEspeciais(regras model.Regras, aposta model.Aposta) error {
+	timeCoracao := strings.TrimSpace(aposta.TimeCoracao)
+	if regras.TemEspecial(model.EspecialTimeCoracao) && timeCoracao == "" {
+		return &model.ApostaInvalidaException{Message: "Informe o time do coração da aposta"}
+	}
+	if !regras.TemEspecial(model.EspecialTimeCoracao) && timeCoracao != "" {
+		return &model.ApostaInvalidaException{Message: "A loteria " + string(regras.Loteria) + " não tem time do coração"}
+	}
+
+	mes := strings.TrimSpace(aposta.MesSorte)
+	if !regras.MesSorte {
+		if mes != "" {
+			return &model.ApostaInvalidaException{Message: "A loteria " + string(regras.Loteria) + " não tem mês da sorte"}
+		}
+		return nil
+	}
+	for _, m := range mesesSorte {
+		if strings.EqualFold(normalizarMes(mes), m) {
+			return nil
+		}
+	}
+	return &model.ApostaInvalidaException{Message: fmt.Sprintf("'%s' não é um mês da sorte válido", aposta.MesSorte)}
+}
+
+// Processar joga a aposta da estratégia no concurso, quando apostar é true e
+// a estratégia já tem histórico suficiente, e depois inclui o resultado na
+// janela.
+func (s *Simulacao) Processar(resultado *model.Resultado, apostar bool) error {
+	if apostar {
+		if aposta, ok := s.proximaAposta(); ok {
+			if err := s.jogar(resultado, aposta); err != nil {
+				return err
+			}
+		}
+	}
+
+	if s.pedido.Janela > 0 {
+		var dezenas []int
+		for sorteio := 1; sorteio <= s.regras.Sorteios; sorteio++ {
+			dezenas = append(dezenas, parseDezenas(resultado.DezenasDoSorteio(sorteio))...)
+		}
+		s.janela = append(s.janela, dezenas)
+		s.janelaTrevos = append(s.janelaTrevos, parseDezenas(resultado.Trevos))
+		if len(s.janela) > s.pedido.Janela {
+			s.janela = s.janela[1:]
+			s.janelaTrevos = s.janelaTrevos[1:]
+		}
+	}
+	return nil
+}
+
+func (s *Simulacao) jogar(resultado *model.Resultado, aposta model.Aposta) error {
+	preparada, err := prepararAposta(s.regras, aposta)
+	if err != nil {
+		return err
+	}
+	conferencia, err := preparada.conferir(resultado)
+	if err != nil {
+		return err
+	}
+
+	backtest := s.backtest
+	if backtest.Concursos == 0 {
+		backtest.ConcursoInicio = resultado.Concurso
+	}
+	backtest.ConcursoFim = resultado.Concurso
+	backtest.Concursos++
+
+	rodada := model.RodadaBacktest{
+		Concurso: resultado.Concurso,
+		Data:     resultado.Data,
+		Aposta:   aposta,
+		Acertos:  []int{},
+		Custo:    preparada.preco(),
+		Premio:   arredondarCentavos(conferencia.ValorTotal),
+	}
+	for _, sorteio := range conferencia.Sorteios {
+		rodada.Acertos = append(rodada.Acertos, sorteio.Acertos)
+		s.acertos[sorteio.Acertos]++
+		for _, p := range sorteio.Premios {
+			resumo, ok := s.faixas[p.Faixa]
+			if !ok {
+				resumo = &model.ResumoFaixa{Faixa: p.Faixa, Descricao: p.Descricao}
+				s.faixas[p.Faixa] = resumo
+			}
+			resumo.Quantidade += p.Quantidade
+			resumo.Valor += p.Valor
+		}
+	}
+
+	backtest.Custo += rodada.Custo
+	backtest.ValorTotal += conferencia.ValorTotal
+	if conferencia.Premiado {
+		backtest.ConcursosPremiados++
+	}
+	rodada.Saldo = arredondarCentavos(backtest.ValorTotal - backtest.Custo)
+	backtest.Rodadas = append(backtest.Rodadas, rodada)
+	return nil
+}
+
+// proximaAposta monta a aposta da estratégia para o próximo concurso.
+// Quentes e frias só apostam com a janela completa.
+func (s *Simulacao) proximaAposta() (model.Aposta, bool) {
+	if s.pedido.Estrategia == model.EstrategiaFixas {
+		return s.pedido.Aposta, true
+	}
+
+	regras := s.regras
+	var dezenas, trevos []int
+	switch s.pedido.Estrategia {
+	case model.EstrategiaAleatoria:
+		dezenas = s.sortear(regras.MenorNumero, regras.MaiorNumero, s.pedido.Numeros)
+		if regras.Trevos != nil {
+			trevos = s.sortear(regras.Trevos.MenorNumero, regras.Trevos.MaiorNumero, s.pedido.Trevos)
+		}
+	default:
+		if len(s.janela) < s.pedido.Janela {
+			return model.Aposta{}, false
+		}
+		frias := s.pedido.Estrategia == model.EstrategiaFrias
+		dezenas = EscolherPorFrequencia(s.janela, regras.MenorNumero, regras.MaiorNumero, s.pedido.Numeros, frias)
+		if regras.Trevos != nil {
+			trevos = EscolherPorFrequencia(s.janelaTrevos, regras.Trevos.MenorNumero, regras.Trevos.MaiorNumero, s.pedido.Trevos, frias)
+		}
+	}
+
+	aposta := model.Aposta{TimeCoracao: s.pedido.Aposta.TimeCoracao, MesSorte: s.pedido.Aposta.MesSorte}
+	for _, d := range dezenas {
+		aposta.Dezenas = append(aposta.Dezenas, formatarDezena(d))
+	}
+	for _, t := range trevos {
+		aposta.Trevos = append(aposta.Trevos, strconv.Itoa(t))
+	}
+	return aposta, true
+}
+
+// sortear escolhe quantidade números distintos do intervalo com o gerador
+// da simulação, para que a mesma semente repita as apostas.
+func (s *Simulacao) sortear(menor, maior, quantidade int) []int {
+	numeros := s.rng.Perm(maior - menor + 1)[:quantidade]
+	for i := range numeros {
+		numeros[i] += menor
+	}
+	sort.Ints(numeros)
+	return numeros
+}
+
+// Resumo arredonda os totais e monta faixas e distribuição de acertos.
+func (s *Simulacao) Resumo() *model.Backtest {
+	backtest := s.backtest
+	for _, faixa := range s.regras.Faixas {
+		if resumo, ok := s.faixas[faixa.Faixa]; ok {
+			resumo.Valor = arredondarCentavos(resumo.Valor)
+			backtest.Faixas = append(backtest.Faixas, *resumo)
+		}
+	}
+	for n := 0; n <= s.regras.MaxNumeros; n++ {
+		if s.acertos[n] > 0 {
+			backtest.DistribuicaoAcertos = append(backtest.DistribuicaoAcertos, model.ContagemAcertos{Acertos: n, Sorteios: s.acertos[n]})
+		}
+	}
+
+	backtest.Saldo = arredondarCentavos(backtest.ValorTotal - backtest.Custo)
+	if backtest.Custo > 0 {
+		backtest.ROI = math.Round(backtest.Saldo/backtest.Custo*10000) / 100
+	}
+	backtest.Custo = arredondarCentavos(backtest.Custo)
+	backtest.ValorTotal = arredondarCentavos(backtest.ValorTotal)
+	return backtest
+}
+
+// EscolherPorFrequencia retorna, em ordem crescente, os quantidade números
+// do intervalo mais sorteados nos concursos informados, ou os menos
+// sorteados quando menos é true. Empates favorecem o menor número.
+func EscolherPorFrequencia(concursos [][]int, menor, maior, quantidade int, menos bool) []int {
+	contagem := make(map[int]int)
+	for _, numeros := range concursos {
+		for _, n := range numeros {
+			contagem[n]++
+		}
+	}
+
+	candidatos := make([]int, 0, maior-menor+1)
+	for n := menor; n <= maior; n++ {
+		candidatos = append(candidatos, n)
+	}
+	sort.SliceStable(candidatos, func(i, j int) bool {
+		a, b := contagem[candidatos[i]], contagem[candidatos[j]]
+		if menos {
+			return a < b
+		}
+		return a > b
+	})
+
+	escolhidos := append([]int{}, candidatos[:quantidade]...)
+	sort.Ints(escolhidos)
+	return escolhidos
+}
@@ -0,0 +1,251 @@
+package service_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"loterias-api-golang/internal/model"
+	"loterias-api-golang/internal/service"
+)
+
+func resultadoMegaSena(concurso int, dezenas ...string) *model.Resultado {
+	resultado := &model.Resultado{
+		ID:      model.ResultadoID{Loteria: "megasena", Concurso: concurso},
+		Dezenas: dezenas,
+		Premiacoes: []model.Premiacao{
+			{Faixa: 1, Descricao: "6 acertos", NumeroDeGanhadores: 1, Valor: 1000000},
+			{Faixa: 2, Descricao: "5 acertos", NumeroDeGanhadores: 10, Valor: 50000},
+			{Faixa: 3, Descricao: "4 acertos", NumeroDeGanhadores: 100, Valor: 1000},
+		},
+	}
+	resultado.AfterFind()
+	return resultado
+}
+
+func TestEscolherPorFrequencia(t *testing.T) {
+	concursos := [][]int{{1, 2, 3}, {1, 2, 4}, {1, 5, 6}}
+
+	if got := service.EscolherPorFrequencia(concursos, 1, 6, 2, false); !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Errorf("quentes = %v, want [1 2]", got)
+	}
+	// Empates entre os menos sorteados favorecem o menor número.
+	if got := service.EscolherPorFrequencia(concursos, 1, 7, 2, true); !reflect.DeepEqual(got, []int{3, 7}) {
+		t.Errorf("frias = %v, want [3 7]", got)
+	}
+}
+
+func TestSimulacaoFixas(t *testing.T) {
+	regras, _ := model.MegaSena.Regras()
+	pedido := model.PedidoBacktest{
+		Estrategia: model.EstrategiaFixas,
+		Aposta:     model.Aposta{Dezenas: []string{"01", "02", "03", "04", "05", "06"}},
+	}
+	simulacao, err := service.NovaSimulacao(regras, pedido)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	resultados := []*model.Resultado{
+		resultadoMegaSena(1, "10", "20", "30", "40", "50", "60"),
+		resultadoMegaSena(2, "01", "02", "03", "04", "50", "60"),
+		resultadoMegaSena(3, "01", "02", "03", "04", "05", "60"),
+	}
+	for i, resultado := range resultados {
+		if err := simulacao.Processar(resultado, i > 0); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	backtest := simulacao.Resumo()
+
+	if backtest.Concursos != 2 || backtest.ConcursoInicio != 2 || backtest.ConcursoFim != 3 {
+		t.Errorf("concursos = %d (%d a %d), want 2 (2 a 3)", backtest.Concursos, backtest.ConcursoInicio, backtest.ConcursoFim)
+	}
+	if backtest.Custo != 12 || backtest.ValorTotal != 51000 || backtest.Saldo != 50988 {
+		t.Errorf("custo = %v, valor = %v, saldo = %v, want 12, 51000 e 50988", backtest.Custo, backtest.ValorTotal, backtest.Saldo)
+	}
+	if backtest.ROI != 424900 || backtest.ConcursosPremiados != 2 {
+		t.Errorf("ROI = %v, premiados = %d, want 424900 e 2", backtest.ROI, backtest.ConcursosPremiados)
+	}
+	if len(backtest.Rodadas) != 2 || backtest.Rodadas[0].Saldo != 994 || !reflect.DeepEqual(backtest.Rodadas[1].Acertos, []int{5}) {
+		t.Errorf("rodadas = %+v, want saldo 994 na primeira e 5 acertos na segunda", backtest.Rodadas)
+	}
+	want := []model.ResumoFaixa{
+		{Faixa: 2, Descricao: "5 acertos", Quantidade: 1, Valor: 50000},
+		{Faixa: 3, Descricao: "4 acertos", Quantidade: 1, Valor: 1000},
+	}
+	if !reflect.DeepEqual(backtest.Faixas, want) {
+		t.Errorf("Faixas = %+v, want %+v", backtest.Faixas, want)
+	}
+}
+
+func TestSimulacaoQuentes(t *testing.T) {
+	regras, _ := model.MegaSena.Regras()
+	simulacao, err := service.NovaSimulacao(regras, model.PedidoBacktest{Estrategia: model.EstrategiaQuentes, Janela: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	resultados := []*model.Resultado{
+		resultadoMegaSena(1, "01", "02", "03", "04", "05", "06"),
+		resultadoMegaSena(2, "01", "02", "03", "10", "11", "12"),
+		resultadoMegaSena(3, "01", "02", "03", "20", "21", "22"),
+	}
+	for _, resultado := range resultados {
+		if err := simulacao.Processar(resultado, true); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	backtest := simulacao.Resumo()
+
+	// Só o terceiro concurso tem dois concursos anteriores na janela.
+	if len(backtest.Rodadas) != 1 {
+		t.Fatalf("rodadas = %d, want 1", len(backtest.Rodadas))
+	}
+	want := []string{"01", "02", "03", "04", "05", "06"}
+	if got := backtest.Rodadas[0].Aposta.Dezenas; !reflect.DeepEqual(got, want) {
+		t.Errorf("aposta = %v, want %v", got, want)
+	}
+}
+
+func TestSimulacaoAleatoriaReproduzivel(t *testing.T) {
+	regras, _ := model.MaisMilionaria.Regras()
+	pedido := model.PedidoBacktest{Estrategia: model.EstrategiaAleatoria, Semente: 42}
+
+	apostas := make([][]model.Aposta, 2)
+	for i := range apostas {
+		simulacao, err := service.NovaSimulacao(regras, pedido)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for concurso := 1; concurso <= 3; concurso++ {
+			resultado := &model.Resultado{
+				ID:      model.ResultadoID{Loteria: "maismilionaria", Concurso: concurso},
+				Dezenas: []string{"01", "02", "03", "04", "05", "06"},
+				Trevos:  []string{"1", "2"},
+			}
+			resultado.AfterFind()
+			if err := simulacao.Processar(resultado, true); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+		for _, rodada := range simulacao.Resumo().Rodadas {
+			if len(rodada.Aposta.Dezenas) != 6 || len(rodada.Aposta.Trevos) != 2 {
+				t.Fatalf("aposta = %+v, want 6 dezenas e 2 trevos", rodada.Aposta)
+			}
+			apostas[i] = append(apostas[i], rodada.Aposta)
+		}
+	}
+
+	if !reflect.DeepEqual(apostas[0], apostas[1]) {
+		t.Errorf("apostas com a mesma semente diferem: %v e %v", apostas[0], apostas[1])
+	}
+}
+
+func TestNovaSimulacaoInvalida(t *testing.T) {
+	megaSena, _ := model.MegaSena.Regras()
+	superSete, _ := model.SuperSete.Regras()
+	timemania, _ := model.Timemania.Regras()
+	diaDeSorte, _ := model.DiaDeSorte.Regras()
+
+	tests := []struct {
+		name   string
+		regras model.Regras
+		pedido model.PedidoBacktest
+	}{
+		{"Sem estratégia", megaSena, model.PedidoBacktest{}},
+		{"Estratégia desconhecida", megaSena, model.PedidoBacktest{Estrategia: "martingale"}},
+		{"Aposta fixa inválida", megaSena, model.PedidoBacktest{Estrategia: model.EstrategiaFixas, Aposta: model.Aposta{Dezenas: []string{"01"}}}},
+		{"Janela acima do máximo", megaSena, model.PedidoBacktest{Estrategia: model.EstrategiaFrias, Janela: 5000}},
+		{"Quentes na Super Sete", superSete, model.PedidoBacktest{Estrategia: model.EstrategiaQuentes}},
+		{"Timemania sem time do coração", timemania, model.PedidoBacktest{Estrategia: model.EstrategiaAleatoria}},
+		{"Timemania com time em branco", timemania, model.PedidoBacktest{Estrategia: model.EstrategiaQuentes, Aposta: model.Aposta{TimeCoracao: "  "}}},
+		{"Dia de Sorte sem mês", diaDeSorte, model.PedidoBacktest{Estrategia: model.EstrategiaFrias}},
+		{"Dia de Sorte com mês inválido", diaDeSorte, model.PedidoBacktest{Estrategia: model.EstrategiaAleatoria, Aposta: model.Aposta{MesSorte: "13"}}},
+		{"Mês da sorte na Mega-Sena", megaSena, model.PedidoBacktest{Estrategia: model.EstrategiaAleatoria, Aposta: model.Aposta{MesSorte: "Março"}}},
+		{"Time do coração na Mega-Sena", megaSena, model.PedidoBacktest{Estrategia: model.EstrategiaQuentes, Aposta: model.Aposta{TimeCoracao: "SANTOS/SP"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := service.NovaSimulacao(tt.regras, tt.pedido); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestNovaSimulacaoEspeciais(t *testing.T) {
+	timemania, _ := model.Timemania.Regras()
+	diaDeSorte, _ := model.DiaDeSorte.Regras()
+
+	tests := []struct {
+		name   string
+		regras model.Regras
+		aposta model.Aposta
+	}{
+		{"Timemania", timemania, model.Aposta{TimeCoracao: "SANTOS/SP"}},
+		{"Dia de Sorte pelo nome", diaDeSorte, model.Aposta{MesSorte: "março"}},
+		{"Dia de Sorte pelo número", diaDeSorte, model.Aposta{MesSorte: "3"}},
+	}
+
+	for _, tt := range tests {
+		for _, estrategia := range []string{model.EstrategiaQuentes, model.EstrategiaFrias, model.EstrategiaAleatoria} {
+			t.Run(tt.name+"/"+estrategia, func(t *testing.T) {
+				if _, err := service.NovaSimulacao(tt.regras, model.PedidoBacktest{Estrategia: estrategia, Aposta: tt.aposta}); err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			})
+		}
+	}
+}
+
+func TestSimulacaoFiltroHistorico(t *testing.T) {
+	regras, _ := model.MegaSena.Regras()
+	dataInicio := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	filtro := model.FiltroConcursos{ConcursoFim: 2800, DataInicio: &dataInicio, Ultimos: 100}
+
+	tests := []struct {
+		name   string
+		pedido model.PedidoBacktest
+		inicio int
+		want   model.FiltroConcursos
+	}{
+		{
+			name:   "Fixas começam no primeiro concurso da janela",
+			pedido: model.PedidoBacktest{Estrategia: model.EstrategiaFixas, Aposta: model.Aposta{Dezenas: []string{"01", "02", "03", "04", "05", "06"}}},
+			inicio: 2701,
+			want:   model.FiltroConcursos{ConcursoInicio: 2701, ConcursoFim: 2800, DataInicio: &dataInicio},
+		},
+		{
+			name:   "Aleatória começa no primeiro concurso da janela",
+			pedido: model.PedidoBacktest{Estrategia: model.EstrategiaAleatoria, Semente: 1},
+			inicio: 2701,
+			want:   model.FiltroConcursos{ConcursoInicio: 2701, ConcursoFim: 2800, DataInicio: &dataInicio},
+		},
+		{
+			name:   "Quentes leem a janela de frequência antes do início",
+			pedido: model.PedidoBacktest{Estrategia: model.EstrategiaQuentes, Janela: 50},
+			inicio: 2701,
+			want:   model.FiltroConcursos{ConcursoInicio: 2651, ConcursoFim: 2800},
+		},
+		{
+			name:   "Frias sem concursos anteriores bastantes",
+			pedido: model.PedidoBacktest{Estrategia: model.EstrategiaFrias, Janela: 50},
+			inicio: 20,
+			want:   model.FiltroConcursos{ConcursoFim: 2800},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			simulacao, err := service.NovaSimulacao(regras, tt.pedido)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := simulacao.FiltroHistorico(filtro, tt.inicio); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FiltroHistorico = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,472 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"loterias-api-golang/internal/model"
+	"loterias-api-golang/internal/repository"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type BolaoService struct {
+	repository       *repository.BolaoRepository
+	resultadoService *ResultadoService
+}
+
+func NewBolaoService(repository *repository.BolaoRepository, resultadoService *ResultadoService) *BolaoService {
+	return &BolaoService{
+		repository:       repository,
+		resultadoService: resultadoService,
+	}
+}
+
+func (s *BolaoService) Listar(loteria string) ([]model.Bolao, error) {
+	return s.repository.FindAll(loteria)
+}
+
+func (s *BolaoService) Buscar(id string) (*model.BolaoDetalhado, error) {
+	bolao, err := s.buscarBolao(id)
+	if err != nil {
+		return nil, err
+	}
+
+	bilhetes, err := s.repository.FindBilhetes(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.BolaoDetalhado{Bolao: *bolao, Bilhetes: bilhetes}, nil
+}
+
+// Criar valida e grava um novo bolão.
+func (s *BolaoService) Criar(dados model.Bolao) (*model.Bolao, error) {
+	bolao, err := NovoBolao(dados)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.Save(bolao); err != nil {
+		return nil, err
+	}
+	return bolao, nil
+}
+
+// NovoBolao monta e valida um bolão a partir dos dados enviados. Os
+// participantes enviados junto com o bolão recebem identificadores próprios.
+func NovoBolao(dados model.Bolao) (*model.Bolao, error) {
+	agora := time.Now()
+	bolao := &model.Bolao{
+		ID:             primitive.NewObjectID().Hex(),
+		Nome:           strings.TrimSpace(dados.Nome),
+		Loteria:        dados.Loteria,
+		ConcursoInicio: dados.ConcursoInicio,
+		ConcursoFim:    dados.ConcursoFim,
+		Participantes:  []model.Participante{},
+		CriadoEm:       agora,
+		AtualizadoEm:   agora,
+	}
+	if err := validarBolao(bolao); err != nil {
+		return nil, err
+	}
+
+	for _, p := range dados.Participantes {
+		participante, err := novoParticipante(p)
+		if err != nil {
+			return nil, err
+		}
+		bolao.Participantes = append(bolao.Participantes, participante)
+	}
+
+	return bolao, nil
+}
+
+// Atualizar altera nome e faixa de concursos do bolão. A loteria não pode
+// ser trocada depois que o bolão existe. Se a faixa mudar, os bilhetes são
+// conferidos novamente.
+func (s *BolaoService) Atualizar(id string, dados model.Bolao) (*model.Bolao, error) {
+	bolao, err := s.buscarBolao(id)
+	if err != nil {
+		return nil, err
+	}
+	if dados.Loteria != "" && dados.Loteria != bolao.Loteria {
+		return nil, &model.ParametroInvalidoException{Message: "A loteria de um bolão não pode ser alterada"}
+	}
+
+	faixaAlterada := dados.ConcursoInicio != bolao.ConcursoInicio || dados.ConcursoFim != bolao.ConcursoFim
+	bolao.Nome = strings.TrimSpace(dados.Nome)
+	bolao.ConcursoInicio = dados.ConcursoInicio
+	bolao.ConcursoFim = dados.ConcursoFim
+	bolao.AtualizadoEm = time.Now()
+	if err := validarBolao(bolao); err != nil {
+		return nil, err
+	}
+
+	bolao, err = s.repository.Atualizar(bolao)
+	if err != nil {
+		return nil, err
+	}
+	if bolao == nil {
+		return nil, &model.ResourceNotFoundException{Message: "Pool not found"}
+	}
+
+	if faixaAlterada {
+		bilhetes, err := s.repository.FindBilhetes(id)
+		if err != nil {
+			return nil, err
+		}
+		for i := range bilhetes {
+			if err := s.conferirBilhete(bolao, &bilhetes[i]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return bolao, nil
+}
+
+func (s *BolaoService) Excluir(id string) error {
+	if _, err := s.buscarBolao(id); err != nil {
+		return err
+	}
+	return s.repository.Delete(id)
+}
+
+// AdicionarParticipante inclui um participante no bolão. As alterações de
+// participantes são aplicadas no banco item a item, sem regravar o bolão,
+// para não perder alterações simultâneas.
+func (s *BolaoService) AdicionarParticipante(id string, dados model.Participante) (*model.Bolao, error) {
+	participante, err := novoParticipante(dados)
+	if err != nil {
+		return nil, err
+	}
+
+	bolao, err := s.repository.AdicionarParticipante(id, participante, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if bolao == nil {
+		return nil, &model.ResourceNotFoundException{Message: "Pool not found"}
+	}
+	return bolao, nil
+}
+
+func (s *BolaoService) AtualizarParticipante(id, participanteID string, dados model.Participante) (*model.Bolao, error) {
+	dados.ID = participanteID
+	dados.Nome = strings.TrimSpace(dados.Nome)
+	if err := validarParticipante(dados); err != nil {
+		return nil, err
+	}
+
+	bolao, err := s.repository.AtualizarParticipante(id, dados, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if bolao == nil {
+		return nil, s.participanteNaoEncontrado(id)
+	}
+	return bolao, nil
+}
+
+func (s *BolaoService) RemoverParticipante(id, participanteID string) (*model.Bolao, error) {
+	bolao, err := s.repository.RemoverParticipante(id, participanteID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if bolao == nil {
+		return nil, s.participanteNaoEncontrado(id)
+	}
+	return bolao, nil
+}
+
+// participanteNaoEncontrado distingue, depois de uma alteração sem efeito,
+// o bolão inexistente do participante que não está no bolão.
+func (s *BolaoService) participanteNaoEncontrado(id string) error {
+	if _, err := s.buscarBolao(id); err != nil {
+		return err
+	}
+	return &model.ResourceNotFoundException{Message: "Participant not found"}
+}
+
+// AdicionarBilhete valida a aposta contra as regras da loteria e já confere
+// o bilhete nos concursos da faixa do bolão que estão no banco.
+func (s *BolaoService) AdicionarBilhete(id string, aposta model.Aposta) (*model.BilheteBolao, error) {
+	bolao, err := s.buscarBolao(id)
+	if err != nil {
+		return nil, err
+	}
+
+	regras, _ := model.GetRegras(bolao.Loteria)
+	preparada, err := prepararAposta(regras, aposta)
+	if err != nil {
+		return nil, err
+	}
+
+	bilhete := &model.BilheteBolao{
+		ID:           primitive.NewObjectID().Hex(),
+		BolaoID:      bolao.ID,
+		Aposta:       aposta,
+		Preco:        preparada.preco(),
+		Conferencias: []model.ConferenciaBilhete{},
+		CriadoEm:     time.Now(),
+	}
+
+	if err := s.conferirBilhete(bolao, bilhete); err != nil {
+		return nil, err
+	}
+	return bilhete, nil
+}
+
+func (s *BolaoService) RemoverBilhete(id, bilheteID string) error {
+	if _, err := s.buscarBolao(id); err != nil {
+		return err
+	}
+
+	removido, err := s.repository.DeleteBilhete(id, bilheteID)
+	if err != nil {
+		return err
+	}
+	if !removido {
+		return &model.ResourceNotFoundException{Message: "Ticket not found"}
+	}
+	return nil
+}
+
+// ConferirConcurso confere o resultado em todos os bilhetes dos bolões da
+// loteria que incluem o concurso. É chamado a cada resultado gravado. Uma
+// falha em um bolão ou bilhete não impede a conferência dos demais; as
+// falhas são retornadas juntas.
+func (s *BolaoService) ConferirConcurso(resultado *model.Resultado) error {
+	regras, ok := model.GetRegras(resultado.Loteria)
+	if !ok || regras.Tipo == model.TipoBilhete {
+		return nil
+	}
+
+	boloes, err := s.repository.FindAtivos(resultado.Loteria, resultado.Concurso)
+	if err != nil {
+		return err
+	}
+
+	var falhas []error
+	for _, bolao := range boloes {
+		bilhetes, err := s.repository.FindBilhetes(bolao.ID)
+		if err != nil {
+			falhas = append(falhas, fmt.Errorf("bolão %s: %w", bolao.ID, err))
+			continue
+		}
+		for i := range bilhetes {
+			if err := ConferirBilhete(&bilhetes[i], resultado); err != nil {
+				falhas = append(falhas, fmt.Errorf("bilhete %s: %w", bilhetes[i].ID, err))
+				continue
+			}
+			if err := s.repository.SaveBilhete(&bilhetes[i]); err != nil {
+				falhas = append(falhas, fmt.Errorf("bilhete %s: %w", bilhetes[i].ID, err))
+			}
+		}
+	}
+
+	return errors.Join(falhas...)
+}
+
+// ConferirBilhete confere o resultado na aposta do bilhete e registra a
+// conferência do concurso, substituindo uma anterior.
+func ConferirBilhete(bilhete *model.BilheteBolao, resultado *model.Resultado) error {
+	regras, ok := model.GetRegras(resultado.Loteria)
+	if !ok {
+		return &model.LoteriaInvalidException{Message: fmt.Sprintf("'%s' não é uma loteria suportada", resultado.Loteria)}
+	}
+	preparada, err := prepararAposta(regras, bilhete.Aposta)
+	if err != nil {
+		return err
+	}
+	conferencia, err := preparada.conferir(resultado)
+	if err != nil {
+		return err
+	}
+	registrarConferencia(bilhete, conferencia)
+	return nil
+}
+
+// Relatorio resume custo e prêmios do bolão e divide os valores entre os
+// participantes na proporção das cotas de cada um.
+func (s *BolaoService) Relatorio(id string) (*model.RelatorioBolao, error) {
+	bolao, err := s.buscarBolao(id)
+	if err != nil {
+		return nil, err
+	}
+
+	bilhetes, err := s.repository.FindBilhetes(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return MontarRelatorio(bolao, bilhetes), nil
+}
+
+// MontarRelatorio soma custo e prêmios dos bilhetes. Cada bilhete custa o
+// seu preço em cada concurso já apurado, ou seja, com conferência
+// registrada; os concursos da faixa ainda não sorteados não entram no custo.
+func MontarRelatorio(bolao *model.Bolao, bilhetes []model.BilheteBolao) *model.RelatorioBolao {
+	relatorio := &model.RelatorioBolao{
+		Bolao:         *bolao,
+		Bilhetes:      len(bilhetes),
+		Concursos:     []model.ResumoConcursoBolao{},
+		Participantes: []model.ParticipacaoBolao{},
+	}
+
+	resumos := make(map[int]*model.ResumoConcursoBolao)
+	for _, bilhete := range bilhetes {
+		relatorio.Custo += bilhete.Preco * float64(len(bilhete.Conferencias))
+		for _, c := range bilhete.Conferencias {
+			resumo, ok := resumos[c.Concurso]
+			if !ok {
+				resumo = &model.ResumoConcursoBolao{Concurso: c.Concurso, Data: c.Data}
+				resumos[c.Concurso] = resumo
+			}
+			if c.Valor > 0 || len(c.Premios) > 0 {
+				resumo.BilhetesPremiados++
+			}
+			resumo.Valor += c.Valor
+			relatorio.ValorTotal += c.Valor
+		}
+	}
+
+	for _, resumo := range resumos {
+		resumo.Valor = arredondarCentavos(resumo.Valor)
+		relatorio.Concursos = append(relatorio.Concursos, *resumo)
+	}
+	sort.Slice(relatorio.Concursos, func(i, j int) bool {
+		return relatorio.Concursos[i].Concurso < relatorio.Concursos[j].Concurso
+	})
+
+	for _, p := range bolao.Participantes {
+		relatorio.TotalCotas += p.Cotas
+	}
+	for _, p := range bolao.Participantes {
+		fracao := float64(p.Cotas) / float64(relatorio.TotalCotas)
+		participacao := model.ParticipacaoBolao{
+			ParticipanteID: p.ID,
+			Nome:           p.Nome,
+			Cotas:          p.Cotas,
+			Percentual:     percentual(p.Cotas, relatorio.TotalCotas),
+			Custo:          arredondarCentavos(relatorio.Custo * fracao),
+			Premio:         arredondarCentavos(relatorio.ValorTotal * fracao),
+		}
+		participacao.Saldo = arredondarCentavos(participacao.Premio - participacao.Custo)
+		relatorio.Participantes = append(relatorio.Participantes, participacao)
+	}
+
+	relatorio.Custo = arredondarCentavos(relatorio.Custo)
+	relatorio.ValorTotal = arredondarCentavos(relatorio.ValorTotal)
+	relatorio.Saldo = arredondarCentavos(relatorio.ValorTotal - relatorio.Custo)
+
+	return relatorio
+}
+
+func (s *BolaoService) buscarBolao(id string) (*model.Bolao, error) {
+	bolao, err := s.repository.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if bolao == nil {
+		return nil, &model.ResourceNotFoundException{Message: "Pool not found"}
+	}
+	return bolao, nil
+}
+
+// conferirBilhete refaz a conferência do bilhete em todos os concursos da
+// faixa do bolão já armazenados e grava o bilhete.
+func (s *BolaoService) conferirBilhete(bolao *model.Bolao, bilhete *model.BilheteBolao) error {
+	regras, _ := model.GetRegras(bolao.Loteria)
+	preparada, err := prepararAposta(regras, bilhete.Aposta)
+	if err != nil {
+		return err
+	}
+
+	bilhete.Conferencias = []model.ConferenciaBilhete{}
+	filtro := model.FiltroConcursos{ConcursoInicio: bolao.ConcursoInicio, ConcursoFim: bolao.ConcursoFim}
+	err = s.resultadoService.PercorrerResultados(bolao.Loteria, filtro, func(resultado *model.Resultado) error {
+		conferencia, err := preparada.conferir(resultado)
+		if err != nil {
+			return err
+		}
+		registrarConferencia(bilhete, conferencia)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.repository.SaveBilhete(bilhete)
+}
+
+// registrarConferencia grava a conferência de um concurso no bilhete,
+// substituindo uma conferência anterior do mesmo concurso.
+func registrarConferencia(bilhete *model.BilheteBolao, conferencia *model.Conferencia) {
+	registro := model.ConferenciaBilhete{
+		Concurso:    conferencia.Concurso,
+		Data:        conferencia.Data,
+		Acertos:     []int{},
+		Premios:     []model.PremioAposta{},
+		Valor:       arredondarCentavos(conferencia.ValorTotal),
+		ConferidoEm: time.Now(),
+	}
+	for _, sorteio := range conferencia.Sorteios {
+		registro.Acertos = append(registro.Acertos, sorteio.Acertos)
+		registro.Premios = append(registro.Premios, sorteio.Premios...)
+	}
+
+	for i, c := range bilhete.Conferencias {
+		if c.Concurso == registro.Concurso {
+			bilhete.Conferencias[i] = registro
+			return
+		}
+	}
+	bilhete.Conferencias = append(bilhete.Conferencias, registro)
+	sort.Slice(bilhete.Conferencias, func(i, j int) bool {
+		return bilhete.Conferencias[i].Concurso < bilhete.Conferencias[j].Concurso
+	})
+}
+
+func validarBolao(bolao *model.Bolao) error {
+	if bolao.Nome == "" {
+		return &model.ParametroInvalidoException{Message: "O nome do bolão é obrigatório"}
+	}
+	regras, ok := model.GetRegras(bolao.Loteria)
+	if !ok {
+		return &model.ParametroInvalidoException{Message: "Loteria inválida: " + bolao.Loteria}
+	}
+	if regras.Tipo == model.TipoBilhete {
+		return &model.ParametroInvalidoException{Message: "Bolões não são suportados para a loteria " + bolao.Loteria}
+	}
+	if bolao.ConcursoInicio < 1 || bolao.ConcursoFim < bolao.ConcursoInicio {
+		return &model.ParametroInvalidoException{Message: "Faixa de concursos inválida"}
+	}
+	return nil
+}
+
+func novoParticipante(dados model.Participante) (model.Participante, error) {
+	participante := model.Participante{
+		ID:    primitive.NewObjectID().Hex(),
+		Nome:  strings.TrimSpace(dados.Nome),
+		Cotas: dados.Cotas,
+	}
+	if participante.Cotas == 0 {
+		participante.Cotas = 1
+	}
+	return participante, validarParticipante(participante)
+}
+
+func validarParticipante(participante model.Participante) error {
+	if participante.Nome == "" {
+		return &model.ParametroInvalidoException{Message: "O nome do participante é obrigatório"}
+	}
+	if participante.Cotas < 1 {
+		return &model.ParametroInvalidoException{Message: "O participante deve ter ao menos uma cota"}
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package service_test
+
+import (
+	"testing"
+
+	"loterias-api-golang/internal/model"
+	"loterias-api-golang/internal/service"
+)
+
+func TestNovoBolao(t *testing.T) {
+	bolao, err := service.NovoBolao(model.Bolao{
+		Nome:           "  Bolão da firma ",
+		Loteria:        "megasena",
+		ConcursoInicio: 2900,
+		ConcursoFim:    2910,
+		Participantes: []model.Participante{
+			{Nome: " Ana ", Cotas: 2},
+			{Nome: "Bruno"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if bolao.ID == "" || bolao.Nome != "Bolão da firma" || bolao.CriadoEm.IsZero() {
+		t.Errorf("Bolao = %+v, want an ID, trimmed name and creation time", bolao)
+	}
+	if len(bolao.Participantes) != 2 {
+		t.Fatalf("Participantes = %+v, want 2", bolao.Participantes)
+	}
+	ana, bruno := bolao.Participantes[0], bolao.Participantes[1]
+	if ana.ID == "" || ana.ID == bruno.ID || ana.Nome != "Ana" || ana.Cotas != 2 || bruno.Cotas != 1 {
+		t.Errorf("Participantes = %+v, want distinct IDs, trimmed names and 1 cota by default", bolao.Participantes)
+	}
+
+	invalidos := []struct {
+		nome  string
+		dados model.Bolao
+	}{
+		{"sem nome", model.Bolao{Loteria: "megasena", ConcursoInicio: 1, ConcursoFim: 1}},
+		{"loteria inválida", model.Bolao{Nome: "x", Loteria: "loto", ConcursoInicio: 1, ConcursoFim: 1}},
+		{"federal", model.Bolao{Nome: "x", Loteria: "federal", ConcursoInicio: 1, ConcursoFim: 1}},
+		{"faixa invertida", model.Bolao{Nome: "x", Loteria: "quina", ConcursoInicio: 10, ConcursoFim: 9}},
+		{"participante sem nome", model.Bolao{Nome: "x", Loteria: "quina", ConcursoInicio: 1, ConcursoFim: 1,
+			Participantes: []model.Participante{{Nome: " "}}}},
+		{"cotas negativas", model.Bolao{Nome: "x", Loteria: "quina", ConcursoInicio: 1, ConcursoFim: 1,
+			Participantes: []model.Participante{{Nome: "Ana", Cotas: -1}}}},
+	}
+	for _, tt := range invalidos {
+		if _, err := service.NovoBolao(tt.dados); err == nil {
+			t.Errorf("%s: NovoBolao error = nil, want an error", tt.nome)
+		}
+	}
+}
+
+func TestConferirBilhete(t *testing.T) {
+	bilhete := &model.BilheteBolao{
+		Aposta:       model.Aposta{Dezenas: []string{"04", "13", "25", "36", "40", "59"}},
+		Conferencias: []model.ConferenciaBilhete{},
+	}
+	resultado := func(concurso int, dezenas ...string) *model.Resultado {
+		return &model.Resultado{
+			Loteria:    "megasena",
+			Concurso:   concurso,
+			Data:       "01/02/2025",
+			Dezenas:    dezenas,
+			Premiacoes: premiacoes(3),
+		}
+	}
+
+	if err := service.ConferirBilhete(bilhete, resultado(2901, "04", "13", "25", "36", "40", "53")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := service.ConferirBilhete(bilhete, resultado(2900, "01", "02", "03", "04", "05", "06")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(bilhete.Conferencias) != 2 || bilhete.Conferencias[0].Concurso != 2900 || bilhete.Conferencias[1].Concurso != 2901 {
+		t.Fatalf("Conferencias = %+v, want concursos 2900 and 2901 in order", bilhete.Conferencias)
+	}
+	quina := bilhete.Conferencias[1]
+	if quina.Acertos[0] != 5 || quina.Valor != 2000 || len(quina.Premios) != 1 || quina.Premios[0].Faixa != 2 {
+		t.Errorf("Conferencias[1] = %+v, want a quina paying 2000", quina)
+	}
+
+	// Uma nova conferência do mesmo concurso substitui a anterior.
+	if err := service.ConferirBilhete(bilhete, resultado(2901, "01", "02", "03", "04", "05", "06")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(bilhete.Conferencias) != 2 || bilhete.Conferencias[1].Valor != 0 || bilhete.Conferencias[1].Acertos[0] != 1 {
+		t.Errorf("Conferencias = %+v, want concurso 2901 checked again with 1 acerto", bilhete.Conferencias)
+	}
+
+	invalido := &model.BilheteBolao{Aposta: model.Aposta{Dezenas: []string{"04", "13"}}}
+	if err := service.ConferirBilhete(invalido, resultado(2901, "04", "13", "25", "36", "40", "53")); err == nil {
+		t.Error("ConferirBilhete with 2 dezenas: error = nil, want an error")
+	}
+}
+
+func TestMontarRelatorio(t *testing.T) {
+	bolao := &model.Bolao{
+		ID:             "b1",
+		Loteria:        "megasena",
+		ConcursoInicio: 2900,
+		ConcursoFim:    2910,
+		Participantes: []model.Participante{
+			{ID: "p1", Nome: "Ana", Cotas: 1},
+			{ID: "p2", Nome: "Bruno", Cotas: 1},
+			{ID: "p3", Nome: "Carla", Cotas: 2},
+		},
+	}
+	// Só os concursos 2900 e 2901 foram sorteados até agora.
+	bilhetes := []model.BilheteBolao{
+		{ID: "t1", Preco: 6, Conferencias: []model.ConferenciaBilhete{
+			{Concurso: 2900, Data: "01/02/2025"},
+			{Concurso: 2901, Data: "04/02/2025", Valor: 100, Premios: []model.PremioAposta{{Faixa: 3, Quantidade: 1}}},
+		}},
+		{ID: "t2", Preco: 6, Conferencias: []model.ConferenciaBilhete{
+			{Concurso: 2900, Data: "01/02/2025"},
+			{Concurso: 2901, Data: "04/02/2025", Valor: 0.01},
+		}},
+	}
+
+	relatorio := service.MontarRelatorio(bolao, bilhetes)
+	if relatorio.Bilhetes != 2 || relatorio.TotalCotas != 4 {
+		t.Errorf("Bilhetes = %d, TotalCotas = %d, want 2 and 4", relatorio.Bilhetes, relatorio.TotalCotas)
+	}
+	if relatorio.Custo != 24 || relatorio.ValorTotal != 100.01 || relatorio.Saldo != 76.01 {
+		t.Errorf("Custo = %v, ValorTotal = %v, Saldo = %v, want 24 (2 drawn concursos), 100.01 and 76.01",
+			relatorio.Custo, relatorio.ValorTotal, relatorio.Saldo)
+	}
+	if len(relatorio.Concursos) != 2 || relatorio.Concursos[0].BilhetesPremiados != 0 ||
+		relatorio.Concursos[1].BilhetesPremiados != 2 || relatorio.Concursos[1].Valor != 100.01 {
+		t.Errorf("Concursos = %+v, want 2901 with 2 bilhetes premiados and 100.01", relatorio.Concursos)
+	}
+
+	want := []model.ParticipacaoBolao{
+		{ParticipanteID: "p1", Nome: "Ana", Cotas: 1, Percentual: 25, Custo: 6, Premio: 25, Saldo: 19},
+		{ParticipanteID: "p2", Nome: "Bruno", Cotas: 1, Percentual: 25, Custo: 6, Premio: 25, Saldo: 19},
+		{ParticipanteID: "p3", Nome: "Carla", Cotas: 2, Percentual: 50, Custo: 12, Premio: 50.01, Saldo: 38.01},
+	}
+	if len(relatorio.Participantes) != len(want) {
+		t.Fatalf("Participantes = %+v, want %+v", relatorio.Participantes, want)
+	}
+	for i := range want {
+		if relatorio.Participantes[i] != want[i] {
+			t.Errorf("Participantes[%d] = %+v, want %+v", i, relatorio.Participantes[i], want[i])
+		}
+	}
+}
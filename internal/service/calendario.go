@@ -0,0 +1,212 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"loterias-api-golang/internal/model"
+)
+
+const (
+	diasPadraoCalendario = 14
+	diasMaximoCalendario = 90
+
+	// dominioCalendario compõe o UID dos eventos, que precisa ser único e
+	// estável para que os aplicativos atualizem em vez de duplicar.
+	dominioCalendario = "api-loterias.moleniuk.com"
+)
+
+// Calendario retorna os sorteios das loterias informadas nos próximos dias,
+// partindo do próximo concurso de cada uma e projetando os seguintes pelos
+// dias de sorteio do jogo.
+func (s *ResultadoService) Calendario(loterias []string, dias int) ([]model.SorteioPrevisto, error) {
+	if dias == 0 {
+		dias = diasPadraoCalendario
+	}
+	if dias < 1 || dias > diasMaximoCalendario {
+		return nil, &model.ParametroInvalidoException{
+			Message: fmt.Sprintf("O parâmetro 'dias' deve estar entre 1 e %d", diasMaximoCalendario),
+		}
+	}
+
+	ultimos, err := s.repository.FindUltimos()
+	if err != nil {
+		return nil, err
+	}
+
+	agora := time.Now().In(model.FusoBrasilia)
+	hoje := time.Date(agora.Year(), agora.Month(), agora.Day(), 0, 0, 0, 0, model.FusoBrasilia)
+	return PreverSorteios(ultimos, loterias, hoje, hoje.AddDate(0, 0, dias)), nil
+}
+
+// PreverSorteios lista os sorteios de cada loteria entre desde e ate, em
+// ordem de data. Loterias sem data do próximo concurso ficam de fora.
+func PreverSorteios(ultimos []model.Resultado, loterias []string, desde, ate time.Time) []model.SorteioPrevisto {
+	porLoteria := make(map[string]model.Resultado, len(ultimos))
+	for _, resultado := range ultimos {
+		porLoteria[resultado.ID.Loteria] = resultado
+	}
+
+	sorteios := []model.SorteioPrevisto{}
+	for _, loteria := range loterias {
+		resultado, ok := porLoteria[loteria]
+		if !ok || resultado.DataProximoSorteio == nil {
+			continue
+		}
+		regras, _ := model.GetRegras(loteria)
+
+		concurso := resultado.ProximoConcurso
+		if concurso == 0 {
+			concurso = resultado.ID.Concurso + 1
+		}
+		sorteio := model.SorteioPrevisto{
+			Loteria:       loteria,
+			Nome:          regras.Nome,
+			Concurso:      concurso,
+			Data:          *resultado.DataProximoSorteio,
+			ValorEstimado: resultado.ValorEstimadoProximoConcurso,
+		}
+
+		for !sorteio.Data.After(ate) {
+			if !sorteio.Data.Before(desde) {
+				sorteios = append(sorteios, sorteio)
+			}
+			proximaData, ok := proximoDiaSorteio(regras.DiasSorteio, sorteio.Data)
+			if !ok {
+				break
+			}
+			sorteio = model.SorteioPrevisto{
+				Loteria:  loteria,
+				Nome:     regras.Nome,
+				Concurso: sorteio.Concurso + 1,
+				Data:     proximaData,
+				Previsto: true,
+			}
+		}
+	}
+
+	sort.SliceStable(sorteios, func(i, j int) bool {
+		return sorteios[i].Data.Before(sorteios[j].Data)
+	})
+	return sorteios
+}
+
+// proximoDiaSorteio retorna o primeiro dia de sorteio depois da data.
+func proximoDiaSorteio(dias []model.DiaSemana, data time.Time) (time.Time, bool) {
+	for i := 1; i <= 7; i++ {
+		candidato := data.AddDate(0, 0, i)
+		for _, dia := range dias {
+			if time.Weekday(dia) == candidato.Weekday() {
+				return candidato, true
+			}
+		}
+	}
+	return data, false
+}
+
+// GerarICS monta um calendário iCalendar (RFC 5545) com um evento por
+// sorteio. Os horários dos eventos são gravados em UTC; o fuso de Brasília
+// vai sempre como VTIMEZONE, já que a RFC exige ao menos um componente e o
+// calendário assinado continua válido quando não há sorteios no período.
+func GerarICS(nome string, sorteios []model.SorteioPrevisto, agora time.Time) string {
+	var ics strings.Builder
+	linha := func(conteudo string) {
+		ics.WriteString(dobrarLinhaICS(conteudo))
+		ics.WriteString("\r\n")
+	}
+
+	linha("BEGIN:VCALENDAR")
+	linha("VERSION:2.0")
+	linha("PRODID:-//Loterias API Golang//Calendario de Sorteios//PT-BR")
+	linha("CALSCALE:GREGORIAN")
+	linha("METHOD:PUBLISH")
+	linha("X-WR-CALNAME:" + escaparTextoICS(nome))
+	linha("X-WR-TIMEZONE:America/Sao_Paulo")
+	linha("BEGIN:VTIMEZONE")
+	linha("TZID:America/Sao_Paulo")
+	linha("BEGIN:STANDARD")
+	linha("DTSTART:19700101T000000")
+	linha("TZOFFSETFROM:-0300")
+	linha("TZOFFSETTO:-0300")
+	linha("TZNAME:-03")
+	linha("END:STANDARD")
+	linha("END:VTIMEZONE")
+
+	carimbo := formatarDataICS(agora)
+	for _, sorteio := range sorteios {
+		inicio := sorteio.Data.Add(model.HorarioSorteio)
+		descricao := "Prêmio estimado: " + formatarReais(sorteio.ValorEstimado)
+		if sorteio.Previsto || sorteio.ValorEstimado == 0 {
+			descricao = "Prêmio estimado: a divulgar após o concurso anterior"
+		}
+		if sorteio.Previsto {
+			descricao += "\nData prevista pelos dias de sorteio da loteria, sujeita a alteração pela Caixa."
+		}
+
+		linha("BEGIN:VEVENT")
+		linha(fmt.Sprintf("UID:%s-%d@%s", sorteio.Loteria, sorteio.Concurso, dominioCalendario))
+		linha("DTSTAMP:" + carimbo)
+		linha("DTSTART:" + formatarDataICS(inicio))
+		linha("DTEND:" + formatarDataICS(inicio.Add(model.DuracaoSorteio)))
+		linha("SUMMARY:" + escaparTextoICS(fmt.Sprintf("%s - concurso %d", sorteio.Nome, sorteio.Concurso)))
+		linha("DESCRIPTION:" + escaparTextoICS(descricao))
+		linha("CATEGORIES:" + escaparTextoICS(sorteio.Nome))
+		linha("TRANSP:TRANSPARENT")
+		linha("END:VEVENT")
+	}
+
+	linha("END:VCALENDAR")
+	return ics.String()
+}
+
+func formatarDataICS(data time.Time) string {
+	return data.UTC().Format("20060102T150405Z")
+}
+
+var escapeTextoICS = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// escaparTextoICS protege os caracteres especiais de valores TEXT.
+func escaparTextoICS(texto string) string {
+	return escapeTextoICS.Replace(texto)
+}
+
+// dobrarLinhaICS quebra linhas com mais de 75 octetos, continuando com um
+// espaço, sem separar os bytes de um caractere UTF-8.
+func dobrarLinhaICS(linha string) string {
+	const limite = 75
+	if len(linha) <= limite {
+		return linha
+	}
+
+	var dobrada strings.Builder
+	tamanho := 0
+	for _, r := range linha {
+		octetos := len(string(r))
+		if tamanho+octetos > limite {
+			dobrada.WriteString("\r\n ")
+			tamanho = 1
+		}
+		dobrada.WriteRune(r)
+		tamanho += octetos
+	}
+	return dobrada.String()
+}
+
+// formatarReais formata o valor em reais com separadores brasileiros, como
+// "R$ 3.500.000,00".
+func formatarReais(valor float64) string {
+	centavos := int64(math.Round(valor * 100))
+	inteiro := fmt.Sprintf("%d", centavos/100)
+
+	var milhares []string
+	for len(inteiro) > 3 {
+		milhares = append([]string{inteiro[len(inteiro)-3:]}, milhares...)
+		inteiro = inteiro[:len(inteiro)-3]
+	}
+	milhares = append([]string{inteiro}, milhares...)
+
+	return fmt.Sprintf("R$ %s,%02d", strings.Join(milhares, "."), centavos%100)
+}
@@ -0,0 +1,80 @@
+package service_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"loterias-api-golang/internal/model"
+	"loterias-api-golang/internal/service"
+)
+
+func TestPreverSorteios(t *testing.T) {
+	ultimos := []model.Resultado{
+		{ID: model.ResultadoID{Loteria: "megasena", Concurso: 2930}, ProximoConcurso: 2931,
+			DataProximoConcurso: "28/10/2025", ValorEstimadoProximoConcurso: 3500000},
+		{ID: model.ResultadoID{Loteria: "federal", Concurso: 6010}},
+	}
+	for i := range ultimos {
+		ultimos[i].AfterFind()
+	}
+
+	desde := time.Date(2025, 10, 27, 0, 0, 0, 0, model.FusoBrasilia)
+	sorteios := service.PreverSorteios(ultimos, []string{"megasena", "federal"}, desde, desde.AddDate(0, 0, 7))
+
+	// Mega-Sena às terças, quintas e sábados: 28/10, 30/10 e 01/11
+	if len(sorteios) != 3 {
+		t.Fatalf("len = %d, want 3 draws (%+v)", len(sorteios), sorteios)
+	}
+	if sorteios[0].Concurso != 2931 || sorteios[0].Previsto || sorteios[0].ValorEstimado != 3500000 {
+		t.Errorf("sorteios[0] = %+v, want stored contest 2931 with estimate", sorteios[0])
+	}
+	if sorteios[2].Concurso != 2933 || !sorteios[2].Previsto || sorteios[2].Data.Weekday() != time.Saturday {
+		t.Errorf("sorteios[2] = %+v, want projected contest 2933 on Saturday", sorteios[2])
+	}
+}
+
+func TestGerarICS(t *testing.T) {
+	data := time.Date(2025, 10, 28, 0, 0, 0, 0, model.FusoBrasilia)
+	sorteios := []model.SorteioPrevisto{
+		{Loteria: "megasena", Nome: "Mega-Sena", Concurso: 2931, Data: data, ValorEstimado: 3500000},
+		{Loteria: "megasena", Nome: "Mega-Sena", Concurso: 2932, Data: data.AddDate(0, 0, 2), Previsto: true},
+	}
+
+	ics := service.GerarICS("Sorteios; Caixa", sorteios, data)
+
+	for _, esperado := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"X-WR-CALNAME:Sorteios\\; Caixa\r\n",
+		"UID:megasena-2931@",
+		"DTSTART:20251028T230000Z\r\n",
+		"DTEND:20251029T000000Z\r\n",
+		"DESCRIPTION:Prêmio estimado: R$ 3.500.000\\,00\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(ics, esperado) {
+			t.Errorf("ICS does not contain %q:\n%s", esperado, ics)
+		}
+	}
+	if strings.Count(ics, "BEGIN:VEVENT") != 2 {
+		t.Errorf("want 2 events:\n%s", ics)
+	}
+	for _, linha := range strings.Split(ics, "\r\n") {
+		if len(linha) > 75 {
+			t.Errorf("line longer than 75 octets: %q", linha)
+		}
+	}
+}
+
+func TestGerarICS_SemSorteios(t *testing.T) {
+	ics := service.GerarICS("Sorteios", nil, time.Date(2025, 10, 28, 0, 0, 0, 0, model.FusoBrasilia))
+
+	if strings.Contains(ics, "BEGIN:VEVENT") {
+		t.Errorf("want no events:\n%s", ics)
+	}
+	inicio := strings.Index(ics, "BEGIN:VTIMEZONE\r\nTZID:America/Sao_Paulo\r\n")
+	fim := strings.Index(ics, "END:VTIMEZONE\r\n")
+	if inicio < 0 || fim < inicio || !strings.HasSuffix(ics, "END:VTIMEZONE\r\nEND:VCALENDAR\r\n") {
+		t.Errorf("want a VTIMEZONE component so the calendar is not empty:\n%s", ics)
+	}
+}
@@ -0,0 +1,570 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"loterias-api-golang/internal/model"
+)
+
+type ConferenciaService struct {
+	resultadoService *ResultadoService
+}
+
+func NewConferenciaService(resultadoService *ResultadoService) *ConferenciaService {
+	return &ConferenciaService{
+		resultadoService: resultadoService,
+	}
+}
+
+// Conferir busca o concurso informado e confere a aposta contra ele.
+func (s *ConferenciaService) Conferir(loteria string, concurso int, aposta model.Aposta) (*model.Conferencia, error) {
+	regras, ok := model.GetRegras(loteria)
+	if !ok || regras.Tipo == model.TipoBilhete {
+		return nil, &model.ApostaInvalidaException{Message: "Conferência não suportada para a loteria " + loteria}
+	}
+
+	resultado, err := s.resultadoService.FindByLoteriaAndConcurso(loteria, concurso)
+	if err != nil {
+		return nil, err
+	}
+	if resultado == nil {
+		return nil, &model.ResourceNotFoundException{Message: "Result not found"}
+	}
+
+	return ConferirResultado(resultado, aposta)
+}
+
+// ConferirHistorico confere a mesma aposta em todos os concursos
+// selecionados, acumulando prêmios por faixa e o valor que teria sido
+// recebido. Os concursos são lidos um a um do banco.
+func (s *ConferenciaService) ConferirHistorico(loteria string, aposta model.Aposta, filtro model.FiltroConcursos) (*model.ConferenciaHistorico, error) {
+	acumulador, err := novoHistoricoAposta(loteria, aposta)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.resultadoService.PercorrerResultados(loteria, filtro, func(resultado *model.Resultado) error {
+		acumulador.adicionar(resultado)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return acumulador.resumir(), nil
+}
+
+// ConferirHistoricoResultados confere a mesma aposta nos resultados já
+// carregados, em ordem crescente de concurso.
+func ConferirHistoricoResultados(loteria string, aposta model.Aposta, resultados []model.Resultado) (*model.ConferenciaHistorico, error) {
+	acumulador, err := novoHistoricoAposta(loteria, aposta)
+	if err != nil {
+		return nil, err
+	}
+	for i := range resultados {
+		acumulador.adicionar(&resultados[i])
+	}
+	return acumulador.resumir(), nil
+}
+
+// historicoAposta acumula a conferência de uma aposta concurso a concurso.
+type historicoAposta struct {
+	regras    model.Regras
+	preparada *apostaPreparada
+	preco     float64
+	historico *model.ConferenciaHistorico
+	faixas    map[int]*model.ResumoFaixa
+	acertos   map[int]int
+}
+
+func novoHistoricoAposta(loteria string, aposta model.Aposta) (*historicoAposta, error) {
+	regras, ok := model.GetRegras(loteria)
+	if !ok || regras.Tipo == model.TipoBilhete {
+		return nil, &model.ApostaInvalidaException{Message: "Conferência não suportada para a loteria " + loteria}
+	}
+
+	preparada, err := prepararAposta(regras, aposta)
+	if err != nil {
+		return nil, err
+	}
+
+	return &historicoAposta{
+		regras:    regras,
+		preparada: preparada,
+		preco:     preparada.preco(),
+		historico: &model.ConferenciaHistorico{
+			Loteria:             loteria,
+			Faixas:              []model.ResumoFaixa{},
+			DistribuicaoAcertos: []model.ContagemAcertos{},
+			ConcursosPremiados:  []model.ConcursoPremiado{},
+		},
+		faixas:  make(map[int]*model.ResumoFaixa),
+		acertos: make(map[int]int),
+	}, nil
+}
+
+// adicionar confere a aposta no resultado. Resultados gravados fora do
+// formato da loteria são contados como ignorados e não interrompem o
+// histórico.
+func (h *historicoAposta) adicionar(resultado *model.Resultado) {
+	historico := h.historico
+	conferencia, err := h.preparada.conferir(resultado)
+	if err != nil {
+		historico.ConcursosIgnorados++
+		return
+	}
+
+	if historico.Concursos == 0 {
+		historico.ConcursoInicio = resultado.Concurso
+	}
+	historico.ConcursoFim = resultado.Concurso
+	historico.Concursos++
+	historico.Custo += h.preco
+
+	premiado := model.ConcursoPremiado{Concurso: resultado.Concurso, Data: resultado.Data}
+	for _, sorteio := range conferencia.Sorteios {
+		h.acertos[sorteio.Acertos]++
+		for _, p := range sorteio.Premios {
+			resumo, ok := h.faixas[p.Faixa]
+			if !ok {
+				resumo = &model.ResumoFaixa{Faixa: p.Faixa, Descricao: p.Descricao}
+				h.faixas[p.Faixa] = resumo
+			}
+			resumo.Quantidade += p.Quantidade
+			resumo.Valor += p.Valor
+			premiado.Premios = append(premiado.Premios, p)
+		}
+	}
+	if conferencia.Premiado {
+		premiado.Valor = conferencia.ValorTotal
+		historico.ValorTotal += conferencia.ValorTotal
+		historico.ConcursosPremiados = append(historico.ConcursosPremiados, premiado)
+	}
+}
+
+// resumir ordena as faixas e a distribuição de acertos e arredonda os
+// valores acumulados.
+func (h *historicoAposta) resumir() *model.ConferenciaHistorico {
+	historico := h.historico
+	for _, faixa := range h.regras.Faixas {
+		if resumo, ok := h.faixas[faixa.Faixa]; ok {
+			historico.Faixas = append(historico.Faixas, *resumo)
+		}
+	}
+	for n := 0; n <= h.regras.MaxNumeros; n++ {
+		if h.acertos[n] > 0 {
+			historico.DistribuicaoAcertos = append(historico.DistribuicaoAcertos, model.ContagemAcertos{Acertos: n, Sorteios: h.acertos[n]})
+		}
+	}
+	for i := range historico.Faixas {
+		historico.Faixas[i].Valor = arredondarCentavos(historico.Faixas[i].Valor)
+	}
+	historico.ValorTotal = arredondarCentavos(historico.ValorTotal)
+	historico.Custo = arredondarCentavos(historico.Custo)
+	historico.Saldo = arredondarCentavos(historico.ValorTotal - historico.Custo)
+
+	return historico
+}
+
+// ConferirBilhete confere um bilhete da Loteria Federal contra a extração
+// informada.
+func (s *ConferenciaService) ConferirBilhete(concurso int, bilhete, serie string) (*model.ConferenciaFederal, error) {
+	resultado, err := s.resultadoService.FindByLoteriaAndConcurso(string(model.Federal), concurso)
+	if err != nil {
+		return nil, err
+	}
+	if resultado == nil {
+		return nil, &model.ResourceNotFoundException{Message: "Result not found"}
+	}
+
+	return ConferirFederal(resultado, bilhete, serie)
+}
+
+// ConferirFederal retorna todos os prêmios de um bilhete e série em uma
+// extração da Federal já carregada.
+func ConferirFederal(resultado *model.Resultado, bilhete, serie string) (*model.ConferenciaFederal, error) {
+	numero, err := model.FormatarBilhete(bilhete)
+	if err != nil {
+		return nil, &model.ApostaInvalidaException{Message: err.Error()}
+	}
+	serie = strings.TrimSpace(serie)
+	if n, err := strconv.Atoi(serie); err != nil || n < 1 {
+		return nil, &model.ApostaInvalidaException{Message: fmt.Sprintf("'%s' não é uma série válida", serie)}
+	}
+
+	federal := resultado.Federal
+	if federal == nil {
+		copia := *resultado
+		copia.MontarFederal()
+		federal = copia.Federal
+	}
+	if federal == nil {
+		return nil, &model.ResourceNotFoundException{Message: "Result has no winning tickets"}
+	}
+
+	conferencia := &model.ConferenciaFederal{
+		Concurso: resultado.Concurso,
+		Data:     resultado.Data,
+		Bilhete:  numero,
+		Serie:    serie,
+		Premios:  federal.PremiosDoBilhete(numero, serie),
+	}
+	for _, p := range conferencia.Premios {
+		conferencia.ValorTotal += p.Valor
+	}
+	conferencia.Premiado = len(conferencia.Premios) > 0
+	conferencia.ValorTotal = arredondarCentavos(conferencia.ValorTotal)
+
+	return conferencia, nil
+}
+
+// ConferirResultado confere uma aposta contra um resultado já carregado,
+// seguindo as regras de premiação da loteria do resultado.
+func ConferirResultado(resultado *model.Resultado, aposta model.Aposta) (*model.Conferencia, error) {
+	regras, ok := model.GetRegras(resultado.Loteria)
+	if !ok || regras.Tipo == model.TipoBilhete {
+		return nil, &model.ApostaInvalidaException{Message: "Conferência não suportada para a loteria " + resultado.Loteria}
+	}
+
+	preparada, err := prepararAposta(regras, aposta)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resultado.Sorteios) == 0 {
+		copia := *resultado
+		copia.MontarSorteios()
+		resultado = &copia
+	}
+
+	return preparada.conferir(resultado)
+}
+
+// apostaPreparada guarda os números de uma aposta já validados contra as
+// regras, para conferir vários concursos sem refazer a validação.
+type apostaPreparada struct {
+	regras  model.Regras
+	aposta  model.Aposta
+	dezenas []int
+	trevos  []int
+	colunas [][]int
+}
+
+func prepararAposta(regras model.Regras, aposta model.Aposta) (*apostaPreparada, error) {
+	preparada := &apostaPreparada{regras: regras, aposta: aposta}
+
+	if regras.Tipo == model.TipoColunas {
+		if len(aposta.Colunas) != regras.Colunas.Quantidade {
+			return nil, &model.ApostaInvalidaException{
+				Message: fmt.Sprintf("A aposta deve ter %d colunas", regras.Colunas.Quantidade),
+			}
+		}
+
+		total := 0
+		for i, coluna := range aposta.Colunas {
+			numeros, err := parseNumeros(coluna, regras.MenorNumero, regras.MaiorNumero, "número")
+			if err != nil {
+				return nil, err
+			}
+			if len(numeros) < regras.Colunas.MinPorColuna || len(numeros) > regras.Colunas.MaxPorColuna {
+				return nil, &model.ApostaInvalidaException{
+					Message: fmt.Sprintf("A coluna %d deve ter entre %d e %d números", i+1, regras.Colunas.MinPorColuna, regras.Colunas.MaxPorColuna),
+				}
+			}
+			total += len(numeros)
+			preparada.colunas = append(preparada.colunas, numeros)
+		}
+
+		if total < regras.MinNumeros || total > regras.MaxNumeros {
+			return nil, &model.ApostaInvalidaException{
+				Message: fmt.Sprintf("A aposta deve ter entre %d e %d números", regras.MinNumeros, regras.MaxNumeros),
+			}
+		}
+		return preparada, nil
+	}
+
+	dezenas, err := parseNumeros(aposta.Dezenas, regras.MenorNumero, regras.MaiorNumero, "dezena")
+	if err != nil {
+		return nil, err
+	}
+	if len(dezenas) < regras.MinNumeros || len(dezenas) > regras.MaxNumeros {
+		return nil, &model.ApostaInvalidaException{
+			Message: fmt.Sprintf("A aposta deve ter entre %d e %d dezenas", regras.MinNumeros, regras.MaxNumeros),
+		}
+	}
+	preparada.dezenas = dezenas
+
+	if regras.Trevos != nil {
+		trevos, err := parseNumeros(aposta.Trevos, regras.Trevos.MenorNumero, regras.Trevos.MaiorNumero, "trevo")
+		if err != nil {
+			return nil, err
+		}
+		if len(trevos) < regras.Trevos.MinNumeros || len(trevos) > regras.Trevos.MaxNumeros {
+			return nil, &model.ApostaInvalidaException{
+				Message: fmt.Sprintf("A aposta deve ter entre %d e %d trevos", regras.Trevos.MinNumeros, regras.Trevos.MaxNumeros),
+			}
+		}
+		preparada.trevos = trevos
+	}
+
+	return preparada, nil
+}
+
+// preco retorna o valor pago pela aposta em um concurso.
+func (a *apostaPreparada) preco() float64 {
+	if a.regras.Tipo == model.TipoColunas {
+		porColuna := make([]int, len(a.colunas))
+		for i, c := range a.colunas {
+			porColuna[i] = len(c)
+		}
+		return a.regras.PrecoColunas(porColuna)
+	}
+	return float64(a.regras.ApostasSimples(len(a.dezenas), len(a.trevos))) * a.regras.PrecoApostaSimples
+}
+
+func (a *apostaPreparada) conferir(resultado *model.Resultado) (*model.Conferencia, error) {
+	conferencia := &model.Conferencia{
+		Loteria:  resultado.Loteria,
+		Concurso: resultado.Concurso,
+		Data:     resultado.Data,
+	}
+
+	if a.regras.Tipo == model.TipoColunas {
+		sorteio, err := a.conferirColunas(resultado)
+		if err != nil {
+			return nil, err
+		}
+		conferencia.Sorteios = []model.ConferenciaSorteio{*sorteio}
+	} else {
+		for sorteio := 1; sorteio <= a.regras.Sorteios; sorteio++ {
+			conferencia.Sorteios = append(conferencia.Sorteios, a.conferirSorteio(resultado, sorteio, resultado.DezenasDoSorteio(sorteio)))
+		}
+	}
+
+	for _, s := range conferencia.Sorteios {
+		for _, p := range s.Premios {
+			conferencia.ValorTotal += p.Valor
+			conferencia.Premiado = true
+		}
+	}
+
+	return conferencia, nil
+}
+
+func (a *apostaPreparada) conferirSorteio(resultado *model.Resultado, sorteio int, sorteadas []string) model.ConferenciaSorteio {
+	regras := a.regras
+	conferido := model.ConferenciaSorteio{
+		Sorteio:          sorteio,
+		Dezenas:          sorteadas,
+		DezenasAcertadas: []string{},
+		Premios:          []model.PremioAposta{},
+	}
+
+	acertadas := intersecao(a.dezenas, sorteadas)
+	conferido.Acertos = len(acertadas)
+	for _, d := range acertadas {
+		conferido.DezenasAcertadas = append(conferido.DezenasAcertadas, formatarDezena(d))
+	}
+
+	trevosAcertados := intersecao(a.trevos, resultado.Trevos)
+	for _, t := range trevosAcertados {
+		conferido.TrevosAcertados = append(conferido.TrevosAcertados, strconv.Itoa(t))
+	}
+
+	apostasSimples := regras.ApostasSimples(len(a.dezenas), len(a.trevos))
+	fixa := regras.MinNumeros == regras.MaxNumeros
+
+	for _, faixa := range regras.FaixasDoSorteio(sorteio) {
+		var quantidade int64
+		switch faixa.Especial {
+		case model.EspecialTimeCoracao:
+			if a.aposta.TimeCoracao != "" && strings.EqualFold(strings.TrimSpace(a.aposta.TimeCoracao), strings.TrimSpace(resultado.TimeCoracao)) {
+				conferido.AcertouTimeCoracao = true
+				quantidade = apostasSimples
+			}
+		case model.EspecialMesSorte:
+			if a.aposta.MesSorte != "" && strings.EqualFold(normalizarMes(a.aposta.MesSorte), normalizarMes(resultado.MesSorte)) {
+				conferido.AcertouMesSorte = true
+				quantidade = apostasSimples
+			}
+		default:
+			quantidade = apostasNaFaixa(len(a.dezenas), conferido.Acertos, regras.NumerosApostaSimples, faixa.Acertos, fixa)
+			if quantidade > 0 && len(faixa.Trevos) > 0 {
+				var comTrevos int64
+				for _, t := range faixa.Trevos {
+					comTrevos += apostasNaFaixa(len(a.trevos), len(trevosAcertados), regras.Trevos.NumerosApostaSimples, t, false)
+				}
+				quantidade *= comTrevos
+			}
+		}
+
+		if quantidade > 0 {
+			conferido.Premios = append(conferido.Premios, novoPremioAposta(resultado, faixa, quantidade))
+		}
+	}
+
+	return conferido
+}
+
+// conferirColunas confere apostas da Super Sete, em que cada coluna tem seu
+// próprio número sorteado e o acerto é contado por coluna.
+func (a *apostaPreparada) conferirColunas(resultado *model.Resultado) (*model.ConferenciaSorteio, error) {
+	if len(resultado.Dezenas) != len(a.colunas) {
+		return nil, fmt.Errorf("resultado do concurso %d não possui %d colunas", resultado.Concurso, len(a.colunas))
+	}
+
+	conferido := &model.ConferenciaSorteio{
+		Sorteio:          1,
+		Dezenas:          resultado.Dezenas,
+		DezenasAcertadas: []string{},
+		Premios:          []model.PremioAposta{},
+	}
+
+	// distribuicao[k] guarda quantas apostas simples acertam k colunas
+	distribuicao := []int64{1}
+	for i, numeros := range a.colunas {
+		sorteado, err := strconv.Atoi(strings.TrimSpace(resultado.Dezenas[i]))
+		if err != nil {
+			return nil, fmt.Errorf("coluna %d inválida no concurso %d: %w", i+1, resultado.Concurso, err)
+		}
+
+		var acerto int64
+		if contem(numeros, sorteado) {
+			acerto = 1
+			conferido.Acertos++
+			conferido.DezenasAcertadas = append(conferido.DezenasAcertadas, strconv.Itoa(sorteado))
+		}
+		erros := int64(len(numeros)) - acerto
+
+		proxima := make([]int64, len(distribuicao)+1)
+		for k, qtd := range distribuicao {
+			proxima[k] += qtd * erros
+			proxima[k+1] += qtd * acerto
+		}
+		distribuicao = proxima
+	}
+
+	for _, faixa := range a.regras.Faixas {
+		if faixa.Acertos < len(distribuicao) && distribuicao[faixa.Acertos] > 0 {
+			conferido.Premios = append(conferido.Premios, novoPremioAposta(resultado, faixa, distribuicao[faixa.Acertos]))
+		}
+	}
+
+	return conferido, nil
+}
+
+func novoPremioAposta(resultado *model.Resultado, faixa model.Faixa, quantidade int64) model.PremioAposta {
+	premio := model.PremioAposta{
+		Faixa:      faixa.Faixa,
+		Descricao:  faixa.Descricao,
+		Quantidade: quantidade,
+	}
+	for _, p := range resultado.PremiacoesDoSorteio(faixa.Sorteio) {
+		if p.Faixa == faixa.Faixa {
+			if p.Descricao != "" {
+				premio.Descricao = p.Descricao
+			}
+			premio.ValorUnitario = p.Valor
+			premio.Valor = p.Valor * float64(quantidade)
+			break
+		}
+	}
+	return premio
+}
+
+// apostasNaFaixa calcula quantas apostas simples de tamanho k, contidas em
+// uma aposta de n números com h acertos, acertam exatamente j números.
+// Loterias de aposta fixa contam apenas a própria aposta.
+func apostasNaFaixa(n, h, k, j int, fixa bool) int64 {
+	if fixa {
+		if h == j {
+			return 1
+		}
+		return 0
+	}
+	return model.Combinacoes(h, j) * model.Combinacoes(n-h, k-j)
+}
+
+// dezenasDoSorteio separa as dezenas de cada sorteio de uma lista plana, como
+// as projeções de ListarDezenas. Na Dupla Sena as seis primeiras dezenas são
+// do primeiro sorteio e as seis últimas do segundo.
+func dezenasDoSorteio(dezenas []string, regras model.Regras, sorteio int) []string {
+	if regras.Sorteios == 1 {
+		return dezenas
+	}
+	tamanho := len(dezenas) / regras.Sorteios
+	return dezenas[(sorteio-1)*tamanho : sorteio*tamanho]
+}
+
+func parseNumeros(valores []string, menor, maior int, nome string) ([]int, error) {
+	numeros := make([]int, 0, len(valores))
+	vistos := make(map[int]bool, len(valores))
+	for _, v := range valores {
+		n, err := strconv.Atoi(strings.TrimSpace(v))
+		if err != nil {
+			return nil, &model.ApostaInvalidaException{Message: fmt.Sprintf("'%s' não é um(a) %s válido(a)", v, nome)}
+		}
+		if n < menor || n > maior {
+			return nil, &model.ApostaInvalidaException{
+				Message: fmt.Sprintf("%s %d fora do intervalo %d a %d", nome, n, menor, maior),
+			}
+		}
+		if vistos[n] {
+			return nil, &model.ApostaInvalidaException{Message: fmt.Sprintf("%s %d repetido(a)", nome, n)}
+		}
+		vistos[n] = true
+		numeros = append(numeros, n)
+	}
+	sort.Ints(numeros)
+	return numeros, nil
+}
+
+func intersecao(numeros []int, sorteados []string) []int {
+	var acertados []int
+	for _, s := range sorteados {
+		n, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil {
+			continue
+		}
+		if contem(numeros, n) {
+			acertados = append(acertados, n)
+		}
+	}
+	sort.Ints(acertados)
+	return acertados
+}
+
+func contem(numeros []int, n int) bool {
+	for _, v := range numeros {
+		if v == n {
+			return true
+		}
+	}
+	return false
+}
+
+func arredondarCentavos(valor float64) float64 {
+	return math.Round(valor*100) / 100
+}
+
+func formatarDezena(n int) string {
+	return fmt.Sprintf("%02d", n)
+}
+
+// normalizarMes aceita o mês pelo número ("3") ou pelo nome ("Março").
+func normalizarMes(mes string) string {
+	mes = strings.TrimSpace(mes)
+	if n, err := strconv.Atoi(mes); err == nil && n >= 1 && n <= 12 {
+		return mesesSorte[n-1]
+	}
+	return mes
+}
+
+var mesesSorte = []string{
+	"Janeiro", "Fevereiro", "Março", "Abril", "Maio", "Junho",
+	"Julho", "Agosto", "Setembro", "Outubro", "Novembro", "Dezembro",
+}
@@ -0,0 +1,299 @@
+package service_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"loterias-api-golang/internal/model"
+	"loterias-api-golang/internal/service"
+)
+
+func premiacoes(faixas int) []model.Premiacao {
+	var p []model.Premiacao
+	for i := 1; i <= faixas; i++ {
+		p = append(p, model.Premiacao{Faixa: i, Valor: float64(1000 * i)})
+	}
+	return p
+}
+
+func TestConferirResultado_MegaSenaApostaMultipla(t *testing.T) {
+	resultado := &model.Resultado{
+		Loteria:    "megasena",
+		Concurso:   2932,
+		Dezenas:    []string{"04", "13", "25", "36", "40", "53"},
+		Premiacoes: premiacoes(3),
+	}
+	aposta := model.Aposta{Dezenas: []string{"4", "13", "25", "36", "40", "53", "60"}}
+
+	conferencia, err := service.ConferirResultado(resultado, aposta)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sorteio := conferencia.Sorteios[0]
+	if sorteio.Acertos != 6 {
+		t.Errorf("Acertos = %d, want 6", sorteio.Acertos)
+	}
+
+	// 7 dezenas com sena: 1 sena e 6 quinas
+	want := map[int]int64{1: 1, 2: 6}
+	if len(sorteio.Premios) != len(want) {
+		t.Fatalf("Premios = %+v, want faixas %v", sorteio.Premios, want)
+	}
+	for _, p := range sorteio.Premios {
+		if want[p.Faixa] != p.Quantidade {
+			t.Errorf("faixa %d: quantidade = %d, want %d", p.Faixa, p.Quantidade, want[p.Faixa])
+		}
+	}
+	if conferencia.ValorTotal != 1000+6*2000 {
+		t.Errorf("ValorTotal = %v, want %v", conferencia.ValorTotal, 1000+6*2000)
+	}
+}
+
+func TestConferirResultado_DuplaSenaSorteiosSeparados(t *testing.T) {
+	resultado := &model.Resultado{
+		Loteria: "duplasena",
+		Dezenas: []string{
+			"01", "02", "03", "04", "05", "06",
+			"10", "20", "30", "40", "45", "50",
+		},
+		Premiacoes: premiacoes(8),
+	}
+	aposta := model.Aposta{Dezenas: []string{"01", "02", "03", "04", "10", "20"}}
+
+	conferencia, err := service.ConferirResultado(resultado, aposta)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(conferencia.Sorteios) != 2 {
+		t.Fatalf("Sorteios = %d, want 2", len(conferencia.Sorteios))
+	}
+	if got := conferencia.Sorteios[0]; got.Acertos != 4 || len(got.Premios) != 1 || got.Premios[0].Faixa != 3 {
+		t.Errorf("primeiro sorteio = %+v, want quadra (faixa 3)", got)
+	}
+	if got := conferencia.Sorteios[1]; got.Acertos != 2 || len(got.Premios) != 0 {
+		t.Errorf("segundo sorteio = %+v, want sem prêmio", got)
+	}
+}
+
+func TestResultado_MontarSorteiosDuplaSena(t *testing.T) {
+	resultado := &model.Resultado{
+		ID: model.ResultadoID{Loteria: "duplasena", Concurso: 2700},
+		Dezenas: []string{
+			"01", "02", "03", "04", "05", "06",
+			"10", "20", "30", "40", "45", "50",
+		},
+		Premiacoes: premiacoes(8),
+	}
+	resultado.AfterFind()
+
+	if len(resultado.Sorteios) != 2 {
+		t.Fatalf("Sorteios = %d, want 2", len(resultado.Sorteios))
+	}
+	segundo := resultado.Sorteios[1]
+	if segundo.Sorteio != 2 || segundo.Dezenas[0] != "10" || len(segundo.Dezenas) != 6 {
+		t.Errorf("segundo sorteio = %+v, want dezenas 10..50", segundo)
+	}
+	if len(segundo.Premiacoes) != 4 || segundo.Premiacoes[0].Faixa != 5 {
+		t.Errorf("premiações do segundo sorteio = %+v, want faixas 5 a 8", segundo.Premiacoes)
+	}
+
+	conferencia, err := service.ConferirResultado(resultado, model.Aposta{Dezenas: []string{"10", "20", "30", "40", "45", "49"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := conferencia.Sorteios[1]; len(got.Premios) != 1 || got.Premios[0].Faixa != 6 || got.Premios[0].Valor != 6000 {
+		t.Errorf("segundo sorteio = %+v, want quina (faixa 6)", got)
+	}
+}
+
+func TestConferirResultado_LotomaniaZeroAcertos(t *testing.T) {
+	var sorteadas, aposta []string
+	for i := 0; i < 100; i++ {
+		dezena := fmt.Sprintf("%02d", i)
+		if i < 20 {
+			sorteadas = append(sorteadas, dezena)
+		} else if i >= 50 {
+			aposta = append(aposta, dezena)
+		}
+	}
+
+	resultado := &model.Resultado{Loteria: "lotomania", Dezenas: sorteadas, Premiacoes: premiacoes(7)}
+	conferencia, err := service.ConferirResultado(resultado, model.Aposta{Dezenas: aposta})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	premios := conferencia.Sorteios[0].Premios
+	if len(premios) != 1 || premios[0].Faixa != 7 {
+		t.Errorf("Premios = %+v, want faixa 7 (0 acertos)", premios)
+	}
+}
+
+func TestConferirResultado_SuperSeteColunas(t *testing.T) {
+	resultado := &model.Resultado{
+		Loteria:    "supersete",
+		Dezenas:    []string{"1", "2", "3", "4", "5", "6", "7"},
+		Premiacoes: premiacoes(5),
+	}
+	aposta := model.Aposta{Colunas: [][]string{{"1", "9"}, {"2"}, {"3"}, {"4"}, {"0"}, {"0"}, {"0"}}}
+
+	conferencia, err := service.ConferirResultado(resultado, aposta)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// A primeira coluna tem dois números: uma aposta com 4 acertos e uma com 3
+	want := map[int]int64{4: 1, 5: 1}
+	for _, p := range conferencia.Sorteios[0].Premios {
+		if want[p.Faixa] != p.Quantidade {
+			t.Errorf("faixa %d: quantidade = %d, want %d", p.Faixa, p.Quantidade, want[p.Faixa])
+		}
+	}
+	if len(conferencia.Sorteios[0].Premios) != len(want) {
+		t.Errorf("Premios = %+v, want faixas %v", conferencia.Sorteios[0].Premios, want)
+	}
+}
+
+func TestConferirResultado_ApostaInvalida(t *testing.T) {
+	resultado := &model.Resultado{Loteria: "megasena", Dezenas: []string{"01", "02", "03", "04", "05", "06"}}
+
+	tests := []struct {
+		name    string
+		dezenas []string
+	}{
+		{"Poucas dezenas", []string{"01", "02", "03"}},
+		{"Fora do intervalo", []string{"01", "02", "03", "04", "05", "61"}},
+		{"Repetida", []string{"01", "01", "03", "04", "05", "06"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.ConferirResultado(resultado, model.Aposta{Dezenas: tt.dezenas})
+			var invalida *model.ApostaInvalidaException
+			if !errors.As(err, &invalida) {
+				t.Errorf("err = %v, want ApostaInvalidaException", err)
+			}
+		})
+	}
+}
+
+func TestConferirFederal_PremiosPrincipaisEDerivados(t *testing.T) {
+	resultado := &model.Resultado{
+		ID:       model.ResultadoID{Loteria: "federal", Concurso: 5900},
+		Concurso: 5900,
+		Dezenas:  []string{"12345", "67890", "54321", "98765", "11111"},
+		Premiacoes: []model.Premiacao{
+			{Faixa: 1, Valor: 500000},
+			{Faixa: 2, Valor: 27000},
+			{Faixa: 3, Valor: 24000},
+			{Faixa: 4, Valor: 19000},
+			{Faixa: 5, Valor: 18329},
+		},
+		LocalGanhadores: []model.MunicipioUFGanhadores{
+			{Posicao: 1, Serie: "07", Municipio: "CURITIBA", UF: "PR", Estabelecimento: "LOTERICA CENTRAL"},
+		},
+	}
+	resultado.AfterFind()
+
+	tests := []struct {
+		name    string
+		bilhete string
+		tipos   []string
+		valor   float64
+	}{
+		{"1º prêmio", "12345", []string{model.PremioFederalPrincipal}, 500000},
+		{"2º prêmio", "67890", []string{model.PremioFederalPrincipal}, 27000},
+		{"Aproximação anterior", "12344", []string{model.PremioFederalAproximacao}, 0},
+		{"Aproximação posterior", "12346", []string{model.PremioFederalAproximacao}, 0},
+		{"Milhar", "92345", []string{model.PremioFederalMilhar}, 0},
+		{"Centena", "90345", []string{model.PremioFederalCentena}, 0},
+		{"Dezena do 1º prêmio", "90045", []string{model.PremioFederalDezena}, 0},
+		{"Dezena do 2º prêmio", "00090", []string{model.PremioFederalDezena}, 0},
+		{"Dezena do 3º prêmio", "00021", []string{model.PremioFederalDezena}, 0},
+		{"Dezena do 4º prêmio", "00065", nil, 0},
+		{"Sem prêmio", "90000", nil, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conferencia, err := service.ConferirFederal(resultado, tt.bilhete, "7")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(conferencia.Premios) != len(tt.tipos) {
+				t.Fatalf("Premios = %+v, want tipos %v", conferencia.Premios, tt.tipos)
+			}
+			for i, p := range conferencia.Premios {
+				if p.Tipo != tt.tipos[i] {
+					t.Errorf("Premios[%d].Tipo = %s, want %s", i, p.Tipo, tt.tipos[i])
+				}
+			}
+			if conferencia.ValorTotal != tt.valor {
+				t.Errorf("ValorTotal = %v, want %v", conferencia.ValorTotal, tt.valor)
+			}
+		})
+	}
+
+	conferencia, _ := service.ConferirFederal(resultado, "12345", "7")
+	if locais := conferencia.Premios[0].Locais; len(locais) != 1 || locais[0].Estabelecimento != "LOTERICA CENTRAL" {
+		t.Errorf("Locais = %+v, want a lotérica da série 07", locais)
+	}
+
+	if _, err := service.ConferirFederal(resultado, "123456", "7"); err == nil {
+		t.Error("expected error for ticket with 6 digits")
+	}
+}
+
+func TestConferirHistoricoResultados(t *testing.T) {
+	regras, _ := model.GetRegras("megasena")
+	resultados := []model.Resultado{
+		{Loteria: "megasena", Concurso: 1, Dezenas: []string{"01", "02", "03", "04", "50", "60"}, Premiacoes: premiacoes(3)},
+		{Loteria: "megasena", Concurso: 2, Dezenas: []string{"10", "20", "30", "40", "50", "60"}, Premiacoes: premiacoes(3)},
+		{Loteria: "megasena", Concurso: 3, Dezenas: []string{"01", "02", "03", "04", "05", "60"}, Premiacoes: premiacoes(3)},
+	}
+	aposta := model.Aposta{Dezenas: []string{"01", "02", "03", "04", "05", "06"}}
+
+	historico, err := service.ConferirHistoricoResultados("megasena", aposta, resultados)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if historico.Concursos != 3 || historico.ConcursoInicio != 1 || historico.ConcursoFim != 3 || historico.ConcursosIgnorados != 0 {
+		t.Errorf("Historico = %+v", historico)
+	}
+	if historico.Custo != 3*regras.PrecoApostaSimples || historico.ValorTotal != 5000 || historico.Saldo != 5000-3*regras.PrecoApostaSimples {
+		t.Errorf("Custo = %v, ValorTotal = %v, Saldo = %v", historico.Custo, historico.ValorTotal, historico.Saldo)
+	}
+	if got := fmt.Sprint(historico.Faixas); got != "[{2 5 acertos 1 2000} {3 4 acertos 1 3000}]" {
+		t.Errorf("Faixas = %s, want quina and quadra in prize order", got)
+	}
+	if got := fmt.Sprint(historico.DistribuicaoAcertos); got != "[{0 1} {4 1} {5 1}]" {
+		t.Errorf("DistribuicaoAcertos = %s", got)
+	}
+	if len(historico.ConcursosPremiados) != 2 || historico.ConcursosPremiados[0].Concurso != 1 || historico.ConcursosPremiados[1].Valor != 2000 {
+		t.Errorf("ConcursosPremiados = %+v, want contests 1 and 3", historico.ConcursosPremiados)
+	}
+}
+
+func TestConferirHistoricoResultados_IgnoraResultadosMalformados(t *testing.T) {
+	resultados := []model.Resultado{
+		{Loteria: "supersete", Concurso: 1, Dezenas: []string{"1", "2", "3", "4", "5", "6"}},
+		{Loteria: "supersete", Concurso: 2, Dezenas: []string{"1", "2", "3", "4", "5", "6", "7"}, Premiacoes: premiacoes(5)},
+		{Loteria: "supersete", Concurso: 3, Dezenas: []string{"1", "2", "3", "4", "5", "6", "x"}},
+	}
+	aposta := model.Aposta{Colunas: [][]string{{"1"}, {"2"}, {"3"}, {"4"}, {"5"}, {"6"}, {"7"}}}
+
+	historico, err := service.ConferirHistoricoResultados("supersete", aposta, resultados)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if historico.Concursos != 1 || historico.ConcursosIgnorados != 2 {
+		t.Errorf("Concursos = %d, ConcursosIgnorados = %d, want 1 and 2", historico.Concursos, historico.ConcursosIgnorados)
+	}
+	if historico.ConcursoInicio != 2 || historico.ConcursoFim != 2 || historico.ValorTotal != 1000 {
+		t.Errorf("Historico = %+v, want only contest 2 with the top prize", historico)
+	}
+}
@@ -90,6 +90,7 @@ type CaixaResponse struct {
 	ListaRateioPremio              []CaixaPremiacao         `json:"listaRateioPremio"`
 	ListaMunicipioUFGanhadores     []CaixaMunicipioGanhador `json:"listaMunicipioUFGanhadores"`
 	Observacao                     string                   `json:"observacao"`
+	IndicadorConcursoEspecial      int                      `json:"indicadorConcursoEspecial"`
 	Acumulado                      bool                     `json:"acumulado"`
 	DataProximoConcurso            string                   `json:"dataProximoConcurso"`
 	ValorArrecadado                float64                  `json:"valorArrecadado"`
@@ -379,18 +380,38 @@ func (c *Consumer) convertToResultado(loteria string, resp *CaixaResponse) *mode
 
 	for _, mg := range resp.ListaMunicipioUFGanhadores {
 		resultado.LocalGanhadores = append(resultado.LocalGanhadores, model.MunicipioUFGanhadores{
-			Ganhadores: mg.Ganhadores,
-			Municipio:  mg.Municipio,
-			Posicao:    mg.Posicao,
-			UF:         mg.UF,
-			Serie:      mg.Serie,
+			Ganhadores:      mg.Ganhadores,
+			Municipio:       mg.Municipio,
+			Posicao:         mg.Posicao,
+			UF:              mg.UF,
+			Serie:           mg.Serie,
+			Estabelecimento: mg.NomeFatansiaUL,
 		})
 	}
 
+	resultado.MarcarEspecial(resp.IndicadorConcursoEspecial == model.IndicadorConcursoEspecial)
+
 	resultado.AfterFind()
+	c.processOrdemSegundoSorteio(resultado, resp)
 	return resultado
 }
 
+// processOrdemSegundoSorteio completa a ordem de sorteio da Dupla Sena. A
+// Caixa envia em dezenasSorteadasOrdemSorteio apenas o primeiro sorteio; a
+// lista do segundo sorteio já vem na ordem em que as dezenas saíram.
+func (c *Consumer) processOrdemSegundoSorteio(resultado *model.Resultado, resp *CaixaResponse) {
+	if len(resultado.Sorteios) != 2 || len(resultado.Sorteios[1].DezenasOrdemSorteio) > 0 {
+		return
+	}
+
+	if len(resp.DezenasSorteadasOrdemSorteio) == len(resultado.Sorteios[0].Dezenas) {
+		resultado.Sorteios[0].DezenasOrdemSorteio = append([]string{}, resp.DezenasSorteadasOrdemSorteio...)
+	}
+	if len(resp.ListaDezenasSegundoSorteio) == len(resultado.Sorteios[1].Dezenas) {
+		resultado.Sorteios[1].DezenasOrdemSorteio = append([]string{}, resp.ListaDezenasSegundoSorteio...)
+	}
+}
+
 func (c *Consumer) processDezenas(loteria string, resp *CaixaResponse) []string {
 	dezenas := make([]string, len(resp.ListaDezenas))
 	copy(dezenas, resp.ListaDezenas)
@@ -417,15 +438,10 @@ func (c *Consumer) processDezenas(loteria string, resp *CaixaResponse) []string
 }
 
 func (c *Consumer) convertMonthNumber(monthStr string) string {
-	meses := []string{
-		"Janeiro", "Fevereiro", "Março", "Abril", "Maio", "Junho",
-		"Julho", "Agosto", "Setembro", "Outubro", "Novembro", "Dezembro",
-	}
-
 	monthNum, err := strconv.Atoi(monthStr)
 	if err != nil || monthNum < 1 || monthNum > 12 {
 		return monthStr
 	}
 
-	return meses[monthNum-1]
+	return mesesSorte[monthNum-1]
 }
@@ -0,0 +1,123 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"loterias-api-golang/internal/model"
+	"loterias-api-golang/internal/repository"
+)
+
+func TestParseDataCaixa(t *testing.T) {
+	tests := []struct {
+		name  string
+		valor string
+		want  string
+	}{
+		{"Formato da Caixa", "05/03/2024", "2024-03-05T00:00:00-03:00"},
+		{"Com espaços", " 31/12/2023 ", "2023-12-31T00:00:00-03:00"},
+		{"Horário de verão", "15/01/2018", "2018-01-15T00:00:00-02:00"},
+		{"Formato ISO", "2024-03-05", ""},
+		{"Dia inexistente", "30/02/2024", ""},
+		{"Vazia", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := model.ParseDataCaixa(tt.valor)
+			if tt.want == "" {
+				if data != nil {
+					t.Errorf("ParseDataCaixa(%q) = %v, want nil", tt.valor, data)
+				}
+				return
+			}
+			if data == nil || data.Format(time.RFC3339) != tt.want {
+				t.Errorf("ParseDataCaixa(%q) = %v, want %s", tt.valor, data, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDataConsulta(t *testing.T) {
+	tests := []struct {
+		name  string
+		valor string
+		ok    bool
+	}{
+		{"dd/mm/aaaa", "05/03/2024", true},
+		{"aaaa-mm-dd", "2024-03-05", true},
+		{"dd-mm-aaaa", "05-03-2024", true},
+		{"Com espaços", " 2024-03-05 ", true},
+		{"aaaa/mm/dd", "2024/03/05", false},
+		{"Mês inválido", "2024-13-05", false},
+		{"Texto", "ontem", false},
+		{"Vazia", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, ok := model.ParseDataConsulta(tt.valor)
+			if ok != tt.ok {
+				t.Fatalf("ParseDataConsulta(%q) ok = %v, want %v", tt.valor, ok, tt.ok)
+			}
+			if !ok {
+				if data != nil {
+					t.Errorf("ParseDataConsulta(%q) = %v, want nil", tt.valor, data)
+				}
+				return
+			}
+			if got := data.Format(time.RFC3339); got != "2024-03-05T00:00:00-03:00" {
+				t.Errorf("ParseDataConsulta(%q) = %s, want midnight in Brasília", tt.valor, got)
+			}
+			if got := data.UTC().Format(time.RFC3339); got != "2024-03-05T03:00:00Z" {
+				t.Errorf("ParseDataConsulta(%q) in UTC = %s, want 03:00", tt.valor, got)
+			}
+		})
+	}
+}
+
+func TestFiltroPeriodo(t *testing.T) {
+	inicio, _ := model.ParseDataConsulta("2024-03-01")
+	fim, _ := model.ParseDataConsulta("2024-03-31")
+
+	if filtro := repository.FiltroPeriodo(nil, nil); filtro != nil {
+		t.Errorf("FiltroPeriodo(nil, nil) = %v, want nil", filtro)
+	}
+	if filtro := repository.FiltroPeriodo(inicio, nil); len(filtro) != 1 || filtro["$gte"] != *inicio {
+		t.Errorf("FiltroPeriodo(inicio, nil) = %v, want only $gte", filtro)
+	}
+	if filtro := repository.FiltroPeriodo(nil, fim); len(filtro) != 1 || filtro["$lte"] != *fim {
+		t.Errorf("FiltroPeriodo(nil, fim) = %v, want only $lte", filtro)
+	}
+	if filtro := repository.FiltroPeriodo(inicio, fim); filtro["$gte"] != *inicio || filtro["$lte"] != *fim {
+		t.Errorf("FiltroPeriodo(inicio, fim) = %v, want both limits inclusive", filtro)
+	}
+}
+
+func TestFiltroDia(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		inicio  string
+		proximo string
+	}{
+		{"Dia comum", "2024-03-05", "2024-03-05T03:00:00Z", "2024-03-06T03:00:00Z"},
+		{"Virada do ano", "2023-12-31", "2023-12-31T03:00:00Z", "2024-01-01T03:00:00Z"},
+		{"Início do horário de verão", "2018-11-03", "2018-11-03T03:00:00Z", "2018-11-04T02:00:00Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, _ := model.ParseDataConsulta(tt.data)
+			filtro := repository.FiltroDia(*data)
+			inicio, _ := filtro["$gte"].(time.Time)
+			proximo, _ := filtro["$lt"].(time.Time)
+			if got := inicio.UTC().Format(time.RFC3339); got != tt.inicio {
+				t.Errorf("$gte = %s, want %s", got, tt.inicio)
+			}
+			if got := proximo.UTC().Format(time.RFC3339); got != tt.proximo {
+				t.Errorf("$lt = %s, want %s", got, tt.proximo)
+			}
+		})
+	}
+}
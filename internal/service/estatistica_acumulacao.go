@@ -0,0 +1,124 @@
+package service
+
+import (
+	"math"
+	"sort"
+
+	"loterias-api-golang/internal/model"
+)
+
+// Acumulacoes analisa as sequências de concursos sem ganhador na faixa
+// principal: a atual, a maior, todas as já registradas e a distribuição dos
+// seus tamanhos.
+func (s *EstatisticaService) Acumulacoes(loteria string, filtro model.FiltroConcursos) (*model.Acumulacoes, error) {
+	regras, err := regrasNumericas(loteria)
+	if err != nil {
+		return nil, err
+	}
+
+	concursos, err := s.repository.ListarAcumulacoes(loteria, filtro)
+	if err != nil {
+		return nil, err
+	}
+
+	acumulacoes := CalcularAcumulacoes(concursos)
+	acumulacoes.Loteria = string(regras.Loteria)
+	return acumulacoes, nil
+}
+
+// CalcularAcumulacoes percorre os concursos em ordem crescente e separa as
+// sequências em que a faixa principal acumulou. O crescimento é medido a
+// partir do acumulado informado pelo concurso anterior à sequência. Uma
+// sequência termina no concurso seguinte que teve ganhador; a que chega ao
+// último concurso fica em andamento e não entra na média nem na
+// distribuição. Concursos especiais não acumulam e têm prêmio próprio, então
+// não abrem nem encerram sequências: são apenas registrados na sequência em
+// curso.
+func CalcularAcumulacoes(concursos []model.AcumulacaoConcurso) *model.Acumulacoes {
+	acumulacoes := &model.Acumulacoes{
+		Concursos:    len(concursos),
+		Sequencias:   []model.SequenciaAcumulacao{},
+		Distribuicao: []model.DistribuicaoSequencia{},
+	}
+
+	var atual *model.SequenciaAcumulacao
+	anterior := 0.0
+	encerrar := func() {
+		atual.ValorInicial = arredondarCentavos(atual.ValorInicial)
+		atual.ValorFinal = arredondarCentavos(atual.ValorFinal)
+		atual.Crescimento = arredondarCentavos(atual.ValorFinal - atual.ValorInicial)
+		acumulacoes.Sequencias = append(acumulacoes.Sequencias, *atual)
+		atual = nil
+	}
+
+	for _, c := range concursos {
+		if c.Especial {
+			if atual != nil {
+				atual.ConcursosEspeciais = append(atual.ConcursosEspeciais, c.Concurso)
+			}
+			continue
+		}
+
+		if !c.Acumulou {
+			if atual != nil {
+				atual.ConcursoPremiado = c.Concurso
+				atual.Ganhadores = c.GanhadoresPrincipal
+				atual.PremioPago = arredondarCentavos(c.PremioPrincipal)
+				encerrar()
+			}
+			anterior = c.ValorAcumuladoProximoConcurso
+			continue
+		}
+
+		acumulacoes.ConcursosAcumulados++
+		if atual == nil {
+			atual = &model.SequenciaAcumulacao{
+				ConcursoInicio: c.Concurso,
+				DataInicio:     c.Data,
+				ValorInicial:   anterior,
+			}
+		}
+		atual.ConcursoFim = c.Concurso
+		atual.DataFim = c.Data
+		atual.Concursos++
+		atual.ValorFinal = c.ValorAcumuladoProximoConcurso
+		anterior = c.ValorAcumuladoProximoConcurso
+	}
+	if atual != nil {
+		atual.EmAndamento = true
+		encerrar()
+		emAndamento := acumulacoes.Sequencias[len(acumulacoes.Sequencias)-1]
+		acumulacoes.Atual = &emAndamento
+	}
+
+	quantidades := make(map[int]int)
+	encerradas, soma := 0, 0
+	for i, sequencia := range acumulacoes.Sequencias {
+		if acumulacoes.Maior == nil || sequencia.Concursos > acumulacoes.Maior.Concursos ||
+			(sequencia.Concursos == acumulacoes.Maior.Concursos && sequencia.ValorFinal > acumulacoes.Maior.ValorFinal) {
+			acumulacoes.Maior = &acumulacoes.Sequencias[i]
+		}
+		if sequencia.EmAndamento {
+			continue
+		}
+		encerradas++
+		soma += sequencia.Concursos
+		quantidades[sequencia.Concursos]++
+	}
+
+	if encerradas > 0 {
+		acumulacoes.MediaConcursos = math.Round(float64(soma)/float64(encerradas)*100) / 100
+	}
+	for tamanho, quantidade := range quantidades {
+		acumulacoes.Distribuicao = append(acumulacoes.Distribuicao, model.DistribuicaoSequencia{
+			Concursos:  tamanho,
+			Quantidade: quantidade,
+			Percentual: percentual(quantidade, encerradas),
+		})
+	}
+	sort.Slice(acumulacoes.Distribuicao, func(i, j int) bool {
+		return acumulacoes.Distribuicao[i].Concursos < acumulacoes.Distribuicao[j].Concursos
+	})
+
+	return acumulacoes
+}
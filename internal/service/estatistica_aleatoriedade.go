@@ -0,0 +1,439 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"loterias-api-golang/internal/model"
+)
+
+// Mínimos usados nas aproximações dos testes: contagem esperada por
+// categoria no qui-quadrado, valores de cada lado da mediana no teste de
+// sequências e sorteios na correlação serial.
+const (
+	esperadoMinimoQuiQuadrado  = 5
+	ladoMinimoSequencias       = 10
+	sorteiosMinimosCorrelacao  = 30
+	categoriasMaximasIntervalo = 100
+)
+
+// Aleatoriedade aplica os testes de aleatoriedade às dezenas dos concursos
+// selecionados, em ordem de concurso. Na Dupla Sena cada sorteio entra como
+// um sorteio independente.
+func (s *EstatisticaService) Aleatoriedade(loteria string, filtro model.FiltroConcursos, significancia float64) (*model.Aleatoriedade, error) {
+	regras, err := regrasNumericas(loteria)
+	if err != nil {
+		return nil, err
+	}
+	if significancia <= 0 || significancia > 0.5 {
+		return nil, &model.ParametroInvalidoException{Message: "A significância deve ser maior que 0 e no máximo 0.5"}
+	}
+
+	var sorteios [][]string
+	concursos, inicio, fim := 0, 0, 0
+	err = s.repository.PercorrerResultados(loteria, filtro, func(resultado *model.Resultado) error {
+		if concursos == 0 {
+			inicio = resultado.Concurso
+		}
+		fim = resultado.Concurso
+		concursos++
+		for sorteio := 1; sorteio <= regras.Sorteios; sorteio++ {
+			sorteios = append(sorteios, resultado.DezenasDoSorteio(sorteio))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	aleatoriedade := TestarAleatoriedade(regras, sorteios, significancia)
+	aleatoriedade.Loteria = loteria
+	aleatoriedade.Concursos = concursos
+	aleatoriedade.ConcursoInicio = inicio
+	aleatoriedade.ConcursoFim = fim
+	return aleatoriedade, nil
+}
+
+// espacoSorteio descreve cada sorteio como grupos independentes em que são
+// escolhidos, sem reposição, sorteados valores entre universo possíveis. Nas
+// loterias de dezenas há um único grupo; na Super Sete, um por coluna.
+type espacoSorteio struct {
+	grupos    int
+	universo  int
+	sorteados int
+}
+
+func (e espacoSorteio) probabilidade() float64 {
+	return float64(e.sorteados) / float64(e.universo)
+}
+
+// TestarAleatoriedade aplica aos sorteios, em ordem cronológica, os testes de
+// qui-quadrado das frequências, de sequências acima e abaixo da mediana da
+// soma, de intervalos entre aparições e de correlação serial da soma.
+// Sorteios incompletos ou com números fora do volante são ignorados.
+func TestarAleatoriedade(regras model.Regras, dezenas [][]string, significancia float64) *model.Aleatoriedade {
+	espaco := espacoSorteio{grupos: 1, universo: regras.MaiorNumero - regras.MenorNumero + 1, sorteados: regras.NumerosSorteados}
+	if regras.Tipo == model.TipoColunas {
+		espaco = espacoSorteio{grupos: regras.Colunas.Quantidade, universo: regras.MaiorNumero - regras.MenorNumero + 1, sorteados: 1}
+	}
+
+	var sorteios [][]int
+	var somas []float64
+	for _, d := range dezenas {
+		if simbolos, soma, ok := simbolosDoSorteio(regras, espaco, d); ok {
+			sorteios = append(sorteios, simbolos)
+			somas = append(somas, float64(soma))
+		}
+	}
+
+	testes := []model.TesteAleatoriedade{
+		testeQuiQuadrado(espaco, sorteios),
+		testeSequencias(somas),
+		testeIntervalos(espaco, sorteios),
+		testeCorrelacaoSerial(somas),
+	}
+
+	aleatoriedade := &model.Aleatoriedade{
+		Sorteios:      len(sorteios),
+		Significancia: significancia,
+	}
+	var aplicados int
+	var abaixo []string
+	for i := range testes {
+		teste := &testes[i]
+		if teste.Veredito == model.VereditoInsuficiente {
+			continue
+		}
+		aplicados++
+		teste.Veredito = model.VereditoConsistente
+		if teste.PValor < significancia {
+			teste.Veredito = model.VereditoDesvio
+			abaixo = append(abaixo, teste.Nome)
+		}
+	}
+	aleatoriedade.Testes = testes
+
+	var desvios []string
+	for _, teste := range testes {
+		if teste.Veredito == model.VereditoDesvio && teste.PValor < significancia/float64(aplicados) {
+			desvios = append(desvios, teste.Nome)
+		}
+	}
+
+	switch {
+	case aplicados == 0:
+		aleatoriedade.Veredito = model.VereditoInsuficiente
+		aleatoriedade.Conclusao = "Não há sorteios suficientes para aplicar os testes de aleatoriedade."
+	case len(desvios) > 0:
+		aleatoriedade.Veredito = model.VereditoDesvio
+		aleatoriedade.Conclusao = fmt.Sprintf("Há indício de desvio da aleatoriedade em: %s, mesmo considerando que %d testes foram aplicados.",
+			strings.Join(desvios, ", "), aplicados)
+	case len(abaixo) > 0:
+		aleatoriedade.Veredito = model.VereditoConsistente
+		aleatoriedade.Conclusao = fmt.Sprintf("Os sorteios são compatíveis com números uniformes e independentes. O p-valor de %s ficou abaixo da significância, mas isso é esperado ao acaso ao aplicar %d testes.",
+			strings.Join(abaixo, ", "), aplicados)
+	default:
+		aleatoriedade.Veredito = model.VereditoConsistente
+		aleatoriedade.Conclusao = fmt.Sprintf("Nenhum dos %d testes encontrou desvio significativo: os sorteios são compatíveis com números uniformes e independentes.", aplicados)
+	}
+	return aleatoriedade
+}
+
+// simbolosDoSorteio converte as dezenas em índices de 0 a grupos*universo-1,
+// um por valor sorteado em cada grupo, e retorna também a soma dos números.
+func simbolosDoSorteio(regras model.Regras, espaco espacoSorteio, dezenas []string) ([]int, int, bool) {
+	var numeros []int
+	if espaco.grupos > 1 {
+		for _, d := range dezenas {
+			n, err := strconv.Atoi(strings.TrimSpace(d))
+			if err != nil {
+				return nil, 0, false
+			}
+			numeros = append(numeros, n)
+		}
+	} else {
+		numeros = parseDezenas(dezenas)
+	}
+	if len(numeros) != espaco.grupos*espaco.sorteados {
+		return nil, 0, false
+	}
+
+	simbolos := make([]int, 0, len(numeros))
+	soma := 0
+	for i, n := range numeros {
+		if n < regras.MenorNumero || n > regras.MaiorNumero {
+			return nil, 0, false
+		}
+		grupo := i / espaco.sorteados
+		simbolos = append(simbolos, grupo*espaco.universo+n-regras.MenorNumero)
+		soma += n
+	}
+	return simbolos, soma, true
+}
+
+// testeQuiQuadrado compara a frequência de cada número com a esperada. Como
+// os números de um sorteio saem sem reposição, a estatística de Pearson é
+// multiplicada por (u-1)/(u-k) para seguir a distribuição qui-quadrado.
+func testeQuiQuadrado(espaco espacoSorteio, sorteios [][]int) model.TesteAleatoriedade {
+	teste := model.TesteAleatoriedade{
+		Teste:          model.TesteQuiQuadrado,
+		Nome:           "Qui-quadrado das frequências",
+		Descricao:      "Compara quantas vezes cada número saiu com a frequência esperada se todos tivessem a mesma chance.",
+		GrausLiberdade: espaco.grupos * (espaco.universo - 1),
+	}
+	teste.Esperado = float64(teste.GrausLiberdade)
+
+	esperado := float64(len(sorteios)) * espaco.probabilidade()
+	if esperado < esperadoMinimoQuiQuadrado {
+		teste.Veredito = model.VereditoInsuficiente
+		return teste
+	}
+
+	contagem := make([]int, espaco.grupos*espaco.universo)
+	for _, simbolos := range sorteios {
+		for _, s := range simbolos {
+			contagem[s]++
+		}
+	}
+
+	var estatistica float64
+	for _, observado := range contagem {
+		diferenca := float64(observado) - esperado
+		estatistica += diferenca * diferenca / esperado
+	}
+	estatistica *= float64(espaco.universo-1) / float64(espaco.universo-espaco.sorteados)
+
+	teste.Estatistica = arredondarCasas(estatistica, 4)
+	teste.PValor = arredondarCasas(pValorQuiQuadrado(estatistica, teste.GrausLiberdade), 6)
+	return teste
+}
+
+// testeSequencias é o teste de Wald-Wolfowitz sobre a soma de cada sorteio:
+// conta as sequências de somas seguidas acima ou abaixo da mediana, que
+// ficam longas demais quando um sorteio influencia o seguinte e curtas
+// demais quando os sorteios se alternam. Somas iguais à mediana são
+// descartadas.
+func testeSequencias(somas []float64) model.TesteAleatoriedade {
+	teste := model.TesteAleatoriedade{
+		Teste:     model.TesteSequencias,
+		Nome:      "Sequências acima e abaixo da mediana",
+		Descricao: "Conta as sequências de sorteios seguidos com soma acima ou abaixo da mediana, comparando com a quantidade esperada para sorteios independentes.",
+	}
+
+	ordenadas := append([]float64{}, somas...)
+	sort.Float64s(ordenadas)
+	var mediana float64
+	if n := len(ordenadas); n > 0 {
+		mediana = (ordenadas[(n-1)/2] + ordenadas[n/2]) / 2
+	}
+
+	var acima, abaixo, sequencias int
+	anterior := 0
+	for _, soma := range somas {
+		lado := 0
+		switch {
+		case soma > mediana:
+			lado, acima = 1, acima+1
+		case soma < mediana:
+			lado, abaixo = -1, abaixo+1
+		default:
+			continue
+		}
+		if lado != anterior {
+			sequencias++
+			anterior = lado
+		}
+	}
+
+	if acima < ladoMinimoSequencias || abaixo < ladoMinimoSequencias {
+		teste.Veredito = model.VereditoInsuficiente
+		return teste
+	}
+
+	n1, n2 := float64(acima), float64(abaixo)
+	n := n1 + n2
+	media := 2*n1*n2/n + 1
+	variancia := 2 * n1 * n2 * (2*n1*n2 - n) / (n * n * (n - 1))
+	z := (float64(sequencias) - media) / math.Sqrt(variancia)
+
+	teste.Estatistica = float64(sequencias)
+	teste.Esperado = arredondarCasas(media, 4)
+	teste.PValor = arredondarCasas(pValorNormal(z), 6)
+	return teste
+}
+
+// testeIntervalos compara os intervalos entre duas aparições seguidas de cada
+// número com a distribuição geométrica esperada: com chance p por sorteio,
+// um intervalo de g sorteios sem o número ocorre com probabilidade
+// p(1-p)^g. Os intervalos são agrupados em 0, 1, ..., m-1 e m ou mais, com m
+// o maior possível mantendo ao menos cinco intervalos esperados em cada
+// categoria.
+func testeIntervalos(espaco espacoSorteio, sorteios [][]int) model.TesteAleatoriedade {
+	teste := model.TesteAleatoriedade{
+		Teste:     model.TesteIntervalos,
+		Nome:      "Intervalos entre aparições",
+		Descricao: "Compara a quantidade de sorteios entre duas aparições seguidas de cada número com a distribuição esperada para sorteios independentes.",
+	}
+
+	ultimo := make([]int, espaco.grupos*espaco.universo)
+	for i := range ultimo {
+		ultimo[i] = -1
+	}
+	var intervalos []int
+	for i, simbolos := range sorteios {
+		for _, s := range simbolos {
+			if ultimo[s] >= 0 {
+				intervalos = append(intervalos, i-ultimo[s]-1)
+			}
+			ultimo[s] = i
+		}
+	}
+
+	p := espaco.probabilidade()
+	total := float64(len(intervalos))
+	categorias := 0
+	for categorias < categoriasMaximasIntervalo &&
+		total*p*math.Pow(1-p, float64(categorias)) >= esperadoMinimoQuiQuadrado &&
+		total*math.Pow(1-p, float64(categorias+1)) >= esperadoMinimoQuiQuadrado {
+		categorias++
+	}
+	if categorias == 0 {
+		teste.Veredito = model.VereditoInsuficiente
+		return teste
+	}
+
+	contagem := make([]int, categorias+1)
+	for _, g := range intervalos {
+		if g > categorias {
+			g = categorias
+		}
+		contagem[g]++
+	}
+
+	var estatistica float64
+	for g, observado := range contagem {
+		probabilidade := math.Pow(1-p, float64(g))
+		if g < categorias {
+			probabilidade *= p
+		}
+		esperado := total * probabilidade
+		diferenca := float64(observado) - esperado
+		estatistica += diferenca * diferenca / esperado
+	}
+
+	teste.GrausLiberdade = categorias
+	teste.Estatistica = arredondarCasas(estatistica, 4)
+	teste.Esperado = float64(categorias)
+	teste.PValor = arredondarCasas(pValorQuiQuadrado(estatistica, categorias), 6)
+	return teste
+}
+
+// testeCorrelacaoSerial mede a autocorrelação de ordem 1 da soma dos
+// sorteios. Para sorteios independentes ela tem média -1/(n-1) e variância
+// aproximada de 1/n.
+func testeCorrelacaoSerial(somas []float64) model.TesteAleatoriedade {
+	teste := model.TesteAleatoriedade{
+		Teste:     model.TesteCorrelacaoSerial,
+		Nome:      "Correlação serial",
+		Descricao: "Mede a correlação entre a soma de cada sorteio e a do sorteio seguinte, que deve ser próxima de zero para sorteios independentes.",
+	}
+
+	n := len(somas)
+	if n < sorteiosMinimosCorrelacao {
+		teste.Veredito = model.VereditoInsuficiente
+		return teste
+	}
+
+	var media float64
+	for _, soma := range somas {
+		media += soma
+	}
+	media /= float64(n)
+
+	var numerador, denominador float64
+	for i, soma := range somas {
+		desvio := soma - media
+		denominador += desvio * desvio
+		if i+1 < n {
+			numerador += desvio * (somas[i+1] - media)
+		}
+	}
+	if denominador == 0 {
+		teste.Veredito = model.VereditoInsuficiente
+		return teste
+	}
+
+	correlacao := numerador / denominador
+	esperado := -1 / float64(n-1)
+	z := (correlacao - esperado) * math.Sqrt(float64(n))
+
+	teste.Estatistica = arredondarCasas(correlacao, 4)
+	teste.Esperado = arredondarCasas(esperado, 4)
+	teste.PValor = arredondarCasas(pValorNormal(z), 6)
+	return teste
+}
+
+// pValorNormal é o p-valor bilateral de um escore z da normal padrão.
+func pValorNormal(z float64) float64 {
+	return math.Erfc(math.Abs(z) / math.Sqrt2)
+}
+
+// pValorQuiQuadrado é a probabilidade de a qui-quadrado com gl graus de
+// liberdade superar x, ou seja, a função gama incompleta superior
+// regularizada Q(gl/2, x/2).
+func pValorQuiQuadrado(x float64, gl int) float64 {
+	a, x := float64(gl)/2, x/2
+	if x <= 0 {
+		return 1
+	}
+	lgama, _ := math.Lgamma(a)
+	fator := math.Exp(-x + a*math.Log(x) - lgama)
+
+	if x < a+1 {
+		// Série de P(a, x), com Q = 1 - P
+		termo := 1 / a
+		soma := termo
+		for n := 1; n < 10000; n++ {
+			termo *= x / (a + float64(n))
+			soma += termo
+			if math.Abs(termo) < math.Abs(soma)*1e-15 {
+				break
+			}
+		}
+		return math.Max(0, 1-soma*fator)
+	}
+
+	// Fração continuada de Q(a, x) pelo método de Lentz
+	const minimo = 1e-300
+	b := x + 1 - a
+	c := 1 / minimo
+	d := 1 / b
+	h := d
+	for i := 1; i < 10000; i++ {
+		an := -float64(i) * (float64(i) - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < minimo {
+			d = minimo
+		}
+		c = b + an/c
+		if math.Abs(c) < minimo {
+			c = minimo
+		}
+		d = 1 / d
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < 1e-15 {
+			break
+		}
+	}
+	return fator * h
+}
+
+func arredondarCasas(valor float64, casas int) float64 {
+	escala := math.Pow(10, float64(casas))
+	return math.Round(valor*escala) / escala
+}
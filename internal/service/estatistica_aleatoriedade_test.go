@@ -0,0 +1,123 @@
+package service_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"loterias-api-golang/internal/model"
+	"loterias-api-golang/internal/service"
+)
+
+// sorteiosMegaSena gera sorteios da Mega-Sena com as dezenas escolhidas por
+// escolher a partir do índice do sorteio.
+func sorteiosMegaSena(quantidade int, escolher func(i int) []int) [][]string {
+	sorteios := make([][]string, 0, quantidade)
+	for i := 0; i < quantidade; i++ {
+		var dezenas []string
+		for _, n := range escolher(i) {
+			dezenas = append(dezenas, fmt.Sprintf("%02d", n))
+		}
+		sorteios = append(sorteios, dezenas)
+	}
+	return sorteios
+}
+
+func vereditos(aleatoriedade *model.Aleatoriedade) map[string]string {
+	vereditos := make(map[string]string)
+	for _, teste := range aleatoriedade.Testes {
+		vereditos[teste.Teste] = teste.Veredito
+	}
+	return vereditos
+}
+
+func TestTestarAleatoriedade(t *testing.T) {
+	regras, _ := model.MegaSena.Regras()
+	rng := rand.New(rand.NewSource(1))
+	sortear := func(menor, quantidade int) []int {
+		numeros := rng.Perm(quantidade)[:6]
+		for i := range numeros {
+			numeros[i] += menor
+		}
+		return numeros
+	}
+
+	t.Run("Sorteios uniformes", func(t *testing.T) {
+		sorteios := sorteiosMegaSena(2000, func(int) []int { return sortear(1, 60) })
+		aleatoriedade := service.TestarAleatoriedade(regras, sorteios, 0.01)
+
+		if aleatoriedade.Sorteios != 2000 || len(aleatoriedade.Testes) != 4 {
+			t.Fatalf("sorteios = %d, testes = %d, want 2000 e 4", aleatoriedade.Sorteios, len(aleatoriedade.Testes))
+		}
+		if aleatoriedade.Veredito != model.VereditoConsistente {
+			t.Errorf("Veredito = %s, want %s: %+v", aleatoriedade.Veredito, model.VereditoConsistente, aleatoriedade.Testes)
+		}
+		for _, teste := range aleatoriedade.Testes {
+			if teste.PValor <= 0 || teste.PValor > 1 {
+				t.Errorf("%s: p-valor = %v, want entre 0 e 1", teste.Teste, teste.PValor)
+			}
+		}
+	})
+
+	t.Run("Números concentrados na primeira metade", func(t *testing.T) {
+		sorteios := sorteiosMegaSena(500, func(int) []int { return sortear(1, 30) })
+		aleatoriedade := service.TestarAleatoriedade(regras, sorteios, 0.01)
+
+		if got := vereditos(aleatoriedade)[model.TesteQuiQuadrado]; got != model.VereditoDesvio {
+			t.Errorf("qui-quadrado = %s, want %s", got, model.VereditoDesvio)
+		}
+		if aleatoriedade.Veredito != model.VereditoDesvio {
+			t.Errorf("Veredito = %s, want %s", aleatoriedade.Veredito, model.VereditoDesvio)
+		}
+	})
+
+	t.Run("Sorteios alternando metades do volante", func(t *testing.T) {
+		sorteios := sorteiosMegaSena(500, func(i int) []int { return sortear(1+30*(i%2), 30) })
+		got := vereditos(service.TestarAleatoriedade(regras, sorteios, 0.01))
+
+		for _, teste := range []string{model.TesteSequencias, model.TesteIntervalos, model.TesteCorrelacaoSerial} {
+			if got[teste] != model.VereditoDesvio {
+				t.Errorf("%s = %s, want %s", teste, got[teste], model.VereditoDesvio)
+			}
+		}
+	})
+
+	t.Run("Poucos sorteios", func(t *testing.T) {
+		sorteios := sorteiosMegaSena(5, func(int) []int { return sortear(1, 60) })
+		aleatoriedade := service.TestarAleatoriedade(regras, sorteios, 0.05)
+
+		if aleatoriedade.Veredito != model.VereditoInsuficiente {
+			t.Errorf("Veredito = %s, want %s", aleatoriedade.Veredito, model.VereditoInsuficiente)
+		}
+	})
+
+	t.Run("Sorteios incompletos são ignorados", func(t *testing.T) {
+		sorteios := [][]string{{"01", "02", "03"}, {"01", "02", "03", "04", "05", "61"}, {"01", "02", "03", "04", "05", "06"}}
+		if got := service.TestarAleatoriedade(regras, sorteios, 0.05).Sorteios; got != 1 {
+			t.Errorf("Sorteios = %d, want 1", got)
+		}
+	})
+}
+
+func TestTestarAleatoriedadeSuperSete(t *testing.T) {
+	regras, _ := model.SuperSete.Regras()
+	rng := rand.New(rand.NewSource(3))
+
+	var sorteios [][]string
+	for i := 0; i < 1000; i++ {
+		var colunas []string
+		for c := 0; c < 7; c++ {
+			colunas = append(colunas, fmt.Sprint(rng.Intn(10)))
+		}
+		sorteios = append(sorteios, colunas)
+	}
+	aleatoriedade := service.TestarAleatoriedade(regras, sorteios, 0.01)
+
+	quiQuadrado := aleatoriedade.Testes[0]
+	if quiQuadrado.Teste != model.TesteQuiQuadrado || quiQuadrado.GrausLiberdade != 63 {
+		t.Errorf("qui-quadrado = %+v, want 63 graus de liberdade (9 por coluna)", quiQuadrado)
+	}
+	if aleatoriedade.Veredito != model.VereditoConsistente {
+		t.Errorf("Veredito = %s, want %s: %+v", aleatoriedade.Veredito, model.VereditoConsistente, aleatoriedade.Testes)
+	}
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"sort"
+
+	"loterias-api-golang/internal/model"
+)
+
+// Composicao monta os histogramas da composição gravada nos concursos
+// selecionados, com a soma agrupada em intervalos do tamanho informado.
+func (s *EstatisticaService) Composicao(loteria string, filtro model.FiltroConcursos, intervalo int) (*model.DistribuicaoComposicao, error) {
+	regras, err := regrasNumericas(loteria)
+	if err != nil {
+		return nil, err
+	}
+	if intervalo < 1 {
+		return nil, &model.ParametroInvalidoException{Message: "O intervalo da soma deve ser maior que zero"}
+	}
+
+	contagem, err := s.repository.ContarComposicao(loteria, filtro)
+	if err != nil {
+		return nil, err
+	}
+
+	return MontarDistribuicaoComposicao(regras, contagem, intervalo), nil
+}
+
+// MontarDistribuicaoComposicao converte as contagens da agregação em
+// histogramas com o percentual de sorteios de cada valor.
+func MontarDistribuicaoComposicao(regras model.Regras, contagem *model.ContagemComposicao, intervalo int) *model.DistribuicaoComposicao {
+	sorteios, soma := 0, 0
+	for _, c := range contagem.Soma {
+		sorteios += c.Quantidade
+		soma += c.Valor * c.Quantidade
+	}
+
+	distribuicao := &model.DistribuicaoComposicao{
+		Loteria:        string(regras.Loteria),
+		Concursos:      contagem.Concursos,
+		ConcursoInicio: contagem.ConcursoInicio,
+		ConcursoFim:    contagem.ConcursoFim,
+		Sorteios:       sorteios,
+		SomaEsperada:   arredondarCentavos(float64(regras.NumerosSorteados) * float64(regras.MenorNumero+regras.MaiorNumero) / 2),
+		IntervaloSoma:  intervalo,
+		Soma:           intervalosSoma(contagem.Soma, intervalo, sorteios),
+		Pares:          montarHistograma(contagem.Pares, -1, sorteios),
+		Primos:         montarHistograma(contagem.Primos, -1, sorteios),
+		Fibonacci:      montarHistograma(contagem.Fibonacci, -1, sorteios),
+	}
+	if sorteios > 0 {
+		distribuicao.SomaMedia = arredondarCentavos(float64(soma) / float64(sorteios))
+	}
+	if regras.Tipo == model.TipoColunas {
+		return distribuicao
+	}
+
+	distribuicao.Consecutivos = montarHistograma(contagem.Consecutivos, -1, sorteios)
+	distribuicao.MaiorSequencia = montarHistograma(contagem.MaiorSequencia, -1, sorteios)
+	decadas, linhas, colunas := gruposComposicao(regras)
+	distribuicao.Decadas = montarHistogramaGrupos(contagem.Decadas, decadas, sorteios)
+	distribuicao.Linhas = montarHistogramaGrupos(contagem.Linhas, linhas, sorteios)
+	distribuicao.Colunas = montarHistogramaGrupos(contagem.Colunas, colunas, sorteios)
+	return distribuicao
+}
+
+// montarHistograma soma as contagens de cada valor em ordem crescente. Com
+// grupo >= 0 considera apenas as contagens desse grupo.
+func montarHistograma(contagens []model.ContagemValor, grupo, sorteios int) []model.Histograma {
+	quantidades := make(map[int]int)
+	for _, c := range contagens {
+		if grupo < 0 || c.Grupo == grupo {
+			quantidades[c.Valor] += c.Quantidade
+		}
+	}
+
+	histograma := make([]model.Histograma, 0, len(quantidades))
+	for valor, quantidade := range quantidades {
+		histograma = append(histograma, model.Histograma{
+			Valor:      valor,
+			Sorteios:   quantidade,
+			Percentual: percentual(quantidade, sorteios),
+		})
+	}
+	sort.Slice(histograma, func(i, j int) bool { return histograma[i].Valor < histograma[j].Valor })
+	return histograma
+}
+
+func montarHistogramaGrupos(contagens []model.ContagemValor, grupos []string, sorteios int) []model.HistogramaGrupo {
+	if len(contagens) == 0 {
+		return nil
+	}
+	histogramas := make([]model.HistogramaGrupo, 0, len(grupos))
+	for i, grupo := range grupos {
+		histogramas = append(histogramas, model.HistogramaGrupo{
+			Grupo:      grupo,
+			Histograma: montarHistograma(contagens, i, sorteios),
+		})
+	}
+	return histogramas
+}
+
+// intervalosSoma agrupa as somas em intervalos alinhados a múltiplos do
+// tamanho informado, omitindo os intervalos sem sorteios.
+func intervalosSoma(contagens []model.ContagemValor, tamanho, sorteios int) []model.IntervaloSoma {
+	quantidades := make(map[int]int)
+	for _, c := range contagens {
+		inicio := c.Valor / tamanho * tamanho
+		quantidades[inicio] += c.Quantidade
+	}
+
+	intervalos := make([]model.IntervaloSoma, 0, len(quantidades))
+	for inicio, quantidade := range quantidades {
+		intervalos = append(intervalos, model.IntervaloSoma{
+			Inicio:     inicio,
+			Fim:        inicio + tamanho - 1,
+			Sorteios:   quantidade,
+			Percentual: percentual(quantidade, sorteios),
+		})
+	}
+	sort.Slice(intervalos, func(i, j int) bool { return intervalos[i].Inicio < intervalos[j].Inicio })
+	return intervalos
+}
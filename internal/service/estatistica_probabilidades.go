@@ -0,0 +1,303 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"loterias-api-golang/internal/model"
+)
+
+// opcoesEspeciais é quantas escolhas existem para os elementos especiais:
+// os 80 clubes da Timemania e os 12 meses do Dia de Sorte.
+var opcoesEspeciais = map[string]int64{
+	model.EspecialTimeCoracao: 80,
+	model.EspecialMesSorte:    12,
+}
+
+// contagemFaixa acumula, para uma faixa, quantos sorteios possíveis premiam a
+// aposta e a soma dos prêmios obtidos em todos eles.
+type contagemFaixa struct {
+	faixa   model.Faixa
+	casos   *big.Int
+	premios *big.Int
+	total   *big.Int
+}
+
+// Probabilidades calcula as chances de cada faixa para uma aposta com a
+// quantidade de números e trevos informada e o valor esperado da aposta. A
+// faixa principal usa a estimativa do próximo concurso; as demais, a média
+// paga por ganhador nos concursos selecionados.
+func (s *EstatisticaService) Probabilidades(loteria string, numeros, trevos int, filtro model.FiltroConcursos) (*model.Probabilidades, error) {
+	regras, err := regrasNumericas(loteria)
+	if err != nil {
+		return nil, err
+	}
+
+	probabilidades, err := CalcularProbabilidades(regras, numeros, trevos)
+	if err != nil {
+		return nil, err
+	}
+
+	ultimo, err := s.repository.FindLatest(loteria)
+	if err != nil {
+		return nil, err
+	}
+	medios, err := s.repository.PremiosMedios(loteria, filtro)
+	if err != nil {
+		return nil, err
+	}
+
+	probabilidades.ProximoConcurso = ultimo.ProximoConcurso
+	AplicarPremios(probabilidades, ultimo.ValorEstimadoProximoConcurso, medios)
+	return probabilidades, nil
+}
+
+// CalcularProbabilidades conta, com inteiros exatos, os sorteios que
+// premiam a aposta em cada faixa. Números e trevos zerados usam a aposta
+// simples. Na Super Sete os números são distribuídos entre as colunas o mais
+// igualmente possível, começando pela primeira.
+func CalcularProbabilidades(regras model.Regras, numeros, trevos int) (*model.Probabilidades, error) {
+	numeros, trevos, err := tamanhoAposta(regras, numeros, trevos)
+	if err != nil {
+		return nil, err
+	}
+
+	probabilidades := &model.Probabilidades{
+		Loteria: string(regras.Loteria),
+		Numeros: numeros,
+		Trevos:  trevos,
+		Faixas:  []model.ProbabilidadeFaixa{},
+	}
+
+	var contagens []contagemFaixa
+	if regras.Tipo == model.TipoColunas {
+		colunas := distribuirColunas(numeros, regras.Colunas.Quantidade)
+		probabilidades.Colunas = colunas
+		probabilidades.ApostasSimples = 1
+		for _, c := range colunas {
+			probabilidades.ApostasSimples *= int64(c)
+		}
+		probabilidades.Preco = regras.PrecoColunas(colunas)
+		contagens = contarColunas(regras, colunas)
+	} else {
+		probabilidades.ApostasSimples = regras.ApostasSimples(numeros, trevos)
+		probabilidades.Preco = float64(probabilidades.ApostasSimples) * regras.PrecoApostaSimples
+		contagens = contarDezenas(regras, numeros, trevos, probabilidades.ApostasSimples)
+	}
+
+	for _, c := range contagens {
+		faixa := model.ProbabilidadeFaixa{
+			Faixa:     c.faixa.Faixa,
+			Descricao: c.faixa.Descricao,
+			Casos:     c.casos.String(),
+			Total:     c.total.String(),
+		}
+		faixa.Probabilidade, _ = new(big.Rat).SetFrac(c.casos, c.total).Float64()
+		faixa.PremiosEsperados, _ = new(big.Rat).SetFrac(c.premios, c.total).Float64()
+		if c.casos.Sign() > 0 {
+			faixa.UmEm = new(big.Rat).SetFrac(c.total, c.casos).FloatString(2)
+		}
+		probabilidades.Faixas = append(probabilidades.Faixas, faixa)
+	}
+
+	return probabilidades, nil
+}
+
+// tamanhoAposta usa a aposta simples para números e trevos zerados e
+// confere as quantidades com as regras da loteria.
+func tamanhoAposta(regras model.Regras, numeros, trevos int) (int, int, error) {
+	if numeros == 0 {
+		numeros = regras.NumerosApostaSimples
+	}
+	if numeros < regras.MinNumeros || numeros > regras.MaxNumeros {
+		return 0, 0, &model.ParametroInvalidoException{
+			Message: fmt.Sprintf("A aposta deve ter entre %d e %d números", regras.MinNumeros, regras.MaxNumeros),
+		}
+	}
+	if regras.Trevos == nil {
+		if trevos != 0 {
+			return 0, 0, &model.ParametroInvalidoException{Message: "A loteria " + string(regras.Loteria) + " não tem trevos"}
+		}
+		return numeros, 0, nil
+	}
+	if trevos == 0 {
+		trevos = regras.Trevos.NumerosApostaSimples
+	}
+	if trevos < regras.Trevos.MinNumeros || trevos > regras.Trevos.MaxNumeros {
+		return 0, 0, &model.ParametroInvalidoException{
+			Message: fmt.Sprintf("A aposta deve ter entre %d e %d trevos", regras.Trevos.MinNumeros, regras.Trevos.MaxNumeros),
+		}
+	}
+	return numeros, trevos, nil
+}
+
+// AplicarPremios completa as faixas com o prêmio considerado e calcula o
+// valor esperado da aposta. A faixa 1 usa o prêmio estimado, quando
+// informado; as demais, a média histórica. Faixas sem prêmio conhecido não
+// entram no valor esperado.
+func AplicarPremios(probabilidades *model.Probabilidades, estimado float64, medios []model.PremioMedio) {
+	medias := make(map[int]float64, len(medios))
+	for _, m := range medios {
+		medias[m.Faixa] = m.Valor
+	}
+
+	probabilidades.PremioEstimado = arredondarCentavos(estimado)
+	var total float64
+	for i := range probabilidades.Faixas {
+		faixa := &probabilidades.Faixas[i]
+		premio, fonte := medias[faixa.Faixa], model.FontePremioMedia
+		if faixa.Faixa == 1 && estimado > 0 {
+			premio, fonte = estimado, model.FontePremioEstimativa
+		}
+		if premio == 0 {
+			continue
+		}
+		faixa.Premio = arredondarCentavos(premio)
+		faixa.FontePremio = fonte
+		valor := faixa.PremiosEsperados * premio
+		faixa.ValorEsperado = arredondarCentavos(valor)
+		total += valor
+	}
+
+	probabilidades.ValorEsperado = arredondarCentavos(total)
+	if probabilidades.Preco > 0 {
+		probabilidades.Retorno = math.Round(total/probabilidades.Preco*10000) / 100
+	}
+}
+
+// contarDezenas conta os sorteios que premiam uma aposta de dezenas a partir
+// da quantidade de acertos: há C(n,h)·C(u-n,d-h) sorteios em que a aposta de
+// n números acerta h das d dezenas sorteadas entre u possíveis. O mesmo vale
+// para os trevos, sorteados de forma independente.
+func contarDezenas(regras model.Regras, numeros, trevos int, apostasSimples int64) []contagemFaixa {
+	universo := regras.MaiorNumero - regras.MenorNumero + 1
+	sorteados := regras.NumerosSorteados
+	fixa := regras.MinNumeros == regras.MaxNumeros
+
+	porAcertos := distribuicaoAcertos(numeros, universo, sorteados)
+	total := binomial(universo, sorteados)
+
+	porTrevos := []*big.Int{big.NewInt(1)}
+	if regras.Trevos != nil {
+		universoTrevos := regras.Trevos.MaiorNumero - regras.Trevos.MenorNumero + 1
+		porTrevos = distribuicaoAcertos(trevos, universoTrevos, regras.Trevos.Sorteados)
+		total.Mul(total, binomial(universoTrevos, regras.Trevos.Sorteados))
+	}
+
+	contagens := make([]contagemFaixa, 0, len(regras.Faixas))
+	for _, faixa := range regras.Faixas {
+		if opcoes, ok := opcoesEspeciais[faixa.Especial]; ok {
+			contagens = append(contagens, contagemFaixa{
+				faixa:   faixa,
+				casos:   big.NewInt(1),
+				premios: big.NewInt(apostasSimples),
+				total:   big.NewInt(opcoes),
+			})
+			continue
+		}
+
+		contagem := contagemFaixa{faixa: faixa, casos: new(big.Int), premios: new(big.Int), total: total}
+		for h, sorteios := range porAcertos {
+			quantidade := apostasNaFaixa(numeros, h, regras.NumerosApostaSimples, faixa.Acertos, fixa)
+			if quantidade == 0 {
+				continue
+			}
+			for t, sorteiosTrevos := range porTrevos {
+				comTrevos := int64(1)
+				if regras.Trevos != nil && len(faixa.Trevos) > 0 {
+					comTrevos = 0
+					for _, acertos := range faixa.Trevos {
+						comTrevos += apostasNaFaixa(trevos, t, regras.Trevos.NumerosApostaSimples, acertos, false)
+					}
+				}
+				if comTrevos == 0 {
+					continue
+				}
+				casos := new(big.Int).Mul(sorteios, sorteiosTrevos)
+				contagem.casos.Add(contagem.casos, casos)
+				contagem.premios.Add(contagem.premios, casos.Mul(casos, big.NewInt(quantidade*comTrevos)))
+			}
+		}
+		contagens = append(contagens, contagem)
+	}
+	return contagens
+}
+
+// contarColunas conta os sorteios que premiam uma aposta da Super Sete
+// percorrendo todas as combinações de colunas acertadas. Uma coluna com m
+// números é acertada em m dos valores possíveis.
+func contarColunas(regras model.Regras, colunas []int) []contagemFaixa {
+	valores := int64(regras.MaiorNumero - regras.MenorNumero + 1)
+	total := new(big.Int).Exp(big.NewInt(valores), big.NewInt(int64(len(colunas))), nil)
+
+	contagens := make([]contagemFaixa, len(regras.Faixas))
+	for i, faixa := range regras.Faixas {
+		contagens[i] = contagemFaixa{faixa: faixa, casos: new(big.Int), premios: new(big.Int), total: total}
+	}
+
+	for mascara := 0; mascara < 1<<len(colunas); mascara++ {
+		sorteios := int64(1)
+		// distribuicao[k] guarda quantas apostas simples acertam k colunas
+		distribuicao := []int64{1}
+		for i, m := range colunas {
+			acerto, erros := int64(0), int64(m)
+			if mascara&(1<<i) != 0 {
+				acerto, erros = 1, int64(m-1)
+				sorteios *= int64(m)
+			} else {
+				sorteios *= valores - int64(m)
+			}
+
+			proxima := make([]int64, len(distribuicao)+1)
+			for k, qtd := range distribuicao {
+				proxima[k] += qtd * erros
+				proxima[k+1] += qtd * acerto
+			}
+			distribuicao = proxima
+		}
+		if sorteios == 0 {
+			continue
+		}
+
+		for i, faixa := range regras.Faixas {
+			if faixa.Acertos >= len(distribuicao) || distribuicao[faixa.Acertos] == 0 {
+				continue
+			}
+			contagens[i].casos.Add(contagens[i].casos, big.NewInt(sorteios))
+			contagens[i].premios.Add(contagens[i].premios, new(big.Int).Mul(big.NewInt(sorteios), big.NewInt(distribuicao[faixa.Acertos])))
+		}
+	}
+	return contagens
+}
+
+// distribuicaoAcertos retorna, para cada quantidade de acertos h, em quantos
+// sorteios de d números entre u a aposta de n números acerta h.
+func distribuicaoAcertos(n, u, d int) []*big.Int {
+	distribuicao := make([]*big.Int, 0, d+1)
+	for h := 0; h <= d; h++ {
+		distribuicao = append(distribuicao, new(big.Int).Mul(binomial(n, h), binomial(u-n, d-h)))
+	}
+	return distribuicao
+}
+
+// distribuirColunas reparte os números entre as colunas o mais igualmente
+// possível, dando as sobras às primeiras colunas.
+func distribuirColunas(numeros, quantidade int) []int {
+	colunas := make([]int, quantidade)
+	for i := range colunas {
+		colunas[i] = numeros / quantidade
+		if i < numeros%quantidade {
+			colunas[i]++
+		}
+	}
+	return colunas
+}
+
+// binomial retorna C(n, k) sem limite de tamanho, zero fora do domínio.
+func binomial(n, k int) *big.Int {
+	if k < 0 || n < 0 || k > n {
+		return new(big.Int)
+	}
+	return new(big.Int).Binomial(int64(n), int64(k))
+}
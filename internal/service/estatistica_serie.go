@@ -0,0 +1,68 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"loterias-api-golang/internal/model"
+)
+
+// Serie retorna a evolução de um valor da loteria, como arrecadação ou
+// prêmio acumulado, agrupada por concurso, mês ou ano.
+func (s *EstatisticaService) Serie(loteria string, filtro model.FiltroConcursos, campo, agrupamento string) (*model.Serie, error) {
+	if !model.IsValid(loteria) {
+		return nil, &model.LoteriaInvalidException{Message: fmt.Sprintf("'%s' não é uma loteria suportada", loteria)}
+	}
+
+	nomeBanco, ok := model.CampoSerie(campo)
+	if !ok {
+		return nil, &model.ParametroInvalidoException{
+			Message: "O parâmetro 'campo' deve ser um destes: " + strings.Join(model.CamposSerie, ", "),
+		}
+	}
+
+	if agrupamento == "" {
+		agrupamento = model.AgruparPorConcurso
+	}
+	if agrupamento != model.AgruparPorConcurso && agrupamento != model.AgruparPorMes && agrupamento != model.AgruparPorAno {
+		return nil, &model.ParametroInvalidoException{Message: "O parâmetro 'agrupar' deve ser 'concurso', 'mes' ou 'ano'"}
+	}
+
+	pontos, err := s.repository.SerieValores(loteria, filtro, nomeBanco, agrupamento)
+	if err != nil {
+		return nil, err
+	}
+
+	return MontarSerie(loteria, campo, agrupamento, pontos), nil
+}
+
+// MontarSerie arredonda os valores dos pontos agregados e anula os dos
+// períodos sem nenhum concurso com o valor.
+func MontarSerie(loteria, campo, agrupamento string, pontos []model.PontoSerie) *model.Serie {
+	for i := range pontos {
+		if pontos[i].ConcursosComValor == 0 {
+			pontos[i].Soma, pontos[i].Media, pontos[i].Maximo = nil, nil, nil
+		}
+		pontos[i].Soma = arredondarValor(pontos[i].Soma)
+		pontos[i].Media = arredondarValor(pontos[i].Media)
+		pontos[i].Maximo = arredondarValor(pontos[i].Maximo)
+		if agrupamento != model.AgruparPorConcurso {
+			pontos[i].Data = ""
+		}
+	}
+
+	return &model.Serie{
+		Loteria:     loteria,
+		Campo:       campo,
+		Agrupamento: agrupamento,
+		Pontos:      pontos,
+	}
+}
+
+func arredondarValor(valor *float64) *float64 {
+	if valor == nil {
+		return nil
+	}
+	arredondado := arredondarCentavos(*valor)
+	return &arredondado
+}
@@ -0,0 +1,100 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+
+	"loterias-api-golang/internal/model"
+	"loterias-api-golang/internal/repository"
+	"loterias-api-golang/internal/service"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestSerie_Validacao(t *testing.T) {
+	estatisticaService := service.NewEstatisticaService(nil)
+
+	tests := []struct {
+		name        string
+		loteria     string
+		campo       string
+		agrupamento string
+		invalida    bool
+	}{
+		{"Loteria inexistente", "quina-especial", "valorArrecadado", "", true},
+		{"Campo não aceito", "megasena", "dezenas", "", false},
+		{"Agrupamento inválido", "megasena", "valorArrecadado", "semana", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := estatisticaService.Serie(tt.loteria, model.FiltroConcursos{}, tt.campo, tt.agrupamento)
+			var loteria *model.LoteriaInvalidException
+			var parametro *model.ParametroInvalidoException
+			if tt.invalida && !errors.As(err, &loteria) {
+				t.Errorf("err = %v, want LoteriaInvalidException", err)
+			}
+			if !tt.invalida && !errors.As(err, &parametro) {
+				t.Errorf("err = %v, want ParametroInvalidoException", err)
+			}
+		})
+	}
+}
+
+func TestMontarSerie(t *testing.T) {
+	valor := func(v float64) *float64 { return &v }
+	pontos := []model.PontoSerie{
+		{Periodo: "2010", Data: "02/01/2010", Concursos: 3, ConcursosComValor: 0, Soma: valor(0)},
+		{Periodo: "2011", Data: "05/01/2011", Concursos: 3, ConcursosComValor: 2, Soma: valor(300.004), Media: valor(150.002), Maximo: valor(200.006)},
+	}
+
+	serie := service.MontarSerie("megasena", "valorArrecadado", model.AgruparPorAno, pontos)
+	if serie.Loteria != "megasena" || serie.Campo != "valorArrecadado" || serie.Agrupamento != model.AgruparPorAno {
+		t.Errorf("Serie = %+v", serie)
+	}
+
+	vazio := serie.Pontos[0]
+	if vazio.Soma != nil || vazio.Media != nil || vazio.Maximo != nil {
+		t.Errorf("Pontos[0] = %+v, want nil values for a period without the field", vazio)
+	}
+	if vazio.Data != "" {
+		t.Errorf("Pontos[0].Data = %q, want empty outside the grouping by contest", vazio.Data)
+	}
+
+	cheio := serie.Pontos[1]
+	if cheio.Soma == nil || *cheio.Soma != 300 || cheio.Media == nil || *cheio.Media != 150 || cheio.Maximo == nil || *cheio.Maximo != 200.01 {
+		t.Errorf("Pontos[1] = %+v, want values rounded to cents", cheio)
+	}
+}
+
+func TestEstagiosSerie(t *testing.T) {
+	estagio := func(estagios []bson.D, operador string) bson.M {
+		for _, e := range estagios {
+			if e[0].Key == operador {
+				return e[0].Value.(bson.M)
+			}
+		}
+		return nil
+	}
+
+	porConcurso := repository.EstagiosSerie("valorArrecadado", model.AgruparPorConcurso)
+	if estagio(porConcurso, "$match") != nil {
+		t.Error("grouping by contest should not require the draw date")
+	}
+	if valor := estagio(porConcurso, "$project")["valor"]; valor != "$valorArrecadado" {
+		t.Errorf("$project.valor = %v, want the raw field so missing values stay null", valor)
+	}
+	grupo := estagio(porConcurso, "$group")
+	if _, ok := grupo["concursosComValor"]; !ok {
+		t.Errorf("$group = %v, want concursosComValor", grupo)
+	}
+
+	porMes := repository.EstagiosSerie("valorArrecadado", model.AgruparPorMes)
+	if estagio(porMes, "$match") == nil {
+		t.Error("grouping by month should skip contests without the draw date")
+	}
+	periodo := estagio(porMes, "$project")["periodo"].(bson.M)["$dateToString"].(bson.M)
+	if periodo["format"] != "%Y-%m" || periodo["timezone"] != "America/Sao_Paulo" {
+		t.Errorf("periodo = %v, want months in Brasília time", periodo)
+	}
+}
@@ -0,0 +1,406 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"loterias-api-golang/internal/model"
+	"loterias-api-golang/internal/repository"
+)
+
+type EstatisticaService struct {
+	repository *repository.ResultadoRepository
+}
+
+func NewEstatisticaService(repository *repository.ResultadoRepository) *EstatisticaService {
+	return &EstatisticaService{
+		repository: repository,
+	}
+}
+
+// Frequencia retorna quantas vezes cada número da loteria foi sorteado,
+// incluindo os que nunca saíram. Na Super Sete a contagem é feita por coluna.
+func (s *EstatisticaService) Frequencia(loteria string, filtro model.FiltroConcursos) (*model.Frequencia, error) {
+	regras, err := regrasNumericas(loteria)
+	if err != nil {
+		return nil, err
+	}
+
+	contagem, err := s.repository.ContarFrequencia(loteria, filtro, regras.Tipo == model.TipoColunas)
+	if err != nil {
+		return nil, err
+	}
+
+	return MontarFrequencia(regras, contagem), nil
+}
+
+// MontarFrequencia converte as contagens da agregação na frequência de cada
+// número do volante, com os percentuais sobre os sorteios dos concursos.
+func MontarFrequencia(regras model.Regras, contagem *model.ContagemFrequencia) *model.Frequencia {
+	frequencia := &model.Frequencia{
+		Loteria:        string(regras.Loteria),
+		Concursos:      contagem.Concursos,
+		ConcursoInicio: contagem.ConcursoInicio,
+		ConcursoFim:    contagem.ConcursoFim,
+	}
+
+	sorteios := contagem.Concursos * regras.Sorteios
+	if regras.Tipo == model.TipoColunas {
+		for coluna := 0; coluna < regras.Colunas.Quantidade; coluna++ {
+			quantidades := somarContagens(contagem.Dezenas, coluna)
+			frequencia.Colunas = append(frequencia.Colunas,
+				montarFrequencias(quantidades, regras.MenorNumero, regras.MaiorNumero, sorteios, strconv.Itoa))
+		}
+	} else {
+		frequencia.Dezenas = montarFrequencias(somarContagens(contagem.Dezenas, -1),
+			regras.MenorNumero, regras.MaiorNumero, sorteios, formatarDezena)
+	}
+
+	if regras.Trevos != nil {
+		frequencia.Trevos = montarFrequencias(somarContagens(contagem.Trevos, -1),
+			regras.Trevos.MenorNumero, regras.Trevos.MaiorNumero, contagem.Concursos, strconv.Itoa)
+	}
+
+	return frequencia
+}
+
+// regrasNumericas retorna as regras de loterias cujo resultado é formado por
+// números sorteados, recusando a Federal.
+func regrasNumericas(loteria string) (model.Regras, error) {
+	regras, ok := model.GetRegras(loteria)
+	if !ok {
+		return regras, &model.LoteriaInvalidException{Message: fmt.Sprintf("'%s' não é uma loteria suportada", loteria)}
+	}
+	if regras.Tipo == model.TipoBilhete {
+		return regras, &model.ParametroInvalidoException{Message: "Estatística não disponível para a loteria " + loteria}
+	}
+	return regras, nil
+}
+
+// somarContagens agrupa as contagens pelo valor numérico, já que a Caixa nem
+// sempre usa zero à esquerda. Com posicao >= 0 considera apenas essa posição.
+func somarContagens(contagens []model.ContagemNumero, posicao int) map[int]int {
+	quantidades := make(map[int]int)
+	for _, c := range contagens {
+		if posicao >= 0 && c.Posicao != posicao {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(c.Numero))
+		if err != nil {
+			continue
+		}
+		quantidades[n] += c.Quantidade
+	}
+	return quantidades
+}
+
+// montarFrequencias cobre todo o intervalo do volante e ordena do número mais
+// sorteado para o menos sorteado.
+func montarFrequencias(quantidades map[int]int, menor, maior, sorteios int, formatar func(int) string) []model.FrequenciaNumero {
+	type item struct {
+		numero     int
+		frequencia int
+	}
+
+	itens := make([]item, 0, maior-menor+1)
+	for n := menor; n <= maior; n++ {
+		itens = append(itens, item{numero: n, frequencia: quantidades[n]})
+	}
+	sort.SliceStable(itens, func(i, j int) bool {
+		return itens[i].frequencia > itens[j].frequencia
+	})
+
+	frequencias := make([]model.FrequenciaNumero, len(itens))
+	for i, it := range itens {
+		frequencias[i] = model.FrequenciaNumero{
+			Numero:     formatar(it.numero),
+			Frequencia: it.frequencia,
+			Percentual: percentual(it.frequencia, sorteios),
+		}
+	}
+	return frequencias
+}
+
+func percentual(parte, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(parte)/float64(total)*10000) / 100
+}
+
+// Atraso calcula, para cada número, há quantos concursos ele não é sorteado,
+// o maior atraso já registrado e o atraso médio. Na +Milionária os trevos são
+// calculados à parte e na Super Sete o cálculo é feito por coluna.
+func (s *EstatisticaService) Atraso(loteria string, filtro model.FiltroConcursos) (*model.Atraso, error) {
+	regras, err := regrasNumericas(loteria)
+	if err != nil {
+		return nil, err
+	}
+
+	porColuna := regras.Tipo == model.TipoColunas
+	aparicoes, err := s.repository.ListarAparicoes(loteria, filtro, porColuna)
+	if err != nil {
+		return nil, err
+	}
+
+	atraso := &model.Atraso{
+		Loteria:        loteria,
+		Concursos:      aparicoes.Concursos,
+		ConcursoInicio: aparicoes.ConcursoInicio,
+		ConcursoFim:    aparicoes.ConcursoFim,
+	}
+
+	inicio, fim := aparicoes.ConcursoInicio, aparicoes.ConcursoFim
+	if porColuna {
+		for coluna := 0; coluna < regras.Colunas.Quantidade; coluna++ {
+			atraso.Colunas = append(atraso.Colunas, montarAtrasos(agruparAparicoes(aparicoes.Dezenas, coluna),
+				regras.MenorNumero, regras.MaiorNumero, inicio, fim, strconv.Itoa))
+		}
+	} else {
+		atraso.Dezenas = montarAtrasos(agruparAparicoes(aparicoes.Dezenas, -1),
+			regras.MenorNumero, regras.MaiorNumero, inicio, fim, formatarDezena)
+	}
+
+	if regras.Trevos != nil {
+		atraso.Trevos = montarAtrasos(agruparAparicoes(aparicoes.Trevos, -1),
+			regras.Trevos.MenorNumero, regras.Trevos.MaiorNumero, inicio, fim, strconv.Itoa)
+	}
+
+	return atraso, nil
+}
+
+// agruparAparicoes junta os concursos de cada número pelo valor numérico,
+// removendo duplicados e ordenando. Com posicao >= 0 considera apenas essa
+// posição.
+func agruparAparicoes(aparicoes []model.AparicoesNumero, posicao int) map[int][]int {
+	vistos := make(map[int]map[int]bool)
+	for _, a := range aparicoes {
+		if posicao >= 0 && a.Posicao != posicao {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(a.Numero))
+		if err != nil {
+			continue
+		}
+		if vistos[n] == nil {
+			vistos[n] = make(map[int]bool)
+		}
+		for _, c := range a.Concursos {
+			vistos[n][c] = true
+		}
+	}
+
+	agrupado := make(map[int][]int, len(vistos))
+	for n, concursos := range vistos {
+		lista := make([]int, 0, len(concursos))
+		for c := range concursos {
+			lista = append(lista, c)
+		}
+		sort.Ints(lista)
+		agrupado[n] = lista
+	}
+	return agrupado
+}
+
+// montarAtrasos cobre todo o intervalo do volante e ordena do número mais
+// atrasado para o menos atrasado.
+func montarAtrasos(aparicoes map[int][]int, menor, maior, inicio, fim int, formatar func(int) string) []model.AtrasoNumero {
+	atrasos := make([]model.AtrasoNumero, 0, maior-menor+1)
+	for n := menor; n <= maior; n++ {
+		atraso := CalcularAtraso(aparicoes[n], inicio, fim)
+		atraso.Numero = formatar(n)
+		atrasos = append(atrasos, atraso)
+	}
+	sort.SliceStable(atrasos, func(i, j int) bool {
+		return atrasos[i].AtrasoAtual > atrasos[j].AtrasoAtual
+	})
+	return atrasos
+}
+
+// CalcularAtraso calcula o atraso de um número a partir dos concursos, em
+// ordem crescente, em que ele saiu dentro do intervalo [inicio, fim].
+// O atraso médio considera os intervalos entre aparições consecutivas; o
+// máximo considera também o período antes da primeira aparição e o atual.
+func CalcularAtraso(concursos []int, inicio, fim int) model.AtrasoNumero {
+	atraso := model.AtrasoNumero{Frequencia: len(concursos)}
+	if fim == 0 {
+		return atraso
+	}
+
+	if len(concursos) == 0 {
+		atraso.AtrasoAtual = fim - inicio + 1
+		atraso.AtrasoMaximo = atraso.AtrasoAtual
+		return atraso
+	}
+
+	ultimo := concursos[len(concursos)-1]
+	atraso.UltimoConcurso = ultimo
+	atraso.AtrasoAtual = fim - ultimo
+	atraso.AtrasoMaximo = max(concursos[0]-inicio, atraso.AtrasoAtual)
+
+	soma := 0
+	for i := 1; i < len(concursos); i++ {
+		intervalo := concursos[i] - concursos[i-1] - 1
+		soma += intervalo
+		atraso.AtrasoMaximo = max(atraso.AtrasoMaximo, intervalo)
+	}
+	if len(concursos) > 1 {
+		atraso.AtrasoMedio = math.Round(float64(soma)/float64(len(concursos)-1)*100) / 100
+	}
+
+	return atraso
+}
+
+// Coocorrencia conta quantas vezes cada par (tamanho 2) ou trio (tamanho 3)
+// de números saiu no mesmo sorteio e retorna os top mais frequentes. Na
+// Dupla Sena os dois sorteios são analisados separadamente.
+func (s *EstatisticaService) Coocorrencia(loteria string, filtro model.FiltroConcursos, tamanho, top int) (*model.Coocorrencia, error) {
+	regras, err := regrasNumericas(loteria)
+	if err != nil {
+		return nil, err
+	}
+	if regras.Tipo == model.TipoColunas {
+		return nil, &model.ParametroInvalidoException{Message: "Coocorrência não disponível para a loteria " + loteria}
+	}
+	if tamanho != 2 && tamanho != 3 {
+		return nil, &model.ParametroInvalidoException{Message: "O tamanho da combinação deve ser 2 (pares) ou 3 (trios)"}
+	}
+	if top < 1 || top > 500 {
+		return nil, &model.ParametroInvalidoException{Message: "O parâmetro 'top' deve estar entre 1 e 500"}
+	}
+
+	concursos, err := s.repository.ListarDezenas(loteria, filtro)
+	if err != nil {
+		return nil, err
+	}
+
+	return MontarCoocorrencia(regras, concursos, tamanho, top), nil
+}
+
+// MontarCoocorrencia conta as combinações dos concursos já carregados, em
+// ordem crescente de concurso, e compara cada uma com o esperado.
+func MontarCoocorrencia(regras model.Regras, concursos []model.DezenasConcurso, tamanho, top int) *model.Coocorrencia {
+	coocorrencia := &model.Coocorrencia{
+		Loteria:   string(regras.Loteria),
+		Tamanho:   tamanho,
+		Concursos: len(concursos),
+		Sorteios:  []model.CoocorrenciaSorteio{},
+	}
+	if len(concursos) > 0 {
+		coocorrencia.ConcursoInicio = concursos[0].Concurso
+		coocorrencia.ConcursoFim = concursos[len(concursos)-1].Concurso
+	}
+
+	volante := regras.MaiorNumero - regras.MenorNumero + 1
+	probabilidade := 1.0
+	for i := 0; i < tamanho; i++ {
+		probabilidade *= float64(regras.NumerosSorteados-i) / float64(volante-i)
+	}
+
+	for sorteio := 1; sorteio <= regras.Sorteios; sorteio++ {
+		matriz := newMatrizCoocorrencia(regras.MenorNumero, volante, tamanho)
+		for _, c := range concursos {
+			matriz.adicionar(parseDezenas(dezenasDoSorteio(c.Dezenas, regras, sorteio)))
+		}
+
+		esperado := float64(len(concursos)) * probabilidade
+		resultado := model.CoocorrenciaSorteio{
+			Sorteio:     sorteio,
+			Esperado:    math.Round(esperado*100) / 100,
+			Combinacoes: []model.CombinacaoFrequente{},
+		}
+		for _, combinacao := range matriz.maisFrequentes(top) {
+			if esperado > 0 {
+				combinacao.Razao = math.Round(float64(combinacao.Ocorrencias)/esperado*100) / 100
+			}
+			resultado.Combinacoes = append(resultado.Combinacoes, combinacao)
+		}
+		coocorrencia.Sorteios = append(coocorrencia.Sorteios, resultado)
+	}
+
+	return coocorrencia
+}
+
+// matrizCoocorrencia conta apenas as combinações sorteadas, indexadas pela
+// posição de cada número no volante compactada em base volante.
+type matrizCoocorrencia struct {
+	menor    int
+	volante  int
+	tamanho  int
+	contagem map[int]int
+}
+
+func newMatrizCoocorrencia(menor, volante, tamanho int) *matrizCoocorrencia {
+	return &matrizCoocorrencia{
+		menor:    menor,
+		volante:  volante,
+		tamanho:  tamanho,
+		contagem: make(map[int]int),
+	}
+}
+
+// adicionar soma uma ocorrência para cada combinação dos números sorteados,
+// que devem estar em ordem crescente.
+func (m *matrizCoocorrencia) adicionar(numeros []int) {
+	var percorrer func(inicio, indice, profundidade int)
+	percorrer = func(inicio, indice, profundidade int) {
+		if profundidade == m.tamanho {
+			m.contagem[indice]++
+			return
+		}
+		for i := inicio; i < len(numeros); i++ {
+			posicao := numeros[i] - m.menor
+			if posicao < 0 || posicao >= m.volante {
+				continue
+			}
+			percorrer(i+1, indice*m.volante+posicao, profundidade+1)
+		}
+	}
+	percorrer(0, 0, 0)
+}
+
+func (m *matrizCoocorrencia) maisFrequentes(top int) []model.CombinacaoFrequente {
+	indices := make([]int, 0, len(m.contagem))
+	for i := range m.contagem {
+		indices = append(indices, i)
+	}
+	sort.Slice(indices, func(a, b int) bool {
+		if m.contagem[indices[a]] != m.contagem[indices[b]] {
+			return m.contagem[indices[a]] > m.contagem[indices[b]]
+		}
+		return indices[a] < indices[b]
+	})
+	if len(indices) > top {
+		indices = indices[:top]
+	}
+
+	combinacoes := make([]model.CombinacaoFrequente, len(indices))
+	for i, indice := range indices {
+		numeros := make([]string, m.tamanho)
+		resto := indice
+		for p := m.tamanho - 1; p >= 0; p-- {
+			numeros[p] = formatarDezena(resto%m.volante + m.menor)
+			resto /= m.volante
+		}
+		combinacoes[i] = model.CombinacaoFrequente{Numeros: numeros, Ocorrencias: m.contagem[indice]}
+	}
+	return combinacoes
+}
+
+// parseDezenas converte as dezenas de um resultado em números ordenados,
+// ignorando valores inválidos e repetidos.
+func parseDezenas(dezenas []string) []int {
+	numeros := make([]int, 0, len(dezenas))
+	for _, d := range dezenas {
+		n, err := strconv.Atoi(strings.TrimSpace(d))
+		if err != nil || contem(numeros, n) {
+			continue
+		}
+		numeros = append(numeros, n)
+	}
+	sort.Ints(numeros)
+	return numeros
+}
@@ -0,0 +1,436 @@
+package service_test
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"loterias-api-golang/internal/model"
+	"loterias-api-golang/internal/service"
+)
+
+func TestCalcularAtraso(t *testing.T) {
+	tests := []struct {
+		name      string
+		concursos []int
+		atual     int
+		maximo    int
+		medio     float64
+		ultimo    int
+	}{
+		{"Nunca sorteado", nil, 10, 10, 0, 0},
+		{"Sorteado no último concurso", []int{3, 10}, 0, 6, 6, 10},
+		{"Atraso atual é o maior", []int{2, 4}, 6, 6, 1, 4},
+		{"Intervalos variados", []int{1, 2, 6, 9}, 1, 3, 1.67, 9},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			atraso := service.CalcularAtraso(tt.concursos, 1, 10)
+			if atraso.AtrasoAtual != tt.atual {
+				t.Errorf("AtrasoAtual = %d, want %d", atraso.AtrasoAtual, tt.atual)
+			}
+			if atraso.AtrasoMaximo != tt.maximo {
+				t.Errorf("AtrasoMaximo = %d, want %d", atraso.AtrasoMaximo, tt.maximo)
+			}
+			if atraso.AtrasoMedio != tt.medio {
+				t.Errorf("AtrasoMedio = %v, want %v", atraso.AtrasoMedio, tt.medio)
+			}
+			if atraso.UltimoConcurso != tt.ultimo {
+				t.Errorf("UltimoConcurso = %d, want %d", atraso.UltimoConcurso, tt.ultimo)
+			}
+		})
+	}
+}
+
+func TestCalcularAcumulacoes(t *testing.T) {
+	concursos := []model.AcumulacaoConcurso{
+		{Concurso: 1, Acumulou: false, GanhadoresPrincipal: 1, PremioPrincipal: 1000},
+		{Concurso: 2, Acumulou: true, ValorAcumuladoProximoConcurso: 1000},
+		{Concurso: 3, Acumulou: true, ValorAcumuladoProximoConcurso: 2500},
+		{Concurso: 4, Acumulou: false, GanhadoresPrincipal: 2, PremioPrincipal: 1500},
+		{Concurso: 5, Acumulou: true, ValorAcumuladoProximoConcurso: 800},
+		{Concurso: 6, Acumulou: false, GanhadoresPrincipal: 1, PremioPrincipal: 1200},
+		{Concurso: 7, Acumulou: true, ValorAcumuladoProximoConcurso: 500},
+		{Concurso: 8, Acumulou: true, ValorAcumuladoProximoConcurso: 1100},
+		{Concurso: 9, Acumulou: true, ValorAcumuladoProximoConcurso: 1900},
+	}
+
+	acumulacoes := service.CalcularAcumulacoes(concursos)
+	if acumulacoes.Concursos != 9 || acumulacoes.ConcursosAcumulados != 6 {
+		t.Errorf("Concursos = %d, ConcursosAcumulados = %d, want 9 and 6", acumulacoes.Concursos, acumulacoes.ConcursosAcumulados)
+	}
+	if len(acumulacoes.Sequencias) != 3 {
+		t.Fatalf("Sequencias = %+v, want 3", acumulacoes.Sequencias)
+	}
+
+	primeira := acumulacoes.Sequencias[0]
+	if primeira.ConcursoInicio != 2 || primeira.ConcursoFim != 3 || primeira.Concursos != 2 ||
+		primeira.Crescimento != 2500 || primeira.ConcursoPremiado != 4 || primeira.Ganhadores != 2 {
+		t.Errorf("Sequencias[0] = %+v", primeira)
+	}
+
+	if acumulacoes.Atual == nil || !acumulacoes.Atual.EmAndamento || acumulacoes.Atual.Concursos != 3 || acumulacoes.Atual.Crescimento != 1900 {
+		t.Errorf("Atual = %+v, want ongoing streak of 3 contests growing 1900", acumulacoes.Atual)
+	}
+	if segunda := acumulacoes.Sequencias[1]; segunda.Concursos != 1 || segunda.ValorInicial != 0 || segunda.Crescimento != 800 {
+		t.Errorf("Sequencias[1] = %+v, want a one-contest streak growing 800", segunda)
+	}
+	if acumulacoes.Maior == nil || acumulacoes.Maior.ConcursoInicio != 7 {
+		t.Errorf("Maior = %+v, want streak starting at 7", acumulacoes.Maior)
+	}
+	if acumulacoes.MediaConcursos != 1.5 {
+		t.Errorf("MediaConcursos = %v, want 1.5", acumulacoes.MediaConcursos)
+	}
+	if len(acumulacoes.Distribuicao) != 2 || acumulacoes.Distribuicao[0].Concursos != 1 || acumulacoes.Distribuicao[0].Percentual != 50 {
+		t.Errorf("Distribuicao = %+v, want 1 and 2 contests with 50%% each", acumulacoes.Distribuicao)
+	}
+
+	if atual := service.CalcularAcumulacoes(concursos[:4]).Atual; atual != nil {
+		t.Errorf("Atual = %+v, want nil after a winner", atual)
+	}
+}
+
+func TestCalcularAcumulacoes_ConcursoEspecial(t *testing.T) {
+	concursos := []model.AcumulacaoConcurso{
+		{Concurso: 1, Acumulou: true, ValorAcumuladoProximoConcurso: 300},
+		{Concurso: 2, Acumulou: false, GanhadoresPrincipal: 1, PremioPrincipal: 300, ValorAcumuladoProximoConcurso: 100},
+		{Concurso: 3, Acumulou: true, ValorAcumuladoProximoConcurso: 600},
+		{Concurso: 4, Acumulou: false, Especial: true, GanhadoresPrincipal: 3, PremioPrincipal: 5000},
+		{Concurso: 5, Acumulou: true, ValorAcumuladoProximoConcurso: 900},
+		{Concurso: 6, Acumulou: false, GanhadoresPrincipal: 1, PremioPrincipal: 900},
+	}
+
+	acumulacoes := service.CalcularAcumulacoes(concursos)
+	if acumulacoes.ConcursosAcumulados != 3 || len(acumulacoes.Sequencias) != 2 {
+		t.Fatalf("Acumulacoes = %+v, want 3 accumulated contests in 2 streaks", acumulacoes)
+	}
+
+	primeira := acumulacoes.Sequencias[0]
+	if primeira.ValorInicial != 0 || primeira.Crescimento != 300 {
+		t.Errorf("Sequencias[0] = %+v, want growth from zero without a previous contest", primeira)
+	}
+
+	segunda := acumulacoes.Sequencias[1]
+	if segunda.ConcursoInicio != 3 || segunda.ConcursoFim != 5 || segunda.Concursos != 2 || segunda.ConcursoPremiado != 6 {
+		t.Errorf("Sequencias[1] = %+v, want the special contest 4 not to end the streak", segunda)
+	}
+	if segunda.ValorInicial != 100 || segunda.Crescimento != 800 {
+		t.Errorf("Sequencias[1] = %+v, want growth of 800 from the previous contest's 100", segunda)
+	}
+	if len(segunda.ConcursosEspeciais) != 1 || segunda.ConcursosEspeciais[0] != 4 {
+		t.Errorf("ConcursosEspeciais = %v, want [4]", segunda.ConcursosEspeciais)
+	}
+}
+
+func TestCalcularProbabilidades(t *testing.T) {
+	tests := []struct {
+		name    string
+		loteria model.Loteria
+		numeros int
+		faixa   int
+		casos   string
+		total   string
+		umEm    string
+	}{
+		{"Sena com aposta simples", model.MegaSena, 0, 1, "1", "50063860", "50063860.00"},
+		{"Quina com aposta simples", model.MegaSena, 6, 2, "324", "50063860", "154518.09"},
+		{"Quadra com aposta simples", model.MegaSena, 6, 3, "21465", "50063860", "2332.35"},
+		{"Sena com sete números", model.MegaSena, 7, 1, "7", "50063860", "7151980.00"},
+		{"Total acima de 64 bits", model.Lotomania, 50, 1, "47129212243960", "535983370403809682970", "11372635.89"},
+		{"Acertos com trevos", model.MaisMilionaria, 6, 1, "1", "238360500", "238360500.00"},
+		{"Colunas", model.SuperSete, 7, 1, "1", "10000000", "10000000.00"},
+		{"Time do Coração", model.Timemania, 10, 6, "1", "80", "80.00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			regras, _ := tt.loteria.Regras()
+			probabilidades, err := service.CalcularProbabilidades(regras, tt.numeros, 0)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			for _, f := range probabilidades.Faixas {
+				if f.Faixa != tt.faixa {
+					continue
+				}
+				if f.Casos != tt.casos || f.Total != tt.total || f.UmEm != tt.umEm {
+					t.Errorf("faixa %d = %s/%s (1 em %s), want %s/%s (1 em %s)", f.Faixa, f.Casos, f.Total, f.UmEm, tt.casos, tt.total, tt.umEm)
+				}
+				return
+			}
+			t.Errorf("faixa %d não encontrada", tt.faixa)
+		})
+	}
+}
+
+func TestCalcularProbabilidadesPremiosEsperados(t *testing.T) {
+	regras, _ := model.MegaSena.Regras()
+	probabilidades, err := service.CalcularProbabilidades(regras, 7, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if probabilidades.ApostasSimples != 7 || probabilidades.Preco != 42 {
+		t.Errorf("ApostasSimples = %d, Preco = %v, want 7 e 42", probabilidades.ApostasSimples, probabilidades.Preco)
+	}
+	// Com sete números cada sorteio premia a quadra tantas vezes quanto as
+	// sete apostas simples somadas: 7 vezes a chance da aposta simples.
+	quadra := probabilidades.Faixas[2]
+	esperado := 7 * 21465.0 / 50063860
+	if diff := quadra.PremiosEsperados - esperado; diff > 1e-12 || diff < -1e-12 {
+		t.Errorf("PremiosEsperados = %v, want %v", quadra.PremiosEsperados, esperado)
+	}
+}
+
+func TestCalcularProbabilidadesInvalidas(t *testing.T) {
+	megaSena, _ := model.MegaSena.Regras()
+	maisMilionaria, _ := model.MaisMilionaria.Regras()
+
+	tests := []struct {
+		name    string
+		regras  model.Regras
+		numeros int
+		trevos  int
+	}{
+		{"Números abaixo do mínimo", megaSena, 5, 0},
+		{"Números acima do máximo", megaSena, 21, 0},
+		{"Trevos em loteria sem trevos", megaSena, 6, 2},
+		{"Trevos acima do máximo", maisMilionaria, 6, 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := service.CalcularProbabilidades(tt.regras, tt.numeros, tt.trevos); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestAplicarPremios(t *testing.T) {
+	probabilidades := &model.Probabilidades{
+		Preco: 5,
+		Faixas: []model.ProbabilidadeFaixa{
+			{Faixa: 1, PremiosEsperados: 0.000001},
+			{Faixa: 2, PremiosEsperados: 0.01},
+			{Faixa: 3, PremiosEsperados: 0.1},
+		},
+	}
+	medios := []model.PremioMedio{{Faixa: 1, Valor: 500000}, {Faixa: 2, Valor: 100}}
+
+	service.AplicarPremios(probabilidades, 1000000, medios)
+
+	if f := probabilidades.Faixas[0]; f.Premio != 1000000 || f.FontePremio != model.FontePremioEstimativa || f.ValorEsperado != 1 {
+		t.Errorf("faixa 1 = %+v, want estimativa de 1000000 e valor esperado 1", f)
+	}
+	if f := probabilidades.Faixas[1]; f.Premio != 100 || f.FontePremio != model.FontePremioMedia || f.ValorEsperado != 1 {
+		t.Errorf("faixa 2 = %+v, want média de 100 e valor esperado 1", f)
+	}
+	if f := probabilidades.Faixas[2]; f.FontePremio != "" || f.ValorEsperado != 0 {
+		t.Errorf("faixa 3 = %+v, want sem prêmio", f)
+	}
+	if probabilidades.ValorEsperado != 2 || probabilidades.Retorno != 40 {
+		t.Errorf("ValorEsperado = %v, Retorno = %v, want 2 e 40", probabilidades.ValorEsperado, probabilidades.Retorno)
+	}
+}
+
+func TestMontarDistribuicaoComposicao(t *testing.T) {
+	regras, _ := model.MegaSena.Regras()
+	contagem := &model.ContagemComposicao{
+		Concursos: 4,
+		Soma:      []model.ContagemValor{{Valor: 181, Quantidade: 3}, {Valor: 135, Quantidade: 1}},
+		Pares:     []model.ContagemValor{{Valor: 3, Quantidade: 4}},
+		Decadas: []model.ContagemValor{
+			{Grupo: 0, Valor: 3, Quantidade: 1},
+			{Grupo: 0, Valor: 1, Quantidade: 3},
+			{Grupo: 5, Valor: 1, Quantidade: 4},
+		},
+		Colunas: []model.ContagemValor{{Grupo: 0, Valor: 0, Quantidade: 4}},
+	}
+
+	distribuicao := service.MontarDistribuicaoComposicao(regras, contagem, 50)
+
+	if distribuicao.Sorteios != 4 || distribuicao.SomaMedia != 169.5 || distribuicao.SomaEsperada != 183 {
+		t.Errorf("sorteios = %d, média = %v, esperada = %v, want 4, 169.5 e 183",
+			distribuicao.Sorteios, distribuicao.SomaMedia, distribuicao.SomaEsperada)
+	}
+
+	soma := distribuicao.Soma
+	if len(soma) != 2 || soma[0] != (model.IntervaloSoma{Inicio: 100, Fim: 149, Sorteios: 1, Percentual: 25}) ||
+		soma[1] != (model.IntervaloSoma{Inicio: 150, Fim: 199, Sorteios: 3, Percentual: 75}) {
+		t.Errorf("Soma = %+v, want 100-149 com 25%% e 150-199 com 75%%", soma)
+	}
+	if len(distribuicao.Pares) != 1 || distribuicao.Pares[0] != (model.Histograma{Valor: 3, Sorteios: 4, Percentual: 100}) {
+		t.Errorf("Pares = %+v, want 3 pares em 100%%", distribuicao.Pares)
+	}
+
+	decadas := distribuicao.Decadas
+	if len(decadas) != 6 || decadas[0].Grupo != "01-10" || decadas[5].Grupo != "51-60" {
+		t.Fatalf("Decadas = %+v, want 6 décadas de 01-10 a 51-60", decadas)
+	}
+	if h := decadas[0].Histograma; len(h) != 2 || h[0] != (model.Histograma{Valor: 1, Sorteios: 3, Percentual: 75}) ||
+		h[1] != (model.Histograma{Valor: 3, Sorteios: 1, Percentual: 25}) {
+		t.Errorf("década 01-10 = %+v, want 1 número em 75%% e 3 em 25%%", h)
+	}
+	if len(decadas[1].Histograma) != 0 {
+		t.Errorf("década 11-20 = %+v, want vazia", decadas[1].Histograma)
+	}
+	if distribuicao.Linhas != nil {
+		t.Errorf("Linhas = %+v, want nil sem contagens", distribuicao.Linhas)
+	}
+	if len(distribuicao.Colunas) != 10 || distribuicao.Colunas[0].Grupo != "01/11/21/31/41/51" {
+		t.Errorf("Colunas = %+v, want 10 colunas começando por 01/11/21/31/41/51", distribuicao.Colunas)
+	}
+}
+
+func TestMontarFrequencia(t *testing.T) {
+	t.Run("Dezenas com e sem zero à esquerda", func(t *testing.T) {
+		regras, _ := model.GetRegras("megasena")
+		contagem := &model.ContagemFrequencia{
+			Concursos: 10,
+			Dezenas: []model.ContagemNumero{
+				{Numero: "05", Quantidade: 3},
+				{Numero: " 5", Quantidade: 1},
+				{Numero: "60", Quantidade: 2},
+				{Numero: "x", Quantidade: 9},
+			},
+		}
+
+		frequencia := service.MontarFrequencia(regras, contagem)
+		if frequencia.Loteria != "megasena" || frequencia.Concursos != 10 {
+			t.Errorf("Frequencia = %+v", frequencia)
+		}
+		if len(frequencia.Dezenas) != 60 {
+			t.Fatalf("len(Dezenas) = %d, want every number of the board", len(frequencia.Dezenas))
+		}
+		esperado := []model.FrequenciaNumero{
+			{Numero: "05", Frequencia: 4, Percentual: 40},
+			{Numero: "60", Frequencia: 2, Percentual: 20},
+			{Numero: "01", Frequencia: 0, Percentual: 0},
+		}
+		for i, e := range esperado {
+			if frequencia.Dezenas[i] != e {
+				t.Errorf("Dezenas[%d] = %+v, want %+v", i, frequencia.Dezenas[i], e)
+			}
+		}
+		if ultima := frequencia.Dezenas[59]; ultima.Numero != "59" {
+			t.Errorf("Dezenas[59] = %+v, want ties kept in board order", ultima)
+		}
+		if frequencia.Trevos != nil || frequencia.Colunas != nil {
+			t.Errorf("Trevos = %v, Colunas = %v, want none", frequencia.Trevos, frequencia.Colunas)
+		}
+	})
+
+	t.Run("Colunas da Super Sete", func(t *testing.T) {
+		regras, _ := model.GetRegras("supersete")
+		contagem := &model.ContagemFrequencia{
+			Concursos: 4,
+			Dezenas: []model.ContagemNumero{
+				{Numero: "7", Posicao: 0, Quantidade: 3},
+				{Numero: "7", Posicao: 1, Quantidade: 1},
+				{Numero: "2", Posicao: 1, Quantidade: 2},
+			},
+		}
+
+		frequencia := service.MontarFrequencia(regras, contagem)
+		if len(frequencia.Colunas) != regras.Colunas.Quantidade || frequencia.Dezenas != nil {
+			t.Fatalf("Colunas = %d, Dezenas = %v, want one list per column", len(frequencia.Colunas), frequencia.Dezenas)
+		}
+		if c := frequencia.Colunas[0][0]; c.Numero != "7" || c.Frequencia != 3 || c.Percentual != 75 {
+			t.Errorf("Colunas[0][0] = %+v, want 7 drawn 3 times", c)
+		}
+		if c := frequencia.Colunas[1][0]; c.Numero != "2" || c.Frequencia != 2 {
+			t.Errorf("Colunas[1][0] = %+v, want 2 drawn 2 times", c)
+		}
+		if n := len(frequencia.Colunas[2]); n != 10 || frequencia.Colunas[2][0].Numero != "0" {
+			t.Errorf("Colunas[2] = %+v, want numbers 0 to 9 without padding", frequencia.Colunas[2])
+		}
+	})
+
+	t.Run("Trevos da +Milionária", func(t *testing.T) {
+		regras, _ := model.GetRegras("maismilionaria")
+		contagem := &model.ContagemFrequencia{
+			Concursos: 5,
+			Trevos:    []model.ContagemNumero{{Numero: "3", Quantidade: 3}},
+		}
+
+		frequencia := service.MontarFrequencia(regras, contagem)
+		if len(frequencia.Trevos) != regras.Trevos.MaiorNumero-regras.Trevos.MenorNumero+1 {
+			t.Fatalf("Trevos = %+v", frequencia.Trevos)
+		}
+		if trevo := frequencia.Trevos[0]; trevo.Numero != "3" || trevo.Frequencia != 3 || trevo.Percentual != 60 {
+			t.Errorf("Trevos[0] = %+v, want 3 drawn in 60%% of contests", trevo)
+		}
+	})
+}
+
+func TestMontarCoocorrencia(t *testing.T) {
+	quina, _ := model.GetRegras("quina")
+	concursos := []model.DezenasConcurso{
+		{Concurso: 10, Dezenas: []string{"01", "02", "03", "04", "05"}},
+		{Concurso: 11, Dezenas: []string{"07", "06", "03", "02", "01"}},
+		{Concurso: 12, Dezenas: []string{"01", "02", "08", "09", "10"}},
+	}
+
+	combinacoes := func(sorteio model.CoocorrenciaSorteio) []string {
+		var lista []string
+		for _, c := range sorteio.Combinacoes {
+			lista = append(lista, fmt.Sprintf("%s=%d", strings.Join(c.Numeros, "-"), c.Ocorrencias))
+		}
+		return lista
+	}
+
+	t.Run("Pares", func(t *testing.T) {
+		coocorrencia := service.MontarCoocorrencia(quina, concursos, 2, 3)
+		if coocorrencia.Concursos != 3 || coocorrencia.ConcursoInicio != 10 || coocorrencia.ConcursoFim != 12 {
+			t.Errorf("Coocorrencia = %+v", coocorrencia)
+		}
+		if len(coocorrencia.Sorteios) != 1 {
+			t.Fatalf("Sorteios = %+v, want 1", coocorrencia.Sorteios)
+		}
+		esperado := []string{"01-02=3", "01-03=2", "02-03=2"}
+		if got := combinacoes(coocorrencia.Sorteios[0]); !slices.Equal(got, esperado) {
+			t.Errorf("Combinacoes = %v, want %v", got, esperado)
+		}
+		if razao := coocorrencia.Sorteios[0].Combinacoes[0].Razao; razao <= 1 {
+			t.Errorf("Razao = %v, want above the expected count", razao)
+		}
+	})
+
+	t.Run("Trios", func(t *testing.T) {
+		coocorrencia := service.MontarCoocorrencia(quina, concursos, 3, 2)
+		esperado := []string{"01-02-03=2", "01-02-04=1"}
+		if got := combinacoes(coocorrencia.Sorteios[0]); !slices.Equal(got, esperado) {
+			t.Errorf("Combinacoes = %v, want %v", got, esperado)
+		}
+	})
+
+	t.Run("Sorteios da Dupla Sena", func(t *testing.T) {
+		dupla, _ := model.GetRegras("duplasena")
+		concursos := []model.DezenasConcurso{
+			{Concurso: 1, Dezenas: []string{"01", "02", "03", "04", "05", "06", "44", "45", "46", "47", "48", "50"}},
+		}
+		coocorrencia := service.MontarCoocorrencia(dupla, concursos, 2, 1)
+		if len(coocorrencia.Sorteios) != 2 {
+			t.Fatalf("Sorteios = %+v, want 2", coocorrencia.Sorteios)
+		}
+		if got := combinacoes(coocorrencia.Sorteios[1]); !slices.Equal(got, []string{"44-45=1"}) {
+			t.Errorf("Sorteios[1] = %v, want pairs of the second draw only", got)
+		}
+	})
+
+	t.Run("Trios da Lotomania", func(t *testing.T) {
+		lotomania, _ := model.GetRegras("lotomania")
+		dezenas := make([]string, 0, lotomania.NumerosSorteados)
+		for n := 0; n < lotomania.NumerosSorteados; n++ {
+			dezenas = append(dezenas, fmt.Sprintf("%02d", n*5))
+		}
+		coocorrencia := service.MontarCoocorrencia(lotomania, []model.DezenasConcurso{{Concurso: 1, Dezenas: dezenas}}, 3, 500)
+		if n := len(coocorrencia.Sorteios[0].Combinacoes); n != 500 {
+			t.Errorf("len(Combinacoes) = %d, want 500", n)
+		}
+	})
+}
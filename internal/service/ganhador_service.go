@@ -0,0 +1,348 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"loterias-api-golang/internal/model"
+	"loterias-api-golang/internal/repository"
+)
+
+const (
+	limitePadraoGanhadores = 50
+	limiteMaximoGanhadores = 500
+
+	limitePadraoPremios = 20
+	primeiroAnoPremios  = 1994
+)
+
+type GanhadorService struct {
+	repository *repository.ResultadoRepository
+}
+
+func NewGanhadorService(repository *repository.ResultadoRepository) *GanhadorService {
+	return &GanhadorService{
+		repository: repository,
+	}
+}
+
+// ListarConcursos retorna os concursos com ganhadores na UF ou no município
+// informados, do mais recente para o mais antigo.
+func (s *GanhadorService) ListarConcursos(loteria string, filtro model.FiltroConcursos, ganhadores model.FiltroGanhadores) (*model.ConcursosComGanhadores, error) {
+	regras, ok := model.GetRegras(loteria)
+	if !ok {
+		return nil, &model.LoteriaInvalidException{Message: fmt.Sprintf("'%s' não é uma loteria suportada", loteria)}
+	}
+
+	ganhadores.UF = model.NormalizarLocal(ganhadores.UF)
+	ganhadores.Municipio = model.NormalizarLocal(ganhadores.Municipio)
+	if ganhadores.UF == "" && ganhadores.Municipio == "" {
+		return nil, &model.ParametroInvalidoException{Message: "Informe a UF ou o município dos ganhadores"}
+	}
+	if err := validarFaixaGanhadores(regras, ganhadores.Faixa); err != nil {
+		return nil, err
+	}
+	if ganhadores.Limite == 0 {
+		ganhadores.Limite = limitePadraoGanhadores
+	}
+	if ganhadores.Limite < 1 || ganhadores.Limite > limiteMaximoGanhadores {
+		return nil, &model.ParametroInvalidoException{
+			Message: fmt.Sprintf("O limite deve estar entre 1 e %d", limiteMaximoGanhadores),
+		}
+	}
+
+	lista := &model.ConcursosComGanhadores{
+		Loteria:   loteria,
+		UF:        ganhadores.UF,
+		Municipio: ganhadores.Municipio,
+		Concursos: []model.ConcursoComGanhadores{},
+	}
+	if !faixaComLocais(loteria, ganhadores.Faixa) {
+		return lista, nil
+	}
+
+	resultados, total, err := s.repository.ListarGanhadores(loteria, filtro, ganhadores)
+	if err != nil {
+		return nil, err
+	}
+
+	lista.Total = total
+	for _, resultado := range resultados {
+		lista.Concursos = append(lista.Concursos, GanhadoresNoLocal(resultado, ganhadores))
+	}
+
+	return lista, nil
+}
+
+// GanhadoresNoLocal separa os ganhadores do concurso que atendem ao filtro,
+// com o valor pago pela faixa de cada um. Fora da Federal todos os locais
+// informados são de ganhadores da faixa principal.
+func GanhadoresNoLocal(resultado model.Resultado, ganhadores model.FiltroGanhadores) model.ConcursoComGanhadores {
+	concurso := model.ConcursoComGanhadores{
+		Concurso:   resultado.ID.Concurso,
+		Data:       resultado.Data,
+		Ganhadores: []model.GanhadorLocal{},
+	}
+
+	valores := make(map[int]float64, len(resultado.Premiacoes))
+	for _, p := range resultado.Premiacoes {
+		valores[p.Faixa] = p.Valor
+	}
+
+	for _, local := range resultado.LocalGanhadores {
+		uf := model.NormalizarLocal(local.UF)
+		municipio := model.NormalizarLocal(local.Municipio)
+		if ganhadores.UF != "" && uf != ganhadores.UF {
+			continue
+		}
+		if ganhadores.Municipio != "" && municipio != ganhadores.Municipio {
+			continue
+		}
+		faixa := local.FaixaDoGanhador(resultado.ID.Loteria)
+		if ganhadores.Faixa > 0 && faixa != ganhadores.Faixa {
+			continue
+		}
+		quantidade := local.Ganhadores
+		if quantidade < 1 {
+			quantidade = 1
+		}
+		concurso.Ganhadores = append(concurso.Ganhadores, model.GanhadorLocal{
+			Faixa:           faixa,
+			Municipio:       local.Municipio,
+			UF:              local.UF,
+			Ganhadores:      quantidade,
+			ValorUnitario:   valores[faixa],
+			CanalEletronico: local.CanalEletronico(),
+			Estabelecimento: local.Estabelecimento,
+		})
+	}
+
+	return concurso
+}
+
+// Ranking soma ganhadores e prêmios pagos por estado ou por município, do
+// local mais premiado para o menos premiado.
+func (s *GanhadorService) Ranking(loteria string, filtro model.FiltroConcursos, agrupamento string, ganhadores model.FiltroGanhadores, ordenar string, top int) (*model.RankingGanhadores, error) {
+	regras, ok := model.GetRegras(loteria)
+	if !ok {
+		return nil, &model.LoteriaInvalidException{Message: fmt.Sprintf("'%s' não é uma loteria suportada", loteria)}
+	}
+	if err := validarFaixaGanhadores(regras, ganhadores.Faixa); err != nil {
+		return nil, err
+	}
+	if ordenar == "" {
+		ordenar = model.OrdenarPorGanhadores
+	}
+	if ordenar != model.OrdenarPorGanhadores && ordenar != model.OrdenarPorValor {
+		return nil, &model.ParametroInvalidoException{Message: "O parâmetro 'ordenar' deve ser 'ganhadores' ou 'valor'"}
+	}
+	if top < 0 {
+		return nil, &model.ParametroInvalidoException{Message: "O parâmetro 'top' deve ser positivo"}
+	}
+
+	ganhadores.UF = model.NormalizarLocal(ganhadores.UF)
+	agregados := []model.AgregadoGanhadores{}
+	if faixaComLocais(loteria, ganhadores.Faixa) {
+		var err error
+		agregados, err = s.repository.AgregarGanhadores(loteria, filtro, agrupamento == model.AgrupamentoMunicipio, model.FiltroGanhadores{UF: ganhadores.UF, Faixa: ganhadores.Faixa})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ranking := MontarRanking(agrupamento, agregados, ordenar, top)
+	ranking.Loteria = loteria
+	ranking.Faixa = ganhadores.Faixa
+	return ranking, nil
+}
+
+// faixaComLocais indica se a faixa pode ter locais de ganhadores. A Caixa só
+// informa o local dos ganhadores da faixa principal, exceto na Federal.
+func faixaComLocais(loteria string, faixa int) bool {
+	return faixa <= 1 || loteria == string(model.Federal)
+}
+
+// MontarRanking junta os agregados do mesmo local, já que a Caixa nem sempre
+// grafa os nomes da mesma forma, e os ordena pelo critério informado. Um
+// concurso premiado em grafias diferentes do local conta uma vez só. Apostas
+// pela internet e locais sem UF válida entram só nos totais. Com top > 0
+// apenas os primeiros locais são retornados.
+func MontarRanking(agrupamento string, agregados []model.AgregadoGanhadores, ordenar string, top int) *model.RankingGanhadores {
+	ranking := &model.RankingGanhadores{Agrupamento: agrupamento, Locais: []model.LocalPremiado{}}
+
+	indices := make(map[string]int)
+	concursos := make(map[string]map[int]bool)
+	for _, a := range agregados {
+		ranking.Total.Ganhadores += a.Ganhadores
+		ranking.Total.Valor += a.Valor
+		ranking.CanalEletronico.Ganhadores += a.GanhadoresCanal
+		ranking.CanalEletronico.Valor += a.ValorCanal
+
+		uf := model.NormalizarLocal(a.UF)
+		if !ufValida(uf) {
+			continue
+		}
+		municipio := ""
+		if agrupamento == model.AgrupamentoMunicipio {
+			municipio = model.NormalizarLocal(a.Municipio)
+			if municipio == "" || a.Ganhadores == a.GanhadoresCanal {
+				continue
+			}
+		}
+
+		chave := uf + "|" + municipio
+		i, existe := indices[chave]
+		if !existe {
+			i = len(ranking.Locais)
+			indices[chave] = i
+			concursos[chave] = make(map[int]bool)
+			ranking.Locais = append(ranking.Locais, model.LocalPremiado{UF: uf, Municipio: municipio})
+		}
+		local := &ranking.Locais[i]
+		local.Ganhadores += a.Ganhadores
+		local.Valor += a.Valor
+		for _, c := range a.Concursos {
+			if !concursos[chave][c] {
+				concursos[chave][c] = true
+				local.Concursos++
+			}
+		}
+		local.CanalEletronico.Ganhadores += a.GanhadoresCanal
+		local.CanalEletronico.Valor += a.ValorCanal
+	}
+
+	ranking.Lotericas = descontarCanal(ranking.Total, ranking.CanalEletronico)
+	ranking.Total.Valor = arredondarCentavos(ranking.Total.Valor)
+	ranking.CanalEletronico.Valor = arredondarCentavos(ranking.CanalEletronico.Valor)
+
+	for i := range ranking.Locais {
+		local := &ranking.Locais[i]
+		local.Lotericas = descontarCanal(model.TotalGanhadores{Ganhadores: local.Ganhadores, Valor: local.Valor}, local.CanalEletronico)
+		local.Valor = arredondarCentavos(local.Valor)
+		local.CanalEletronico.Valor = arredondarCentavos(local.CanalEletronico.Valor)
+	}
+
+	sort.SliceStable(ranking.Locais, func(i, j int) bool {
+		a, b := ranking.Locais[i], ranking.Locais[j]
+		if ordenar == model.OrdenarPorValor && a.Valor != b.Valor {
+			return a.Valor > b.Valor
+		}
+		if a.Ganhadores != b.Ganhadores {
+			return a.Ganhadores > b.Ganhadores
+		}
+		if a.Valor != b.Valor {
+			return a.Valor > b.Valor
+		}
+		if a.UF != b.UF {
+			return a.UF < b.UF
+		}
+		return a.Municipio < b.Municipio
+	})
+
+	if top > 0 && len(ranking.Locais) > top {
+		ranking.Locais = ranking.Locais[:top]
+	}
+
+	return ranking
+}
+
+// descontarCanal desconta do total a parte paga pela internet, restando o
+// que saiu em lotéricas.
+func descontarCanal(total, canal model.TotalGanhadores) model.TotalGanhadores {
+	return model.TotalGanhadores{
+		Ganhadores: total.Ganhadores - canal.Ganhadores,
+		Valor:      arredondarCentavos(total.Valor - canal.Valor),
+	}
+}
+
+// ufValida indica se a sigla tem o formato de uma UF. A Caixa usa "--" e
+// "XX" quando o local do ganhador não é conhecido.
+func ufValida(uf string) bool {
+	if len(uf) != 2 || uf == "XX" {
+		return false
+	}
+	for _, c := range uf {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// RankingPremios retorna os maiores prêmios pagos por aposta vencedora em
+// todas as loterias ou em uma delas, do maior para o menor.
+func (s *GanhadorService) RankingPremios(filtro model.FiltroPremios) (*model.RankingPremios, error) {
+	if filtro.Loteria != "" {
+		regras, ok := model.GetRegras(filtro.Loteria)
+		if !ok {
+			return nil, &model.LoteriaInvalidException{Message: fmt.Sprintf("'%s' não é uma loteria suportada", filtro.Loteria)}
+		}
+		if err := validarFaixaGanhadores(regras, filtro.Faixa); err != nil {
+			return nil, err
+		}
+	} else if filtro.Faixa < 0 {
+		return nil, &model.ParametroInvalidoException{Message: "A faixa deve ser um número positivo"}
+	}
+	if anoAtual := time.Now().In(model.FusoBrasilia).Year(); filtro.Ano != 0 && (filtro.Ano < primeiroAnoPremios || filtro.Ano > anoAtual) {
+		return nil, &model.ParametroInvalidoException{
+			Message: fmt.Sprintf("O ano deve estar entre %d e %d", primeiroAnoPremios, anoAtual),
+		}
+	}
+	if filtro.Limite == 0 {
+		filtro.Limite = limitePadraoPremios
+	}
+	if filtro.Limite < 1 || filtro.Limite > limiteMaximoGanhadores {
+		return nil, &model.ParametroInvalidoException{
+			Message: fmt.Sprintf("O parâmetro 'top' deve estar entre 1 e %d", limiteMaximoGanhadores),
+		}
+	}
+
+	premios, err := s.repository.MaioresPremios(filtro)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.RankingPremios{
+		Loteria: filtro.Loteria,
+		Faixa:   filtro.Faixa,
+		Ano:     filtro.Ano,
+		Premios: MontarPremiosPagos(premios),
+	}, nil
+}
+
+// MontarPremiosPagos arredonda os valores dos prêmios retornados pela
+// agregação e completa os locais com o valor pago a cada ganhador.
+func MontarPremiosPagos(premios []model.PremioPago) []model.PremioPago {
+	for i := range premios {
+		premio := &premios[i]
+		premio.ValorPorGanhador = arredondarCentavos(premio.ValorPorGanhador)
+		premio.ValorTotal = arredondarCentavos(premio.ValorTotal)
+		if premio.Locais == nil {
+			premio.Locais = []model.GanhadorLocal{}
+		}
+		for j := range premio.Locais {
+			local := &premio.Locais[j]
+			if local.Ganhadores < 1 {
+				local.Ganhadores = 1
+			}
+			local.ValorUnitario = premio.ValorPorGanhador
+			local.CanalEletronico = model.MunicipioUFGanhadores{Municipio: local.Municipio}.CanalEletronico()
+		}
+	}
+	return premios
+}
+
+func validarFaixaGanhadores(regras model.Regras, faixa int) error {
+	if faixa == 0 {
+		return nil
+	}
+	for _, f := range regras.Faixas {
+		if f.Faixa == faixa {
+			return nil
+		}
+	}
+	return &model.ParametroInvalidoException{
+		Message: fmt.Sprintf("A faixa %d não existe na loteria %s", faixa, regras.Loteria),
+	}
+}
@@ -0,0 +1,173 @@
+package service_test
+
+import (
+	"testing"
+
+	"loterias-api-golang/internal/model"
+	"loterias-api-golang/internal/service"
+)
+
+func TestMontarRanking(t *testing.T) {
+	agregados := []model.AgregadoGanhadores{
+		{UF: "SP", Municipio: "SÃO PAULO", Ganhadores: 3, Valor: 300, Concursos: []int{10, 11, 12}},
+		{UF: "SP", Municipio: "SAO PAULO", Ganhadores: 1, Valor: 100, Concursos: []int{11}},
+		{UF: "SP", Municipio: "CANAL ELETRONICO", Ganhadores: 2, Valor: 500, GanhadoresCanal: 2, ValorCanal: 500, Concursos: []int{10, 13}},
+		{UF: "PR", Municipio: "CURITIBA", Ganhadores: 2, Valor: 1000.005, Concursos: []int{12, 13}},
+		{UF: "--", Municipio: "", Ganhadores: 1, Valor: 50, Concursos: []int{14}},
+	}
+
+	ranking := service.MontarRanking(model.AgrupamentoMunicipio, agregados, model.OrdenarPorGanhadores, 0)
+	if ranking.Total.Ganhadores != 9 || ranking.Total.Valor != 1950.01 {
+		t.Errorf("Total = %+v, want 9 ganhadores and 1950.01", ranking.Total)
+	}
+	if ranking.CanalEletronico.Ganhadores != 2 || ranking.Lotericas.Ganhadores != 7 {
+		t.Errorf("CanalEletronico = %+v, Lotericas = %+v, want 2 and 7 ganhadores", ranking.CanalEletronico, ranking.Lotericas)
+	}
+	if len(ranking.Locais) != 2 {
+		t.Fatalf("Locais = %+v, want São Paulo and Curitiba only", ranking.Locais)
+	}
+	if ranking.Locais[0].Municipio != "SAO PAULO" || ranking.Locais[0].Ganhadores != 4 || ranking.Locais[0].Valor != 400 {
+		t.Errorf("Locais[0] = %+v, want SAO PAULO with 4 ganhadores and 400", ranking.Locais[0])
+	}
+	if ranking.Locais[0].Concursos != 3 {
+		t.Errorf("Locais[0].Concursos = %d, want 3 (concurso 11 appears in both spellings)", ranking.Locais[0].Concursos)
+	}
+
+	ranking = service.MontarRanking(model.AgrupamentoMunicipio, agregados, model.OrdenarPorValor, 1)
+	if len(ranking.Locais) != 1 || ranking.Locais[0].Municipio != "CURITIBA" {
+		t.Errorf("Locais = %+v, want CURITIBA first by valor", ranking.Locais)
+	}
+
+	ranking = service.MontarRanking(model.AgrupamentoEstado, []model.AgregadoGanhadores{
+		{UF: "SP", Ganhadores: 6, Valor: 900, GanhadoresCanal: 2, ValorCanal: 500, Concursos: []int{1, 2, 3, 4, 5}},
+		{UF: "pr", Ganhadores: 2, Valor: 1000, Concursos: []int{1, 2}},
+		{UF: "PR", Ganhadores: 1, Valor: 500, Concursos: []int{2, 3}},
+	}, model.OrdenarPorGanhadores, 0)
+	if len(ranking.Locais) != 2 || ranking.Locais[0].UF != "SP" || ranking.Locais[1].UF != "PR" {
+		t.Fatalf("Locais = %+v, want SP and PR", ranking.Locais)
+	}
+	if ranking.Locais[0].Lotericas.Ganhadores != 4 || ranking.Locais[0].Lotericas.Valor != 400 {
+		t.Errorf("Lotericas = %+v, want 4 ganhadores and 400", ranking.Locais[0].Lotericas)
+	}
+	if ranking.Locais[0].Concursos != 5 || ranking.Locais[1].Concursos != 3 {
+		t.Errorf("Concursos = %d and %d, want 5 and 3", ranking.Locais[0].Concursos, ranking.Locais[1].Concursos)
+	}
+}
+
+func TestGanhadoresNoLocal(t *testing.T) {
+	resultado := model.Resultado{
+		ID:   model.ResultadoID{Loteria: "megasena", Concurso: 2700},
+		Data: "01/02/2024",
+		Premiacoes: []model.Premiacao{
+			{Faixa: 1, Valor: 50000000},
+			{Faixa: 2, Valor: 40000},
+			{Faixa: 3, Valor: 1000},
+		},
+		LocalGanhadores: []model.MunicipioUFGanhadores{
+			{Posicao: 1, Municipio: "São Paulo", UF: "SP", Ganhadores: 1},
+			{Posicao: 2, Municipio: "SAO PAULO", UF: "SP", Ganhadores: 2},
+			{Posicao: 3, Municipio: "CANAL ELETRONICO", UF: "SP"},
+			{Posicao: 4, Municipio: "Curitiba", UF: "PR", Ganhadores: 1},
+		},
+	}
+
+	concurso := service.GanhadoresNoLocal(resultado, model.FiltroGanhadores{UF: "SP"})
+	if concurso.Concurso != 2700 || len(concurso.Ganhadores) != 3 {
+		t.Fatalf("Ganhadores = %+v, want the 3 jackpot entries from SP", concurso.Ganhadores)
+	}
+	for i, g := range concurso.Ganhadores {
+		if g.Faixa != 1 || g.ValorUnitario != 50000000 {
+			t.Errorf("Ganhadores[%d] = %+v, want faixa 1 valued at 50000000", i, g)
+		}
+	}
+	if concurso.Ganhadores[1].Ganhadores != 2 || concurso.Ganhadores[2].Ganhadores != 1 || !concurso.Ganhadores[2].CanalEletronico {
+		t.Errorf("Ganhadores = %+v, want 2 winners in the second entry and 1 online winner in the third", concurso.Ganhadores)
+	}
+
+	concurso = service.GanhadoresNoLocal(resultado, model.FiltroGanhadores{Municipio: "SAO PAULO", Faixa: 1})
+	if len(concurso.Ganhadores) != 2 {
+		t.Errorf("Ganhadores = %+v, want both São Paulo spellings", concurso.Ganhadores)
+	}
+	concurso = service.GanhadoresNoLocal(resultado, model.FiltroGanhadores{UF: "SP", Faixa: 2})
+	if len(concurso.Ganhadores) != 0 {
+		t.Errorf("Ganhadores = %+v, want none for faixa 2", concurso.Ganhadores)
+	}
+
+	federal := model.Resultado{
+		ID:         model.ResultadoID{Loteria: "federal", Concurso: 5800},
+		Premiacoes: []model.Premiacao{{Faixa: 1, Valor: 500000}, {Faixa: 2, Valor: 27000}},
+		LocalGanhadores: []model.MunicipioUFGanhadores{
+			{Posicao: 1, Municipio: "RECIFE", UF: "PE"},
+			{Posicao: 2, Municipio: "OLINDA", UF: "PE"},
+		},
+	}
+	concurso = service.GanhadoresNoLocal(federal, model.FiltroGanhadores{UF: "PE", Faixa: 2})
+	if len(concurso.Ganhadores) != 1 || concurso.Ganhadores[0].Municipio != "OLINDA" || concurso.Ganhadores[0].ValorUnitario != 27000 {
+		t.Errorf("Ganhadores = %+v, want the 2nd prize ticket from OLINDA", concurso.Ganhadores)
+	}
+}
+
+func TestMontarPremiosPagos(t *testing.T) {
+	premios := service.MontarPremiosPagos([]model.PremioPago{
+		{
+			Loteria: "megasena", Concurso: 2150, Faixa: 1, Ganhadores: 2,
+			ValorPorGanhador: 144688022.595, ValorTotal: 289376045.19,
+			Locais: []model.GanhadorLocal{
+				{Faixa: 1, Municipio: "CANAL ELETRONICO", UF: "--"},
+				{Faixa: 1, Municipio: "SALVADOR", UF: "BA", Ganhadores: 1},
+			},
+		},
+		{Loteria: "quina", Concurso: 5000, Faixa: 2, Ganhadores: 10, ValorPorGanhador: 5000, ValorTotal: 50000},
+	})
+
+	if premios[0].ValorPorGanhador != 144688022.6 || premios[0].ValorTotal != 289376045.19 {
+		t.Errorf("Premios[0] = %+v, want values rounded to centavos", premios[0])
+	}
+	for i, local := range premios[0].Locais {
+		if local.Ganhadores != 1 || local.ValorUnitario != 144688022.6 {
+			t.Errorf("Locais[%d] = %+v, want 1 ganhador paid 144688022.6", i, local)
+		}
+	}
+	if !premios[0].Locais[0].CanalEletronico || premios[0].Locais[1].CanalEletronico {
+		t.Errorf("Locais = %+v, want only the first one online", premios[0].Locais)
+	}
+	if premios[1].Locais == nil || len(premios[1].Locais) != 0 {
+		t.Errorf("Premios[1].Locais = %#v, want an empty list", premios[1].Locais)
+	}
+}
+
+func TestRankingPremiosValidacao(t *testing.T) {
+	ganhadorService := service.NewGanhadorService(nil)
+
+	testes := []struct {
+		nome   string
+		filtro model.FiltroPremios
+	}{
+		{"loteria inválida", model.FiltroPremios{Loteria: "loto"}},
+		{"faixa inexistente", model.FiltroPremios{Loteria: "megasena", Faixa: 4}},
+		{"faixa negativa", model.FiltroPremios{Faixa: -1}},
+		{"ano anterior ao real", model.FiltroPremios{Ano: 1990}},
+		{"limite acima do máximo", model.FiltroPremios{Limite: 501}},
+		{"limite negativo", model.FiltroPremios{Limite: -1}},
+	}
+	for _, tt := range testes {
+		if _, err := ganhadorService.RankingPremios(tt.filtro); err == nil {
+			t.Errorf("%s: RankingPremios(%+v) error = nil, want an error", tt.nome, tt.filtro)
+		}
+	}
+}
+
+func TestMaiorPremio(t *testing.T) {
+	resultado := model.Resultado{
+		ID: model.ResultadoID{Loteria: "megasena", Concurso: 2700},
+		Premiacoes: []model.Premiacao{
+			{Faixa: 1, NumeroDeGanhadores: 0, Valor: 90000000},
+			{Faixa: 2, NumeroDeGanhadores: 40, Valor: 52000.5},
+			{Faixa: 3, NumeroDeGanhadores: 3000, Valor: 1000},
+		},
+	}
+	resultado.BeforeSave()
+	if resultado.MaiorPremio != 52000.5 {
+		t.Errorf("MaiorPremio = %v, want 52000.5 (the accumulated faixa 1 has no winners)", resultado.MaiorPremio)
+	}
+}
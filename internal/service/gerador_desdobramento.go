@@ -0,0 +1,533 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"loterias-api-golang/internal/model"
+)
+
+const (
+	maxDezenasDesdobramento        = 60
+	limiteCombinacoesDesdobramento = 250000
+	maxApostasDesdobramento        = 2000
+	tempoPadraoDesdobramento       = 3
+	tempoMaximoDesdobramento       = 20
+
+	// candidatosPorPasso é quantas apostas a construção gulosa compara antes
+	// de escolher a que cobre mais combinações ainda descobertas.
+	candidatosPorPasso = 32
+	// resfriamento e temperaturaMinima controlam a chance de a busca local
+	// aceitar uma troca que descobre combinações.
+	resfriamento      = 0.999
+	temperaturaMinima = 0.2
+)
+
+// Desdobrar gera um desdobramento com as dezenas escolhidas e confere a
+// garantia pedida contra todas as combinações possíveis antes de responder.
+func (s *GeradorService) Desdobrar(loteria string, pedido model.PedidoDesdobramento) (*model.Desdobramento, error) {
+	regras, err := regrasNumericas(loteria)
+	if err != nil {
+		return nil, err
+	}
+	if regras.Tipo != model.TipoDezenas {
+		return nil, &model.ParametroInvalidoException{Message: "Desdobramento não disponível para a loteria " + loteria}
+	}
+
+	if err := validarEspeciais(regras, model.Aposta{TimeCoracao: pedido.TimeCoracao, MesSorte: pedido.MesSorte}); err != nil {
+		return nil, err
+	}
+
+	escolhidas, err := parseNumeros(pedido.Dezenas, regras.MenorNumero, regras.MaiorNumero, "dezena")
+	if err != nil {
+		return nil, err
+	}
+	sort.Ints(escolhidas)
+
+	if pedido.Numeros == 0 {
+		pedido.Numeros = regras.NumerosApostaSimples
+	}
+	if pedido.Numeros < regras.MinNumeros || pedido.Numeros > regras.MaxNumeros {
+		return nil, &model.ParametroInvalidoException{
+			Message: fmt.Sprintf("A aposta deve ter entre %d e %d números", regras.MinNumeros, regras.MaxNumeros),
+		}
+	}
+	if len(escolhidas) <= pedido.Numeros || len(escolhidas) > maxDezenasDesdobramento {
+		return nil, &model.ParametroInvalidoException{
+			Message: fmt.Sprintf("Escolha entre %d e %d dezenas para apostas de %d números", pedido.Numeros+1, maxDezenasDesdobramento, pedido.Numeros),
+		}
+	}
+
+	faixa, ok := faixaPorAcertos(regras, pedido.Garantia)
+	if !ok {
+		return nil, &model.ParametroInvalidoException{
+			Message: fmt.Sprintf("Nenhuma faixa da loteria %s premia %d acertos", loteria, pedido.Garantia),
+		}
+	}
+	if pedido.Condicao == 0 {
+		pedido.Condicao = regras.NumerosSorteados
+	}
+	if pedido.Condicao < pedido.Garantia || pedido.Condicao > regras.NumerosSorteados {
+		return nil, &model.ParametroInvalidoException{
+			Message: fmt.Sprintf("A condição deve estar entre %d e %d dezenas sorteadas", pedido.Garantia, regras.NumerosSorteados),
+		}
+	}
+	if combinacoes := model.Combinacoes(len(escolhidas), pedido.Condicao); combinacoes > limiteCombinacoesDesdobramento {
+		return nil, &model.ParametroInvalidoException{
+			Message: fmt.Sprintf("A condição gera %d combinações a cobrir; o limite é %d. Escolha menos dezenas ou uma condição menor", combinacoes, limiteCombinacoesDesdobramento),
+		}
+	}
+	if minimo := limiteInferiorDesdobramento(len(escolhidas), pedido.Numeros, pedido.Garantia, pedido.Condicao); minimo > maxApostasDesdobramento {
+		return nil, &model.ParametroInvalidoException{
+			Message: fmt.Sprintf("A garantia exige ao menos %d apostas; o limite é %d. Escolha menos dezenas ou uma garantia menor", minimo, maxApostasDesdobramento),
+		}
+	}
+
+	var trevos []int
+	if regras.Trevos != nil {
+		trevos, err = parseNumeros(pedido.Trevos, regras.Trevos.MenorNumero, regras.Trevos.MaiorNumero, "trevo")
+		if err != nil {
+			return nil, err
+		}
+		if len(trevos) < regras.Trevos.MinNumeros || len(trevos) > regras.Trevos.MaxNumeros {
+			return nil, &model.ParametroInvalidoException{
+				Message: fmt.Sprintf("Informe entre %d e %d trevos para as apostas", regras.Trevos.MinNumeros, regras.Trevos.MaxNumeros),
+			}
+		}
+		sort.Ints(trevos)
+	} else if len(pedido.Trevos) > 0 {
+		return nil, &model.ParametroInvalidoException{Message: "A loteria " + loteria + " não tem trevos"}
+	}
+
+	if pedido.TempoMaximo == 0 {
+		pedido.TempoMaximo = tempoPadraoDesdobramento
+	}
+	if pedido.TempoMaximo < 1 || pedido.TempoMaximo > tempoMaximoDesdobramento {
+		return nil, &model.ParametroInvalidoException{
+			Message: fmt.Sprintf("O tempo máximo deve estar entre 1 e %d segundos", tempoMaximoDesdobramento),
+		}
+	}
+
+	apostas := GerarDesdobramento(escolhidas, pedido.Numeros, pedido.Condicao, pedido.Garantia, time.Duration(pedido.TempoMaximo)*time.Second)
+	preco := float64(regras.ApostasSimples(pedido.Numeros, len(trevos))) * regras.PrecoApostaSimples
+
+	desdobramento := &model.Desdobramento{
+		Loteria:  loteria,
+		Garantia: pedido.Garantia,
+		Condicao: pedido.Condicao,
+		Faixa:    faixa.Descricao,
+		Apostas:  make([]model.ApostaGerada, 0, len(apostas)),
+		CombinacaoCompleta: model.CombinacaoCompleta{
+			Apostas: model.Combinacoes(len(escolhidas), pedido.Numeros),
+		},
+		Cobertura: VerificarDesdobramento(escolhidas, apostas, pedido.Condicao, pedido.Garantia),
+	}
+	desdobramento.CombinacaoCompleta.Valor = float64(desdobramento.CombinacaoCompleta.Apostas) * preco
+	for _, d := range escolhidas {
+		desdobramento.Dezenas = append(desdobramento.Dezenas, formatarDezena(d))
+	}
+
+	for _, dezenas := range apostas {
+		aposta := model.ApostaGerada{Preco: preco}
+		aposta.TimeCoracao = strings.TrimSpace(pedido.TimeCoracao)
+		if pedido.MesSorte != "" {
+			aposta.MesSorte = normalizarMes(pedido.MesSorte)
+		}
+		for _, d := range dezenas {
+			aposta.Dezenas = append(aposta.Dezenas, formatarDezena(d))
+		}
+		for _, t := range trevos {
+			aposta.Trevos = append(aposta.Trevos, strconv.Itoa(t))
+		}
+		desdobramento.Apostas = append(desdobramento.Apostas, aposta)
+		desdobramento.ValorTotal += preco
+	}
+
+	return desdobramento, nil
+}
+
+// faixaPorAcertos retorna a faixa do primeiro sorteio que premia a
+// quantidade de acertos informada, ignorando as faixas especiais.
+func faixaPorAcertos(regras model.Regras, acertos int) (model.Faixa, bool) {
+	for _, f := range regras.FaixasDoSorteio(1) {
+		if f.Especial == "" && acertos > 0 && f.Acertos == acertos {
+			return f, true
+		}
+	}
+	return model.Faixa{}, false
+}
+
+// GerarDesdobramento procura o menor conjunto de apostas de numeros dezenas,
+// tiradas das escolhidas, em que toda combinação de condicao dezenas
+// escolhidas tenha ao menos garantia acertos em alguma aposta. Uma construção
+// gulosa dá a primeira cobertura; depois, até o prazo, a busca remove uma
+// aposta e tenta recuperar a cobertura trocando dezenas (recozimento
+// simulado). As apostas retornadas sempre cumprem a garantia. Quando cada
+// aposta só cobre a si mesma, a resposta é a combinação completa.
+func GerarDesdobramento(escolhidas []int, numeros, condicao, garantia int, prazo time.Duration) [][]int {
+	if garantia == numeros && numeros == condicao {
+		return apostasDasMascaras(escolhidas, numeros, combinacoesBits(len(escolhidas), numeros))
+	}
+
+	limite := time.Now().Add(prazo)
+	busca := &buscaCobertura{
+		v:     len(escolhidas),
+		k:     numeros,
+		t:     garantia,
+		m:     condicao,
+		alvos: combinacoesBits(len(escolhidas), condicao),
+		rng:   rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+
+	melhor := busca.gulosa(limite)
+	minimo := limiteInferiorDesdobramento(len(escolhidas), numeros, garantia, condicao)
+	for int64(len(melhor)) > minimo && time.Now().Before(limite) {
+		tentativa := busca.removerMenosUtil(melhor)
+		if !busca.cobrir(tentativa, limite) {
+			break
+		}
+		melhor = tentativa
+	}
+
+	return apostasDasMascaras(escolhidas, numeros, melhor)
+}
+
+// apostasDasMascaras converte as máscaras em dezenas, sem repetições e em
+// ordem crescente.
+func apostasDasMascaras(escolhidas []int, numeros int, mascaras []uint64) [][]int {
+	apostas := make([][]int, 0, len(mascaras))
+	vistas := make(map[uint64]bool, len(mascaras))
+	for _, aposta := range mascaras {
+		if vistas[aposta] {
+			continue
+		}
+		vistas[aposta] = true
+		dezenas := make([]int, 0, numeros)
+		for i, d := range escolhidas {
+			if aposta&(1<<uint(i)) != 0 {
+				dezenas = append(dezenas, d)
+			}
+		}
+		apostas = append(apostas, dezenas)
+	}
+	sort.Slice(apostas, func(i, j int) bool {
+		for p := range apostas[i] {
+			if apostas[i][p] != apostas[j][p] {
+				return apostas[i][p] < apostas[j][p]
+			}
+		}
+		return false
+	})
+	return apostas
+}
+
+// VerificarDesdobramento confere as apostas contra todas as combinações de
+// condicao dezenas escolhidas, registrando o maior número de acertos que
+// cada combinação obtém. As combinações cobertas são montadas a partir de
+// cada aposta; só as descobertas são comparadas com todas as apostas.
+func VerificarDesdobramento(escolhidas []int, apostas [][]int, condicao, garantia int) model.CoberturaDesdobramento {
+	indices := make(map[int]uint, len(escolhidas))
+	for i, d := range escolhidas {
+		indices[d] = uint(i)
+	}
+	mascaras := make([]uint64, len(apostas))
+	for i, aposta := range apostas {
+		for _, d := range aposta {
+			if indice, ok := indices[d]; ok {
+				mascaras[i] |= 1 << indice
+			}
+		}
+	}
+
+	busca := &buscaCobertura{v: len(escolhidas), t: garantia, m: condicao, alvos: combinacoesBits(len(escolhidas), condicao)}
+	maiores := make([]int, len(busca.alvos))
+	for _, m := range mascaras {
+		busca.cobertos(m, func(j int) {
+			if acertos := bits.OnesCount64(m & busca.alvos[j]); acertos > maiores[j] {
+				maiores[j] = acertos
+			}
+		})
+	}
+
+	porAcertos := make(map[int]int)
+	cobertura := model.CoberturaDesdobramento{Distribuicao: []model.AcertosCobertura{}}
+	for j, combinacao := range busca.alvos {
+		maior := maiores[j]
+		if maior < garantia {
+			for _, m := range mascaras {
+				if acertos := bits.OnesCount64(m & combinacao); acertos > maior {
+					maior = acertos
+				}
+			}
+		}
+		porAcertos[maior]++
+		cobertura.Combinacoes++
+		if maior >= garantia {
+			cobertura.Cobertas++
+		}
+	}
+
+	for acertos := condicao; acertos >= 0; acertos-- {
+		if porAcertos[acertos] > 0 {
+			cobertura.Distribuicao = append(cobertura.Distribuicao, model.AcertosCobertura{Acertos: acertos, Combinacoes: porAcertos[acertos]})
+		}
+	}
+	cobertura.Percentual = percentual(cobertura.Cobertas, cobertura.Combinacoes)
+	cobertura.Garantida = cobertura.Cobertas == cobertura.Combinacoes
+	return cobertura
+}
+
+// limiteInferiorDesdobramento é o mínimo de apostas possível: cada aposta de
+// k dezenas entre v cobre no máximo a soma de C(k,i)·C(v-k,m-i) combinações
+// de m dezenas, para i >= t.
+func limiteInferiorDesdobramento(v, k, t, m int) int64 {
+	var porAposta int64
+	for i := t; i <= m && i <= k; i++ {
+		porAposta += model.Combinacoes(k, i) * model.Combinacoes(v-k, m-i)
+	}
+	if porAposta == 0 {
+		return 1
+	}
+	return (model.Combinacoes(v, m) + porAposta - 1) / porAposta
+}
+
+// buscaCobertura representa apostas e combinações como máscaras de bits
+// sobre os índices das dezenas escolhidas. Uma aposta cobre uma combinação
+// de m dezenas quando têm ao menos t dezenas em comum. As combinações ficam
+// em ordem crescente, o que permite achar a posição de cada uma.
+type buscaCobertura struct {
+	v, k, t, m int
+	alvos      []uint64
+	rng        *rand.Rand
+}
+
+func (b *buscaCobertura) cobre(aposta, alvo uint64) bool {
+	return bits.OnesCount64(aposta&alvo) >= b.t
+}
+
+// cobertos chama visitar com a posição de cada combinação que a aposta
+// cobre, montando-as a partir das dezenas da aposta em vez de percorrer
+// todas as combinações.
+func (b *buscaCobertura) cobertos(aposta uint64, visitar func(j int)) {
+	dentro := indicesBits(aposta)
+	fora := indicesBits(^aposta & (1<<uint(b.v) - 1))
+	for i := b.t; i <= b.m && i <= len(dentro); i++ {
+		if b.m-i > len(fora) {
+			continue
+		}
+		combinarIndices(dentro, i, 0, func(parte uint64) {
+			combinarIndices(fora, b.m-i, parte, func(alvo uint64) {
+				visitar(sort.Search(len(b.alvos), func(j int) bool { return b.alvos[j] >= alvo }))
+			})
+		})
+	}
+}
+
+// combinarIndices chama visitar com cada máscara formada pela base e por
+// quantidade dos índices da lista.
+func combinarIndices(lista []int, quantidade int, base uint64, visitar func(uint64)) {
+	if quantidade == 0 {
+		visitar(base)
+		return
+	}
+	for i := 0; i <= len(lista)-quantidade; i++ {
+		combinarIndices(lista[i+1:], quantidade-1, base|1<<uint(lista[i]), visitar)
+	}
+}
+
+// gulosa cobre as combinações uma a uma: para a primeira ainda descoberta,
+// compara apostas que a cobrem e fica com a que cobre mais combinações
+// descobertas. Depois do prazo compara uma só, para terminar logo.
+func (b *buscaCobertura) gulosa(limite time.Time) []uint64 {
+	cobertos := make([]bool, len(b.alvos))
+	restantes := len(b.alvos)
+	var apostas []uint64
+
+	for i := 0; restantes > 0; {
+		for cobertos[i] {
+			i++
+		}
+
+		candidatos := candidatosPorPasso
+		if time.Now().After(limite) {
+			candidatos = 1
+		}
+		melhor, melhorGanho := uint64(0), -1
+		for c := 0; c < candidatos; c++ {
+			aposta := b.candidato(b.alvos[i])
+			ganho := 0
+			b.cobertos(aposta, func(j int) {
+				if !cobertos[j] {
+					ganho++
+				}
+			})
+			if ganho > melhorGanho {
+				melhor, melhorGanho = aposta, ganho
+			}
+		}
+
+		apostas = append(apostas, melhor)
+		b.cobertos(melhor, func(j int) {
+			if !cobertos[j] {
+				cobertos[j] = true
+				restantes--
+			}
+		})
+	}
+	return apostas
+}
+
+// candidato monta uma aposta com t dezenas aleatórias do alvo, completada
+// com dezenas aleatórias entre as demais escolhidas.
+func (b *buscaCobertura) candidato(alvo uint64) uint64 {
+	var aposta uint64
+	dentro := indicesBits(alvo)
+	b.rng.Shuffle(len(dentro), func(i, j int) { dentro[i], dentro[j] = dentro[j], dentro[i] })
+	for _, i := range dentro[:b.t] {
+		aposta |= 1 << uint(i)
+	}
+
+	fora := indicesBits(^aposta & (1<<uint(b.v) - 1))
+	b.rng.Shuffle(len(fora), func(i, j int) { fora[i], fora[j] = fora[j], fora[i] })
+	for _, i := range fora[:b.k-b.t] {
+		aposta |= 1 << uint(i)
+	}
+	return aposta
+}
+
+// contarCobertura retorna quantas apostas cobrem cada combinação.
+func (b *buscaCobertura) contarCobertura(apostas []uint64) []int32 {
+	cobertura := make([]int32, len(b.alvos))
+	for _, aposta := range apostas {
+		b.cobertos(aposta, func(j int) { cobertura[j]++ })
+	}
+	return cobertura
+}
+
+// removerMenosUtil retorna uma cópia das apostas sem a que cobre sozinha o
+// menor número de combinações.
+func (b *buscaCobertura) removerMenosUtil(apostas []uint64) []uint64 {
+	cobertura := b.contarCobertura(apostas)
+	menor, menorExclusivas := 0, -1
+	for i, aposta := range apostas {
+		exclusivas := 0
+		b.cobertos(aposta, func(j int) {
+			if cobertura[j] == 1 {
+				exclusivas++
+			}
+		})
+		if menorExclusivas < 0 || exclusivas < menorExclusivas {
+			menor, menorExclusivas = i, exclusivas
+		}
+	}
+	return append(append([]uint64{}, apostas[:menor]...), apostas[menor+1:]...)
+}
+
+// cobrir tenta, até o prazo, fazer as apostas cobrirem todas as combinações.
+// Cada passo escolhe uma combinação descoberta e troca, em uma aposta
+// aleatória, uma dezena de fora dela por uma de dentro. Trocas que descobrem
+// outras combinações são aceitas com probabilidade decrescente.
+func (b *buscaCobertura) cobrir(apostas []uint64, limite time.Time) bool {
+	cobertura := b.contarCobertura(apostas)
+	descobertos := 0
+	for _, c := range cobertura {
+		if c == 0 {
+			descobertos++
+		}
+	}
+
+	temperatura := 1.0
+	for passo := 0; descobertos > 0; passo++ {
+		if passo%32 == 0 && time.Now().After(limite) {
+			return false
+		}
+
+		i := b.rng.Intn(len(b.alvos))
+		for cobertura[i] != 0 {
+			i = (i + 1) % len(b.alvos)
+		}
+		j := b.rng.Intn(len(apostas))
+		alvo, aposta := b.alvos[i], apostas[j]
+
+		sai, entra := aposta&^alvo, alvo&^aposta
+		if sai == 0 || entra == 0 {
+			continue
+		}
+		nova := aposta&^b.bitAleatorio(sai) | b.bitAleatorio(entra)
+
+		delta := 0
+		b.cobertos(aposta, func(a int) {
+			if cobertura[a] == 1 && !b.cobre(nova, b.alvos[a]) {
+				delta++
+			}
+		})
+		b.cobertos(nova, func(a int) {
+			if cobertura[a] == 0 && !b.cobre(aposta, b.alvos[a]) {
+				delta--
+			}
+		})
+
+		temperatura = math.Max(temperatura*resfriamento, temperaturaMinima)
+		if delta > 0 && b.rng.Float64() >= math.Exp(-float64(delta)/temperatura) {
+			continue
+		}
+
+		b.cobertos(aposta, func(a int) {
+			if !b.cobre(nova, b.alvos[a]) {
+				cobertura[a]--
+				if cobertura[a] == 0 {
+					descobertos++
+				}
+			}
+		})
+		b.cobertos(nova, func(a int) {
+			if !b.cobre(aposta, b.alvos[a]) {
+				if cobertura[a] == 0 {
+					descobertos--
+				}
+				cobertura[a]++
+			}
+		})
+		apostas[j] = nova
+	}
+	return true
+}
+
+// bitAleatorio retorna um dos bits ligados da máscara.
+func (b *buscaCobertura) bitAleatorio(mascara uint64) uint64 {
+	indices := indicesBits(mascara)
+	return 1 << uint(indices[b.rng.Intn(len(indices))])
+}
+
+func indicesBits(mascara uint64) []int {
+	indices := make([]int, 0, bits.OnesCount64(mascara))
+	for mascara != 0 {
+		i := bits.TrailingZeros64(mascara)
+		indices = append(indices, i)
+		mascara &= mascara - 1
+	}
+	return indices
+}
+
+// combinacoesBits lista, como máscaras, todas as combinações de m entre v
+// índices, em ordem crescente (regra de Gosper).
+func combinacoesBits(v, m int) []uint64 {
+	combinacoes := make([]uint64, 0, model.Combinacoes(v, m))
+	if m == 0 {
+		return append(combinacoes, 0)
+	}
+	limite := uint64(1) << uint(v)
+	for c := uint64(1)<<uint(m) - 1; c < limite; {
+		combinacoes = append(combinacoes, c)
+		menor := c & -c
+		proxima := c + menor
+		c = proxima | ((c^proxima)>>2)/menor
+	}
+	return combinacoes
+}
@@ -0,0 +1,168 @@
+package service_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"loterias-api-golang/internal/model"
+	"loterias-api-golang/internal/service"
+)
+
+func sequencia(n int) []int {
+	numeros := make([]int, n)
+	for i := range numeros {
+		numeros[i] = i + 1
+	}
+	return numeros
+}
+
+func TestGerarDesdobramento(t *testing.T) {
+	tests := []struct {
+		name     string
+		dezenas  int
+		numeros  int
+		condicao int
+		garantia int
+		maximo   int
+	}{
+		// O plano de Fano atinge o limite inferior e encerra a busca na hora.
+		{"Plano de Fano", 7, 3, 2, 2, 7},
+		{"Quadra com 10 dezenas", 10, 6, 6, 4, 5},
+		{"14 pontos com 18 dezenas", 18, 15, 15, 14, 40},
+		// Cada aposta só cobre a si mesma: a resposta é a combinação completa.
+		{"Sena com 10 dezenas", 10, 6, 6, 6, 210},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			escolhidas := sequencia(tt.dezenas)
+			apostas := service.GerarDesdobramento(escolhidas, tt.numeros, tt.condicao, tt.garantia, 300*time.Millisecond)
+
+			if len(apostas) == 0 || len(apostas) > tt.maximo {
+				t.Errorf("len = %d, want between 1 and %d", len(apostas), tt.maximo)
+			}
+			for _, aposta := range apostas {
+				if len(aposta) != tt.numeros {
+					t.Fatalf("aposta %v tem %d números, want %d", aposta, len(aposta), tt.numeros)
+				}
+			}
+			if cobertura := service.VerificarDesdobramento(escolhidas, apostas, tt.condicao, tt.garantia); !cobertura.Garantida {
+				t.Errorf("cobertura = %+v, want garantida", cobertura)
+			}
+		})
+	}
+}
+
+func TestVerificarDesdobramento(t *testing.T) {
+	apostas := [][]int{{1, 2, 3}, {4, 5, 6}}
+	cobertura := service.VerificarDesdobramento(sequencia(7), apostas, 2, 2)
+
+	if cobertura.Combinacoes != 21 || cobertura.Cobertas != 6 || cobertura.Garantida {
+		t.Errorf("cobertura = %+v, want 6 de 21 pares cobertos", cobertura)
+	}
+	if cobertura.Percentual != 28.57 {
+		t.Errorf("Percentual = %v, want 28.57", cobertura.Percentual)
+	}
+	want := []model.AcertosCobertura{{Acertos: 2, Combinacoes: 6}, {Acertos: 1, Combinacoes: 15}}
+	if len(cobertura.Distribuicao) != len(want) {
+		t.Fatalf("Distribuicao = %+v, want %+v", cobertura.Distribuicao, want)
+	}
+	for i := range want {
+		if cobertura.Distribuicao[i] != want[i] {
+			t.Errorf("Distribuicao[%d] = %+v, want %+v", i, cobertura.Distribuicao[i], want[i])
+		}
+	}
+}
+
+func TestGerarDesdobramento_RespeitaPrazo(t *testing.T) {
+	escolhidas := sequencia(22)
+	inicio := time.Now()
+	apostas := service.GerarDesdobramento(escolhidas, 6, 6, 5, 200*time.Millisecond)
+	if duracao := time.Since(inicio); duracao > 2*time.Second {
+		t.Errorf("GerarDesdobramento took %v with a 200ms budget", duracao)
+	}
+	if cobertura := service.VerificarDesdobramento(escolhidas, apostas, 6, 5); !cobertura.Garantida {
+		t.Errorf("cobertura = %+v, want garantida", cobertura)
+	}
+}
+
+func TestDesdobrar_Invalido(t *testing.T) {
+	dezenas := func(n int) []string {
+		var lista []string
+		for _, d := range sequencia(n) {
+			lista = append(lista, strconv.Itoa(d))
+		}
+		return lista
+	}
+
+	tests := []struct {
+		name    string
+		loteria string
+		pedido  model.PedidoDesdobramento
+	}{
+		{"Garantia acima do máximo de apostas", "megasena", model.PedidoDesdobramento{Dezenas: dezenas(26), Garantia: 6}},
+		{"Combinações acima do limite", "megasena", model.PedidoDesdobramento{Dezenas: dezenas(40), Garantia: 4}},
+		{"Garantia sem faixa", "megasena", model.PedidoDesdobramento{Dezenas: dezenas(10), Garantia: 2}},
+	}
+	especiais := []struct {
+		name    string
+		loteria string
+		pedido  model.PedidoDesdobramento
+	}{
+		{"Timemania sem time do coração", "timemania", model.PedidoDesdobramento{Dezenas: dezenas(12), Garantia: 5}},
+		{"Dia de Sorte sem mês", "diadesorte", model.PedidoDesdobramento{Dezenas: dezenas(9), Garantia: 5}},
+		{"Dia de Sorte com mês inválido", "diadesorte", model.PedidoDesdobramento{Dezenas: dezenas(9), Garantia: 5, MesSorte: "Smarch"}},
+		{"Time do coração na Mega-Sena", "megasena", model.PedidoDesdobramento{Dezenas: dezenas(10), Garantia: 4, TimeCoracao: "SANTOS/SP"}},
+		{"Mês da sorte na Mega-Sena", "megasena", model.PedidoDesdobramento{Dezenas: dezenas(10), Garantia: 4, MesSorte: "Março"}},
+	}
+
+	geradorService := service.NewGeradorService(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := geradorService.Desdobrar(tt.loteria, tt.pedido)
+			var invalido *model.ParametroInvalidoException
+			if !errors.As(err, &invalido) {
+				t.Errorf("err = %v, want ParametroInvalidoException", err)
+			}
+		})
+	}
+	for _, tt := range especiais {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := geradorService.Desdobrar(tt.loteria, tt.pedido)
+			var invalida *model.ApostaInvalidaException
+			if !errors.As(err, &invalida) {
+				t.Errorf("err = %v, want ApostaInvalidaException", err)
+			}
+		})
+	}
+}
+
+func TestDesdobrar_Especiais(t *testing.T) {
+	geradorService := service.NewGeradorService(nil)
+
+	timemania, err := geradorService.Desdobrar("timemania", model.PedidoDesdobramento{
+		Dezenas: []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12"}, Garantia: 5, TimeCoracao: " SANTOS/SP ", TempoMaximo: 1,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, aposta := range timemania.Apostas {
+		if aposta.TimeCoracao != "SANTOS/SP" || aposta.MesSorte != "" {
+			t.Fatalf("aposta = %+v, want the club in every bet", aposta)
+		}
+	}
+
+	diaDeSorte, err := geradorService.Desdobrar("diadesorte", model.PedidoDesdobramento{
+		Dezenas: []string{"1", "2", "3", "4", "5", "6", "7", "8", "9"}, Garantia: 5, MesSorte: "3", TempoMaximo: 1,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, aposta := range diaDeSorte.Apostas {
+		if aposta.MesSorte != "Março" || aposta.TimeCoracao != "" {
+			t.Fatalf("aposta = %+v, want Março in every bet", aposta)
+		}
+	}
+}
@@ -0,0 +1,452 @@
+package service
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"loterias-api-golang/internal/model"
+	"loterias-api-golang/internal/repository"
+)
+
+const (
+	maxApostasGeradas   = 100
+	tentativasPorAposta = 10000
+)
+
+type GeradorService struct {
+	repository *repository.ResultadoRepository
+}
+
+func NewGeradorService(repository *repository.ResultadoRepository) *GeradorService {
+	return &GeradorService{
+		repository: repository,
+	}
+}
+
+// Gerar sorteia apostas válidas para a loteria usando crypto/rand. O
+// histórico só é lido quando o pedido exige apostas inéditas; os times do
+// coração, quando a loteria tem essa faixa.
+func (s *GeradorService) Gerar(loteria string, pedido model.PedidoGeracao) (*model.ApostasGeradas, error) {
+	regras, err := regrasNumericas(loteria)
+	if err != nil {
+		return nil, err
+	}
+
+	var sorteados []model.DezenasConcurso
+	if pedido.Inedita && regras.Tipo == model.TipoDezenas {
+		sorteados, err = s.repository.ListarDezenas(loteria, model.FiltroConcursos{})
+		if err != nil {
+			return nil, err
+		}
+	}
+	var times []string
+	if regras.TemEspecial(model.EspecialTimeCoracao) {
+		times, err = s.repository.ListarTimesCoracao(loteria)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return GerarApostas(regras, pedido, sorteados, times)
+}
+
+// GerarApostas sorteia as apostas do pedido. As que não atendem aos filtros
+// são descartadas e sorteadas novamente; com o pedido de apostas inéditas,
+// são descartadas as que contêm algum dos sorteios informados. O time do
+// coração é sorteado entre os times informados.
+func GerarApostas(regras model.Regras, pedido model.PedidoGeracao, sorteados []model.DezenasConcurso, times []string) (*model.ApostasGeradas, error) {
+	loteria := string(regras.Loteria)
+	if pedido.Quantidade == 0 {
+		pedido.Quantidade = 1
+	}
+	if pedido.Quantidade < 1 || pedido.Quantidade > maxApostasGeradas {
+		return nil, &model.ParametroInvalidoException{
+			Message: fmt.Sprintf("A quantidade de apostas deve estar entre 1 e %d", maxApostasGeradas),
+		}
+	}
+	if pedido.Numeros == 0 {
+		pedido.Numeros = regras.NumerosApostaSimples
+	}
+	if pedido.Numeros < regras.MinNumeros || pedido.Numeros > regras.MaxNumeros {
+		return nil, &model.ParametroInvalidoException{
+			Message: fmt.Sprintf("A aposta deve ter entre %d e %d números", regras.MinNumeros, regras.MaxNumeros),
+		}
+	}
+
+	geradas := &model.ApostasGeradas{Loteria: loteria, Apostas: []model.ApostaGerada{}}
+
+	if regras.Tipo == model.TipoColunas {
+		if len(pedido.Fixos) > 0 || len(pedido.Excluidos) > 0 || pedido.Pares != nil ||
+			pedido.SomaMinima > 0 || pedido.SomaMaxima > 0 || pedido.Inedita {
+			return nil, &model.ParametroInvalidoException{Message: "Filtros não estão disponíveis para a loteria " + loteria}
+		}
+		for i := 0; i < pedido.Quantidade; i++ {
+			aposta, err := gerarColunas(regras, pedido.Numeros)
+			if err != nil {
+				return nil, err
+			}
+			geradas.Apostas = append(geradas.Apostas, aposta)
+			geradas.ValorTotal += aposta.Preco
+		}
+		return geradas, nil
+	}
+
+	if regras.TemEspecial(model.EspecialTimeCoracao) && len(times) == 0 {
+		return nil, &model.ResourceNotFoundException{Message: "Nenhum time do coração encontrado nos resultados da " + regras.Nome}
+	}
+
+	gerador, err := novoGeradorDezenas(regras, pedido, sorteados)
+	if err != nil {
+		return nil, err
+	}
+
+	vistas := make(map[conjuntoNumeros]bool)
+	for len(geradas.Apostas) < pedido.Quantidade {
+		dezenas, err := gerador.sortear(vistas)
+		if err != nil {
+			return nil, err
+		}
+		vistas[novoConjunto(dezenas)] = true
+
+		aposta := model.ApostaGerada{Preco: float64(regras.ApostasSimples(pedido.Numeros, gerador.trevos)) * regras.PrecoApostaSimples}
+		for _, d := range dezenas {
+			aposta.Dezenas = append(aposta.Dezenas, formatarDezena(d))
+		}
+		if regras.Trevos != nil {
+			trevos, err := sortearNumeros(regras.Trevos.MenorNumero, regras.Trevos.MaiorNumero, gerador.trevos)
+			if err != nil {
+				return nil, err
+			}
+			for _, t := range trevos {
+				aposta.Trevos = append(aposta.Trevos, strconv.Itoa(t))
+			}
+		}
+		if regras.MesSorte {
+			mes, err := sortearIndice(len(mesesSorte))
+			if err != nil {
+				return nil, err
+			}
+			aposta.MesSorte = mesesSorte[mes]
+		}
+		if len(times) > 0 {
+			i, err := sortearIndice(len(times))
+			if err != nil {
+				return nil, err
+			}
+			aposta.TimeCoracao = strings.TrimSpace(times[i])
+		}
+
+		geradas.Apostas = append(geradas.Apostas, aposta)
+		geradas.ValorTotal += aposta.Preco
+	}
+
+	return geradas, nil
+}
+
+// geradorDezenas guarda os filtros já validados de um pedido de geração.
+// Com a quantidade de pares definida, as dezenas são sorteadas separadamente
+// entre as pares e as ímpares disponíveis.
+type geradorDezenas struct {
+	numeros            int
+	trevos             int
+	fixos              []int
+	disponivel         []int
+	pares              *int
+	faltamPares        int
+	faltamImpares      int
+	paresDisponiveis   []int
+	imparesDisponiveis []int
+	somaMinima         int
+	somaMaxima         int
+	sorteados          int
+	historico          map[conjuntoNumeros]bool
+}
+
+func novoGeradorDezenas(regras model.Regras, pedido model.PedidoGeracao, sorteados []model.DezenasConcurso) (*geradorDezenas, error) {
+	gerador := &geradorDezenas{
+		numeros:    pedido.Numeros,
+		pares:      pedido.Pares,
+		somaMinima: pedido.SomaMinima,
+		somaMaxima: pedido.SomaMaxima,
+		sorteados:  regras.NumerosSorteados,
+	}
+
+	if regras.Trevos != nil {
+		gerador.trevos = pedido.Trevos
+		if gerador.trevos == 0 {
+			gerador.trevos = regras.Trevos.NumerosApostaSimples
+		}
+		if gerador.trevos < regras.Trevos.MinNumeros || gerador.trevos > regras.Trevos.MaxNumeros {
+			return nil, &model.ParametroInvalidoException{
+				Message: fmt.Sprintf("A aposta deve ter entre %d e %d trevos", regras.Trevos.MinNumeros, regras.Trevos.MaxNumeros),
+			}
+		}
+	}
+
+	fixos, err := parseNumeros(pedido.Fixos, regras.MenorNumero, regras.MaiorNumero, "dezena fixa")
+	if err != nil {
+		return nil, err
+	}
+	excluidos, err := parseNumeros(pedido.Excluidos, regras.MenorNumero, regras.MaiorNumero, "dezena excluída")
+	if err != nil {
+		return nil, err
+	}
+	if len(fixos) > gerador.numeros {
+		return nil, &model.ParametroInvalidoException{Message: "Há mais dezenas fixas que números na aposta"}
+	}
+	for _, f := range fixos {
+		if contem(excluidos, f) {
+			return nil, &model.ParametroInvalidoException{Message: fmt.Sprintf("A dezena %d não pode ser fixa e excluída", f)}
+		}
+	}
+	gerador.fixos = fixos
+
+	for n := regras.MenorNumero; n <= regras.MaiorNumero; n++ {
+		if !contem(fixos, n) && !contem(excluidos, n) {
+			gerador.disponivel = append(gerador.disponivel, n)
+		}
+	}
+	if len(fixos)+len(gerador.disponivel) < gerador.numeros {
+		return nil, &model.ParametroInvalidoException{Message: "Não há dezenas disponíveis suficientes após as exclusões"}
+	}
+	if gerador.pares != nil {
+		if err := gerador.separarParidade(); err != nil {
+			return nil, err
+		}
+	}
+	if gerador.somaMaxima > 0 && gerador.somaMinima > gerador.somaMaxima {
+		return nil, &model.ParametroInvalidoException{Message: "A soma mínima não pode ser maior que a soma máxima"}
+	}
+
+	if pedido.Inedita {
+		gerador.historico = make(map[conjuntoNumeros]bool, len(sorteados)*regras.Sorteios)
+		for _, c := range sorteados {
+			for sorteio := 1; sorteio <= regras.Sorteios; sorteio++ {
+				dezenas := parseDezenas(dezenasDoSorteio(c.Dezenas, regras, sorteio))
+				if len(dezenas) == regras.NumerosSorteados {
+					gerador.historico[novoConjunto(dezenas)] = true
+				}
+			}
+		}
+	}
+
+	return gerador, nil
+}
+
+// separarParidade divide as dezenas disponíveis entre pares e ímpares e
+// recusa quantidades de pares impossíveis com as fixas e as exclusões.
+func (g *geradorDezenas) separarParidade() error {
+	if *g.pares < 0 || *g.pares > g.numeros {
+		return &model.ParametroInvalidoException{
+			Message: fmt.Sprintf("A quantidade de pares deve estar entre 0 e %d", g.numeros),
+		}
+	}
+
+	fixasPares := 0
+	for _, f := range g.fixos {
+		if f%2 == 0 {
+			fixasPares++
+		}
+	}
+	for _, d := range g.disponivel {
+		if d%2 == 0 {
+			g.paresDisponiveis = append(g.paresDisponiveis, d)
+		} else {
+			g.imparesDisponiveis = append(g.imparesDisponiveis, d)
+		}
+	}
+
+	g.faltamPares = *g.pares - fixasPares
+	g.faltamImpares = g.numeros - *g.pares - (len(g.fixos) - fixasPares)
+	if g.faltamPares < 0 || g.faltamPares > len(g.paresDisponiveis) ||
+		g.faltamImpares < 0 || g.faltamImpares > len(g.imparesDisponiveis) {
+		return &model.ParametroInvalidoException{
+			Message: fmt.Sprintf("Não é possível montar uma aposta de %d números com %d pares após as dezenas fixas e excluídas", g.numeros, *g.pares),
+		}
+	}
+	return nil
+}
+
+// completar sorteia as dezenas que faltam além das fixas, respeitando a
+// quantidade de pares quando definida.
+func (g *geradorDezenas) completar() ([]int, error) {
+	if g.pares == nil {
+		return embaralhar(g.disponivel, g.numeros-len(g.fixos))
+	}
+	pares, err := embaralhar(g.paresDisponiveis, g.faltamPares)
+	if err != nil {
+		return nil, err
+	}
+	impares, err := embaralhar(g.imparesDisponiveis, g.faltamImpares)
+	if err != nil {
+		return nil, err
+	}
+	return append(pares, impares...), nil
+}
+
+// sortear completa as dezenas fixas com dezenas aleatórias até encontrar uma
+// aposta que atenda aos filtros e ainda não tenha sido gerada.
+func (g *geradorDezenas) sortear(vistas map[conjuntoNumeros]bool) ([]int, error) {
+	for tentativa := 0; tentativa < tentativasPorAposta; tentativa++ {
+		sorteadas, err := g.completar()
+		if err != nil {
+			return nil, err
+		}
+		dezenas := append(append([]int{}, g.fixos...), sorteadas...)
+		sort.Ints(dezenas)
+
+		if g.aceita(dezenas) && !vistas[novoConjunto(dezenas)] {
+			return dezenas, nil
+		}
+	}
+
+	return nil, &model.ParametroInvalidoException{Message: "Não foi possível gerar apostas que atendam a todos os filtros"}
+}
+
+func (g *geradorDezenas) aceita(dezenas []int) bool {
+	soma := 0
+	for _, d := range dezenas {
+		soma += d
+	}
+
+	if g.somaMinima > 0 && soma < g.somaMinima {
+		return false
+	}
+	if g.somaMaxima > 0 && soma > g.somaMaxima {
+		return false
+	}
+
+	return !g.jaPremiada(dezenas)
+}
+
+// jaPremiada indica se algum sorteio passado está contido na aposta, o que
+// lhe teria dado o prêmio máximo. Quando a aposta tem menos combinações de
+// números sorteados que o histórico, cada combinação é procurada no
+// conjunto; senão o histórico é percorrido.
+func (g *geradorDezenas) jaPremiada(dezenas []int) bool {
+	if len(g.historico) == 0 {
+		return false
+	}
+
+	if model.Combinacoes(len(dezenas), g.sorteados) > int64(len(g.historico)) {
+		aposta := novoConjunto(dezenas)
+		for sorteio := range g.historico {
+			if aposta.contem(sorteio) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var procurar func(inicio, faltam int, parcial conjuntoNumeros) bool
+	procurar = func(inicio, faltam int, parcial conjuntoNumeros) bool {
+		if faltam == 0 {
+			return g.historico[parcial]
+		}
+		for i := inicio; i <= len(dezenas)-faltam; i++ {
+			proximo := parcial
+			proximo.adicionar(dezenas[i])
+			if procurar(i+1, faltam-1, proximo) {
+				return true
+			}
+		}
+		return false
+	}
+	return procurar(0, g.sorteados, conjuntoNumeros{})
+}
+
+// gerarColunas sorteia um número para cada coluna da Super Sete e distribui
+// os números extras entre colunas aleatórias.
+func gerarColunas(regras model.Regras, numeros int) (model.ApostaGerada, error) {
+	colunas := regras.Colunas
+	porColuna := make([]int, colunas.Quantidade)
+	for i := range porColuna {
+		porColuna[i] = colunas.MinPorColuna
+	}
+	for extras := numeros - colunas.Quantidade*colunas.MinPorColuna; extras > 0; {
+		i, err := sortearIndice(colunas.Quantidade)
+		if err != nil {
+			return model.ApostaGerada{}, err
+		}
+		if porColuna[i] < colunas.MaxPorColuna {
+			porColuna[i]++
+			extras--
+		}
+	}
+
+	aposta := model.ApostaGerada{Preco: regras.PrecoColunas(porColuna)}
+	for _, quantidade := range porColuna {
+		numerosColuna, err := sortearNumeros(regras.MenorNumero, regras.MaiorNumero, quantidade)
+		if err != nil {
+			return model.ApostaGerada{}, err
+		}
+		coluna := make([]string, len(numerosColuna))
+		for i, n := range numerosColuna {
+			coluna[i] = strconv.Itoa(n)
+		}
+		aposta.Colunas = append(aposta.Colunas, coluna)
+	}
+	return aposta, nil
+}
+
+// sortearNumeros sorteia quantidade números distintos do intervalo, em ordem.
+func sortearNumeros(menor, maior, quantidade int) ([]int, error) {
+	intervalo := make([]int, 0, maior-menor+1)
+	for n := menor; n <= maior; n++ {
+		intervalo = append(intervalo, n)
+	}
+	numeros, err := embaralhar(intervalo, quantidade)
+	if err != nil {
+		return nil, err
+	}
+	sort.Ints(numeros)
+	return numeros, nil
+}
+
+// embaralhar retorna quantidade elementos aleatórios de valores usando um
+// Fisher-Yates parcial sobre uma cópia.
+func embaralhar(valores []int, quantidade int) ([]int, error) {
+	copia := append([]int{}, valores...)
+	for i := 0; i < quantidade; i++ {
+		j, err := sortearIndice(len(copia) - i)
+		if err != nil {
+			return nil, err
+		}
+		copia[i], copia[i+j] = copia[i+j], copia[i]
+	}
+	return copia[:quantidade], nil
+}
+
+func sortearIndice(n int) (int, error) {
+	i, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, fmt.Errorf("falha ao gerar número aleatório: %w", err)
+	}
+	return int(i.Int64()), nil
+}
+
+// conjuntoNumeros representa números de 0 a 127 como bits, o suficiente para
+// todos os volantes.
+type conjuntoNumeros [2]uint64
+
+func novoConjunto(numeros []int) conjuntoNumeros {
+	var c conjuntoNumeros
+	for _, n := range numeros {
+		c.adicionar(n)
+	}
+	return c
+}
+
+func (c *conjuntoNumeros) adicionar(n int) {
+	if n >= 0 && n < 128 {
+		c[n/64] |= 1 << uint(n%64)
+	}
+}
+
+func (c conjuntoNumeros) contem(outro conjuntoNumeros) bool {
+	return c[0]&outro[0] == outro[0] && c[1]&outro[1] == outro[1]
+}
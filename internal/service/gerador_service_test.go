@@ -0,0 +1,293 @@
+package service_test
+
+import (
+	"strconv"
+	"testing"
+
+	"loterias-api-golang/internal/model"
+	"loterias-api-golang/internal/service"
+)
+
+func numerosDaAposta(t *testing.T, valores []string) []int {
+	t.Helper()
+	numeros := make([]int, len(valores))
+	for i, v := range valores {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			t.Fatalf("número inválido %q: %v", v, err)
+		}
+		numeros[i] = n
+	}
+	return numeros
+}
+
+// conferirPares verifica que a aposta tem exatamente a quantidade de pares.
+func conferirPares(pares int) func(t *testing.T, aposta model.ApostaGerada) {
+	return func(t *testing.T, aposta model.ApostaGerada) {
+		contagem := 0
+		for _, n := range numerosDaAposta(t, aposta.Dezenas) {
+			if n%2 == 0 {
+				contagem++
+			}
+		}
+		if contagem != pares {
+			t.Errorf("dezenas = %v, want %d pares", aposta.Dezenas, pares)
+		}
+	}
+}
+
+func TestGerarApostas(t *testing.T) {
+	pares, doisPares, dezPares, semPares := 4, 2, 10, 0
+
+	tests := []struct {
+		name      string
+		loteria   string
+		pedido    model.PedidoGeracao
+		sorteados []model.DezenasConcurso
+		times     []string
+		conferir  func(t *testing.T, aposta model.ApostaGerada)
+	}{
+		{
+			name:    "dezenas fixas",
+			loteria: "megasena",
+			pedido:  model.PedidoGeracao{Quantidade: 20, Numeros: 8, Fixos: []string{"07", "60", "1"}},
+			conferir: func(t *testing.T, aposta model.ApostaGerada) {
+				numeros := numerosDaAposta(t, aposta.Dezenas)
+				if len(numeros) != 8 || aposta.Preco != 168 {
+					t.Errorf("aposta = %+v, want 8 dezenas costing 168", aposta)
+				}
+				for _, fixa := range []int{1, 7, 60} {
+					if !contemNumero(numeros, fixa) {
+						t.Errorf("dezenas = %v, want fixed %d", numeros, fixa)
+					}
+				}
+			},
+		},
+		{
+			name:    "dezenas excluídas",
+			loteria: "lotofacil",
+			pedido:  model.PedidoGeracao{Quantidade: 20, Excluidos: []string{"1", "2", "3", "4", "05"}},
+			conferir: func(t *testing.T, aposta model.ApostaGerada) {
+				for _, n := range numerosDaAposta(t, aposta.Dezenas) {
+					if n < 6 {
+						t.Errorf("dezenas = %v, want none below 6", aposta.Dezenas)
+					}
+				}
+			},
+		},
+		{
+			name:     "pares e ímpares",
+			loteria:  "quina",
+			pedido:   model.PedidoGeracao{Quantidade: 20, Numeros: 7, Pares: &pares},
+			conferir: conferirPares(4),
+		},
+		{
+			name:     "poucos pares na Lotofácil",
+			loteria:  "lotofacil",
+			pedido:   model.PedidoGeracao{Quantidade: 20, Pares: &doisPares},
+			conferir: conferirPares(2),
+		},
+		{
+			name:     "poucos pares na Lotomania",
+			loteria:  "lotomania",
+			pedido:   model.PedidoGeracao{Quantidade: 20, Pares: &dezPares},
+			conferir: conferirPares(10),
+		},
+		{
+			name:     "pares com dezenas fixas",
+			loteria:  "megasena",
+			pedido:   model.PedidoGeracao{Quantidade: 20, Fixos: []string{"02", "04"}, Pares: &doisPares},
+			conferir: conferirPares(2),
+		},
+		{
+			name:    "faixa de soma",
+			loteria: "megasena",
+			pedido:  model.PedidoGeracao{Quantidade: 20, SomaMinima: 170, SomaMaxima: 190},
+			conferir: func(t *testing.T, aposta model.ApostaGerada) {
+				soma := 0
+				for _, n := range numerosDaAposta(t, aposta.Dezenas) {
+					soma += n
+				}
+				if soma < 170 || soma > 190 {
+					t.Errorf("dezenas = %v sum to %d, want between 170 and 190", aposta.Dezenas, soma)
+				}
+			},
+		},
+		{
+			// Com cinco fixas e só cinco dezenas disponíveis, a única aposta
+			// inédita é a que não repete nenhum dos quatro sorteios.
+			name:    "aposta inédita",
+			loteria: "megasena",
+			pedido: model.PedidoGeracao{Quantidade: 1, Inedita: true, Fixos: []string{"1", "2", "3", "4", "5"},
+				Excluidos: excluirAlem(10, 60)},
+			sorteados: []model.DezenasConcurso{
+				{Concurso: 1, Dezenas: []string{"01", "02", "03", "04", "05", "06"}},
+				{Concurso: 2, Dezenas: []string{"07", "05", "04", "03", "02", "01"}},
+				{Concurso: 3, Dezenas: []string{"01", "02", "03", "04", "05", "08"}},
+				{Concurso: 4, Dezenas: []string{"01", "02", "03", "04", "05", "09"}},
+			},
+			conferir: func(t *testing.T, aposta model.ApostaGerada) {
+				numeros := numerosDaAposta(t, aposta.Dezenas)
+				if numeros[5] != 10 {
+					t.Errorf("dezenas = %v, want 01 to 05 and 10", aposta.Dezenas)
+				}
+			},
+		},
+		{
+			name:    "aposta inédita maior que o sorteio",
+			loteria: "lotofacil",
+			pedido:  model.PedidoGeracao{Quantidade: 5, Numeros: 16, Inedita: true, Excluidos: []string{"20", "21", "22", "23", "24", "25"}},
+			sorteados: []model.DezenasConcurso{
+				{Concurso: 1, Dezenas: []string{"01", "02", "03", "04", "05", "06", "07", "08", "09", "10", "11", "12", "13", "14", "15"}},
+			},
+			conferir: func(t *testing.T, aposta model.ApostaGerada) {
+				numeros := numerosDaAposta(t, aposta.Dezenas)
+				for n := 1; n <= 15; n++ {
+					if !contemNumero(numeros, n) {
+						return
+					}
+				}
+				t.Errorf("dezenas = %v contain the draw of concurso 1", aposta.Dezenas)
+			},
+		},
+		{
+			name:    "trevos da +Milionária",
+			loteria: "maismilionaria",
+			pedido:  model.PedidoGeracao{Quantidade: 10, Numeros: 7, Trevos: 3},
+			conferir: func(t *testing.T, aposta model.ApostaGerada) {
+				trevos := numerosDaAposta(t, aposta.Trevos)
+				if len(aposta.Dezenas) != 7 || len(trevos) != 3 || aposta.Preco != 126 {
+					t.Fatalf("aposta = %+v, want 7 dezenas, 3 trevos and 126", aposta)
+				}
+				for i, trevo := range trevos {
+					if trevo < 1 || trevo > 6 || (i > 0 && trevo <= trevos[i-1]) {
+						t.Errorf("trevos = %v, want distinct values from 1 to 6 in order", trevos)
+					}
+				}
+			},
+		},
+		{
+			name:    "colunas da Super Sete",
+			loteria: "supersete",
+			pedido:  model.PedidoGeracao{Quantidade: 10, Numeros: 10},
+			conferir: func(t *testing.T, aposta model.ApostaGerada) {
+				if len(aposta.Colunas) != 7 {
+					t.Fatalf("colunas = %v, want 7", aposta.Colunas)
+				}
+				total, apostas := 0, 1
+				for _, coluna := range aposta.Colunas {
+					if len(coluna) < 1 || len(coluna) > 3 {
+						t.Errorf("coluna %v, want 1 to 3 números", coluna)
+					}
+					for _, n := range numerosDaAposta(t, coluna) {
+						if n < 0 || n > 9 {
+							t.Errorf("coluna %v, want números from 0 to 9", coluna)
+						}
+					}
+					total += len(coluna)
+					apostas *= len(coluna)
+				}
+				if total != 10 || aposta.Preco != float64(apostas)*3 {
+					t.Errorf("aposta = %+v, want 10 números priced by column", aposta)
+				}
+			},
+		},
+		{
+			name:    "time do coração e mês da sorte",
+			loteria: "timemania",
+			pedido:  model.PedidoGeracao{Quantidade: 10, Pares: &semPares},
+			times:   []string{"FLAMENGO/RJ  ", "SANTOS/SP"},
+			conferir: func(t *testing.T, aposta model.ApostaGerada) {
+				conferirPares(0)(t, aposta)
+				if aposta.TimeCoracao != "FLAMENGO/RJ" && aposta.TimeCoracao != "SANTOS/SP" {
+					t.Errorf("TimeCoracao = %q, want one of the given clubs", aposta.TimeCoracao)
+				}
+				if len(aposta.Dezenas) != 10 || aposta.MesSorte != "" {
+					t.Errorf("aposta = %+v, want 10 dezenas and no mês", aposta)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			regras, _ := model.GetRegras(tt.loteria)
+			geradas, err := service.GerarApostas(regras, tt.pedido, tt.sorteados, tt.times)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(geradas.Apostas) != tt.pedido.Quantidade {
+				t.Fatalf("len = %d, want %d", len(geradas.Apostas), tt.pedido.Quantidade)
+			}
+			vistas := make(map[string]bool)
+			for _, aposta := range geradas.Apostas {
+				chave := ""
+				for _, d := range aposta.Dezenas {
+					chave += d + " "
+				}
+				if chave != "" && vistas[chave] {
+					t.Errorf("aposta %v generated twice", aposta.Dezenas)
+				}
+				vistas[chave] = true
+				tt.conferir(t, aposta)
+			}
+		})
+	}
+}
+
+func TestGerarApostas_Invalido(t *testing.T) {
+	muitosPares, catorzePares, umPar := 8, 14, 1
+	sorteados := []model.DezenasConcurso{
+		{Concurso: 1, Dezenas: []string{"01", "02", "03", "04", "05", "06"}},
+	}
+
+	tests := []struct {
+		name      string
+		loteria   string
+		pedido    model.PedidoGeracao
+		sorteados []model.DezenasConcurso
+		times     []string
+	}{
+		{"quantidade acima do máximo", "megasena", model.PedidoGeracao{Quantidade: 101}, nil, nil},
+		{"números abaixo do mínimo", "megasena", model.PedidoGeracao{Numeros: 5}, nil, nil},
+		{"fixa e excluída", "megasena", model.PedidoGeracao{Fixos: []string{"5"}, Excluidos: []string{"05"}}, nil, nil},
+		{"mais fixas que números", "quina", model.PedidoGeracao{Fixos: []string{"1", "2", "3", "4", "5", "6"}}, nil, nil},
+		{"pares acima dos números", "megasena", model.PedidoGeracao{Pares: &muitosPares}, nil, nil},
+		{"pares acima dos disponíveis", "lotofacil", model.PedidoGeracao{Pares: &catorzePares}, nil, nil},
+		{"pares abaixo das fixas pares", "megasena", model.PedidoGeracao{Fixos: []string{"02", "04"}, Pares: &umPar}, nil, nil},
+		{"ímpares acima dos disponíveis", "quina", model.PedidoGeracao{Numeros: 15, Pares: &umPar, Excluidos: excluirAlem(20, 80)}, nil, nil},
+		{"soma invertida", "megasena", model.PedidoGeracao{SomaMinima: 200, SomaMaxima: 100}, nil, nil},
+		{"trevos acima do máximo", "maismilionaria", model.PedidoGeracao{Trevos: 7}, nil, nil},
+		{"filtros na Super Sete", "supersete", model.PedidoGeracao{Inedita: true}, nil, nil},
+		{"Timemania sem times", "timemania", model.PedidoGeracao{}, nil, nil},
+		{"nenhuma aposta inédita possível", "megasena",
+			model.PedidoGeracao{Inedita: true, Fixos: []string{"1", "2", "3", "4", "5", "6"}}, sorteados, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			regras, _ := model.GetRegras(tt.loteria)
+			if _, err := service.GerarApostas(regras, tt.pedido, tt.sorteados, tt.times); err == nil {
+				t.Error("error = nil, want an error")
+			}
+		})
+	}
+}
+
+func contemNumero(numeros []int, n int) bool {
+	for _, v := range numeros {
+		if v == n {
+			return true
+		}
+	}
+	return false
+}
+
+// excluirAlem lista como texto os números do intervalo acima de limite.
+func excluirAlem(limite, maior int) []string {
+	var excluidos []string
+	for n := limite + 1; n <= maior; n++ {
+		excluidos = append(excluidos, strconv.Itoa(n))
+	}
+	return excluidos
+}
@@ -16,12 +16,14 @@ import (
 type LoteriasUpdate struct {
 	consumer         *Consumer
 	resultadoService *ResultadoService
+	bolaoService     *BolaoService
 }
 
-func NewLoteriasUpdate(consumer *Consumer, resultadoService *ResultadoService) *LoteriasUpdate {
+func NewLoteriasUpdate(consumer *Consumer, resultadoService *ResultadoService, bolaoService *BolaoService) *LoteriasUpdate {
 	return &LoteriasUpdate{
 		consumer:         consumer,
 		resultadoService: resultadoService,
+		bolaoService:     bolaoService,
 	}
 }
 
@@ -95,17 +97,21 @@ func (l *LoteriasUpdate) updateLoteria(loteria string) error {
 		latest.Data = latestAPI.Data
 		latest.Local = latestAPI.Local
 		latest.Premiacoes = latestAPI.Premiacoes
+		latest.Sorteios = latestAPI.Sorteios
 		latest.LocalGanhadores = latestAPI.LocalGanhadores
+		latest.Federal = latestAPI.Federal
 		latest.Acumulou = latestAPI.Acumulou
 		latest.DataProximoConcurso = latestAPI.DataProximoConcurso
 		latest.ValorAcumuladoProximoConcurso = latestAPI.ValorAcumuladoProximoConcurso
 		latest.ValorEstimadoProximoConcurso = latestAPI.ValorEstimadoProximoConcurso
+		ComporResultado(latest)
 
 		if err := l.resultadoService.Save(latest); err != nil {
 			log.Printf("%s: ❌ Error updating contest %d: %v", loteria, latestDBConcurso, err)
 			return err
 		}
 		log.Printf("%s: ✓ Contest %d data updated", loteria, latestDBConcurso)
+		l.conferirBoloes(latest)
 		return nil
 	}
 
@@ -142,11 +148,13 @@ func (l *LoteriasUpdate) updateLoteria(loteria string) error {
 			}
 		}
 
+		ComporResultado(resultado)
 		if err := l.resultadoService.Save(resultado); err != nil {
 			log.Printf("%s: ❌ Error saving contest %d: %v", loteria, concurso, err)
 			// Não para, continua tentando outros
 		} else {
 			log.Printf("%s: ✓ Saved contest %d", loteria, concurso)
+			l.conferirBoloes(resultado)
 		}
 
 		concurso++
@@ -156,6 +164,17 @@ func (l *LoteriasUpdate) updateLoteria(loteria string) error {
 	return nil
 }
 
+// conferirBoloes confere o concurso gravado nos bilhetes dos bolões. Falhas
+// são apenas registradas para não interromper a atualização.
+func (l *LoteriasUpdate) conferirBoloes(resultado *model.Resultado) {
+	if l.bolaoService == nil {
+		return
+	}
+	if err := l.bolaoService.ConferirConcurso(resultado); err != nil {
+		log.Printf("%s: ⚠ Error checking pools for contest %d: %v", resultado.Loteria, resultado.Concurso, err)
+	}
+}
+
 func (l *LoteriasUpdate) UpdateOne(loteria string) error {
 	return l.updateLoteria(loteria)
 }
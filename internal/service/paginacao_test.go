@@ -0,0 +1,145 @@
+package service_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"loterias-api-golang/internal/model"
+)
+
+func TestParseCursor(t *testing.T) {
+	for _, cursor := range []model.Cursor{{Concurso: 3501}, {Concurso: 1, Anterior: true}} {
+		lido, err := model.ParseCursor(cursor.String())
+		if err != nil {
+			t.Fatalf("ParseCursor(%q): unexpected error: %v", cursor.String(), err)
+		}
+		if *lido != cursor {
+			t.Errorf("ParseCursor(%q) = %+v, want %+v", cursor.String(), *lido, cursor)
+		}
+	}
+
+	for _, valor := range []string{"", "!!!", "eyJjIjowfQ", "bm90IGpzb24"} {
+		if _, err := model.ParseCursor(valor); err == nil {
+			t.Errorf("ParseCursor(%q) error = nil, want an error", valor)
+		}
+	}
+}
+
+func TestProjetarResultado(t *testing.T) {
+	// Como vem da projeção no banco: só o _id e os campos pedidos.
+	resultado := model.Resultado{
+		ID:      model.ResultadoID{Loteria: "lotofacil", Concurso: 3520},
+		Data:    "25/10/2025",
+		Dezenas: []string{"01", "02"},
+	}
+
+	projetado, err := model.ProjetarResultado(resultado, []string{"data", "dezenas", "sorteios"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	dados, _ := json.Marshal(projetado)
+	want := `{"concurso":3520,"data":"25/10/2025","dezenas":["01","02"],"loteria":"lotofacil"}`
+	if string(dados) != want {
+		t.Errorf("ProjetarResultado = %s, want %s", dados, want)
+	}
+}
+
+func resultadosDosConcursos(concursos ...int) []model.Resultado {
+	resultados := make([]model.Resultado, len(concursos))
+	for i, c := range concursos {
+		resultados[i].ID = model.ResultadoID{Loteria: "megasena", Concurso: c}
+	}
+	return resultados
+}
+
+func concursosDaPagina(pagina *model.PaginaConcursos) []int {
+	concursos := make([]int, len(pagina.Resultados))
+	for i, r := range pagina.Resultados {
+		concursos[i] = r.ID.Concurso
+	}
+	return concursos
+}
+
+func TestPaginacao_MontarPagina(t *testing.T) {
+	tests := []struct {
+		name        string
+		paginacao   model.Paginacao
+		lidos       []int
+		sentido     int
+		want        []int
+		temAnterior bool
+		temProximo  bool
+	}{
+		{
+			name:       "primeira página com mais concursos",
+			paginacao:  model.Paginacao{Limite: 3, Ordem: model.OrdemDecrescente},
+			lidos:      []int{10, 9, 8, 7},
+			sentido:    -1,
+			want:       []int{10, 9, 8},
+			temProximo: true,
+		},
+		{
+			name:      "página única",
+			paginacao: model.Paginacao{Limite: 3, Ordem: model.OrdemCrescente},
+			lidos:     []int{1, 2},
+			sentido:   1,
+			want:      []int{1, 2},
+		},
+		{
+			name:        "última página depois de um cursor",
+			paginacao:   model.Paginacao{Limite: 3, Ordem: model.OrdemDecrescente, Cursor: &model.Cursor{Concurso: 4}},
+			lidos:       []int{3, 2, 1},
+			sentido:     -1,
+			want:        []int{3, 2, 1},
+			temAnterior: true,
+		},
+		{
+			// A página anterior é lida em ordem crescente a partir do cursor
+			// e revertida; o concurso extra indica mais páginas antes dela.
+			name:        "página anterior com mais concursos",
+			paginacao:   model.Paginacao{Limite: 3, Ordem: model.OrdemDecrescente, Cursor: &model.Cursor{Concurso: 4, Anterior: true}},
+			lidos:       []int{5, 6, 7, 8},
+			sentido:     1,
+			want:        []int{7, 6, 5},
+			temAnterior: true,
+			temProximo:  true,
+		},
+		{
+			name:       "primeira página voltando pelo cursor",
+			paginacao:  model.Paginacao{Limite: 3, Ordem: model.OrdemCrescente, Cursor: &model.Cursor{Concurso: 4, Anterior: true}},
+			lidos:      []int{3, 2, 1},
+			sentido:    -1,
+			want:       []int{1, 2, 3},
+			temProximo: true,
+		},
+		{
+			name:      "página anterior vazia",
+			paginacao: model.Paginacao{Limite: 3, Ordem: model.OrdemDecrescente, Cursor: &model.Cursor{Concurso: 10, Anterior: true}},
+			lidos:     []int{},
+			sentido:   1,
+			want:      []int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if sentido := tt.paginacao.Sentido(); sentido != tt.sentido {
+				t.Errorf("Sentido() = %d, want %d", sentido, tt.sentido)
+			}
+			pagina := tt.paginacao.MontarPagina(resultadosDosConcursos(tt.lidos...), 10)
+			got := concursosDaPagina(pagina)
+			if len(got) != len(tt.want) {
+				t.Fatalf("concursos = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("concursos = %v, want %v", got, tt.want)
+				}
+			}
+			if pagina.Total != 10 || pagina.TemAnterior != tt.temAnterior || pagina.TemProximo != tt.temProximo {
+				t.Errorf("pagina = {Total: %d, TemAnterior: %v, TemProximo: %v}, want {10 %v %v}",
+					pagina.Total, pagina.TemAnterior, pagina.TemProximo, tt.temAnterior, tt.temProximo)
+			}
+		})
+	}
+}
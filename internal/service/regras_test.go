@@ -0,0 +1,100 @@
+package service_test
+
+import (
+	"testing"
+
+	"loterias-api-golang/internal/model"
+)
+
+func TestRegras_Registro(t *testing.T) {
+	for _, loteria := range model.AllLoterias() {
+		regras, ok := model.GetRegras(loteria)
+		if !ok {
+			t.Errorf("%s: GetRegras ok = false, want every lottery registered", loteria)
+			continue
+		}
+		if string(regras.Loteria) != loteria || regras.Nome == "" || len(regras.DiasSorteio) == 0 {
+			t.Errorf("%s: Regras = %+v, want loteria, nome and dias de sorteio filled", loteria, regras)
+		}
+		if regras.MenorNumero >= regras.MaiorNumero || regras.NumerosSorteados < 1 || regras.Sorteios < 1 {
+			t.Errorf("%s: volante %d-%d with %d sorteados in %d sorteios is invalid",
+				loteria, regras.MenorNumero, regras.MaiorNumero, regras.NumerosSorteados, regras.Sorteios)
+		}
+		if regras.Tipo != model.TipoBilhete && (regras.MinNumeros > regras.NumerosApostaSimples || regras.NumerosApostaSimples > regras.MaxNumeros) {
+			t.Errorf("%s: aposta simples of %d outside %d-%d", loteria, regras.NumerosApostaSimples, regras.MinNumeros, regras.MaxNumeros)
+		}
+		for i, f := range regras.Faixas {
+			if f.Faixa != i+1 {
+				t.Errorf("%s: Faixas[%d].Faixa = %d, want %d", loteria, i, f.Faixa, i+1)
+			}
+			if f.Sorteio < 1 || f.Sorteio > regras.Sorteios {
+				t.Errorf("%s: faixa %d belongs to sorteio %d of %d", loteria, f.Faixa, f.Sorteio, regras.Sorteios)
+			}
+		}
+	}
+
+	if _, ok := model.GetRegras("loto"); ok {
+		t.Error("GetRegras(loto) ok = true, want false")
+	}
+	if regras, _ := model.GetRegras("duplasena"); len(regras.FaixasDoSorteio(2)) != 4 {
+		t.Errorf("Dupla Sena FaixasDoSorteio(2) = %+v, want 4 faixas", regras.FaixasDoSorteio(2))
+	}
+	if regras, _ := model.GetRegras("diadesorte"); !regras.MesSorte {
+		t.Error("Dia de Sorte MesSorte = false, want true")
+	}
+}
+
+func TestRegras_Precos(t *testing.T) {
+	testes := []struct {
+		loteria string
+		numeros int
+		trevos  int
+		apostas int64
+		valor   float64
+	}{
+		{"megasena", 6, 0, 1, 6},
+		{"megasena", 7, 0, 7, 42},
+		{"megasena", 20, 0, 38760, 232560},
+		{"lotofacil", 16, 0, 16, 56},
+		{"quina", 15, 0, 3003, 9009},
+		{"lotomania", 50, 0, 1, 3},
+		{"maismilionaria", 6, 2, 1, 6},
+		{"maismilionaria", 7, 3, 21, 126},
+		{"maismilionaria", 12, 6, 13860, 83160},
+	}
+	for _, tt := range testes {
+		regras, _ := model.GetRegras(tt.loteria)
+		encontrado := false
+		for _, p := range regras.Precos {
+			if p.Numeros != tt.numeros || p.Trevos != tt.trevos {
+				continue
+			}
+			encontrado = true
+			if p.ApostasSimples != tt.apostas || p.Valor != tt.valor {
+				t.Errorf("%s %d+%d: preço = %+v, want %d apostas and %v", tt.loteria, tt.numeros, tt.trevos, p, tt.apostas, tt.valor)
+			}
+		}
+		if !encontrado {
+			t.Errorf("%s %d+%d: no preço in the table", tt.loteria, tt.numeros, tt.trevos)
+		}
+	}
+
+	megasena, _ := model.GetRegras("megasena")
+	if len(megasena.Precos) != 15 {
+		t.Errorf("Mega-Sena has %d preços, want one per quantity from 6 to 20", len(megasena.Precos))
+	}
+	maisMilionaria, _ := model.GetRegras("maismilionaria")
+	if len(maisMilionaria.Precos) != 7*5 {
+		t.Errorf("+Milionária has %d preços, want 35 combinations of números and trevos", len(maisMilionaria.Precos))
+	}
+	for _, loteria := range []string{"federal", "supersete"} {
+		if regras, _ := model.GetRegras(loteria); regras.Precos != nil {
+			t.Errorf("%s Precos = %+v, want none", loteria, regras.Precos)
+		}
+	}
+
+	supersete, _ := model.GetRegras("supersete")
+	if preco := supersete.PrecoColunas([]int{1, 2, 1, 1, 3, 1, 1}); preco != 18 {
+		t.Errorf("Super Sete PrecoColunas = %v, want 18", preco)
+	}
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"loterias-api-golang/internal/model"
+)
+
+const (
+	limitePadraoBusca = 50
+	limiteMaximoBusca = 500
+)
+
+// Buscar retorna os concursos em que saíram os números procurados, do que
+// teve mais acertos para o que teve menos.
+func (s *ResultadoService) Buscar(loteria string, pedido model.PedidoBusca) (*model.Busca, error) {
+	regras, err := regrasNumericas(loteria)
+	if err != nil {
+		return nil, err
+	}
+
+	criterio, err := PrepararBusca(regras, pedido)
+	if err != nil {
+		return nil, err
+	}
+
+	encontrados, err := s.repository.BuscarNumeros(loteria, criterio)
+	if err != nil {
+		return nil, err
+	}
+
+	busca := &model.Busca{
+		Loteria:   loteria,
+		Min:       criterio.Min,
+		MinTrevos: criterio.MinTrevos,
+		Total:     encontrados.Total,
+		Concursos: encontrados.Concursos,
+	}
+	for _, d := range criterio.Dezenas {
+		busca.Dezenas = append(busca.Dezenas, formatarDezena(d))
+	}
+	for _, t := range criterio.Trevos {
+		busca.Trevos = append(busca.Trevos, strconv.Itoa(t))
+	}
+	for _, coluna := range colunasOrdenadas(criterio.Colunas) {
+		busca.Colunas = append(busca.Colunas, model.ColunaBuscada{Coluna: coluna + 1, Numero: strconv.Itoa(criterio.Colunas[coluna])})
+	}
+
+	return busca, nil
+}
+
+// PrepararBusca valida os números procurados contra as regras do jogo. Na
+// Super Sete a busca é feita por coluna; nas demais, por dezenas e trevos,
+// cada um com seu mínimo de acertos.
+func PrepararBusca(regras model.Regras, pedido model.PedidoBusca) (model.CriterioBusca, error) {
+	criterio := model.CriterioBusca{Limite: pedido.Limite}
+	if criterio.Limite == 0 {
+		criterio.Limite = limitePadraoBusca
+	}
+	if criterio.Limite < 1 || criterio.Limite > limiteMaximoBusca {
+		return criterio, &model.ParametroInvalidoException{
+			Message: fmt.Sprintf("O limite deve estar entre 1 e %d", limiteMaximoBusca),
+		}
+	}
+
+	var procurados int
+	if regras.Tipo == model.TipoColunas {
+		if len(pedido.Dezenas) > 0 || len(pedido.Trevos) > 0 {
+			return criterio, &model.ParametroInvalidoException{Message: "Na loteria " + string(regras.Loteria) + " a busca é feita por colunas (coluna:número)"}
+		}
+		colunas, err := parseColunasBusca(regras, pedido.Colunas)
+		if err != nil {
+			return criterio, err
+		}
+		criterio.Colunas = colunas
+		procurados = len(colunas)
+	} else {
+		if len(pedido.Colunas) > 0 {
+			return criterio, &model.ParametroInvalidoException{Message: "Busca por colunas disponível apenas na Super Sete"}
+		}
+		dezenas, err := parseNumeros(pedido.Dezenas, regras.MenorNumero, regras.MaiorNumero, "dezena")
+		if err != nil {
+			return criterio, err
+		}
+		if len(dezenas) > regras.NumerosSorteados*regras.Sorteios {
+			return criterio, &model.ParametroInvalidoException{
+				Message: fmt.Sprintf("Informe no máximo %d dezenas", regras.NumerosSorteados*regras.Sorteios),
+			}
+		}
+		criterio.Dezenas = dezenas
+		procurados = len(dezenas)
+
+		if len(pedido.Trevos) > 0 {
+			if regras.Trevos == nil {
+				return criterio, &model.ParametroInvalidoException{Message: "A loteria " + string(regras.Loteria) + " não sorteia trevos"}
+			}
+			trevos, err := parseNumeros(pedido.Trevos, regras.Trevos.MenorNumero, regras.Trevos.MaiorNumero, "trevo")
+			if err != nil {
+				return criterio, err
+			}
+			criterio.Trevos = trevos
+		}
+	}
+
+	if procurados == 0 && len(criterio.Trevos) == 0 {
+		return criterio, &model.ParametroInvalidoException{Message: "Informe ao menos um número para a busca"}
+	}
+
+	criterio.Min = pedido.Min
+	if criterio.Min == 0 {
+		criterio.Min = procurados
+	}
+	if procurados > 0 && (criterio.Min < 1 || criterio.Min > procurados) {
+		return criterio, &model.ParametroInvalidoException{
+			Message: fmt.Sprintf("O parâmetro 'min' deve estar entre 1 e %d", procurados),
+		}
+	}
+
+	criterio.MinTrevos = pedido.MinTrevos
+	if criterio.MinTrevos == 0 {
+		criterio.MinTrevos = len(criterio.Trevos)
+	}
+	if criterio.MinTrevos > len(criterio.Trevos) {
+		return criterio, &model.ParametroInvalidoException{
+			Message: fmt.Sprintf("O parâmetro 'minTrevos' deve estar entre 1 e %d", len(criterio.Trevos)),
+		}
+	}
+
+	return criterio, nil
+}
+
+// parseColunasBusca lê pares "coluna:número" da Super Sete, com colunas a
+// partir de 1.
+func parseColunasBusca(regras model.Regras, valores []string) (map[int]int, error) {
+	colunas := make(map[int]int, len(valores))
+	for _, valor := range valores {
+		partes := strings.SplitN(valor, ":", 2)
+		if len(partes) != 2 {
+			return nil, &model.ParametroInvalidoException{Message: fmt.Sprintf("'%s' deve estar no formato coluna:número", valor)}
+		}
+		coluna, err := strconv.Atoi(strings.TrimSpace(partes[0]))
+		if err != nil || coluna < 1 || coluna > regras.Colunas.Quantidade {
+			return nil, &model.ParametroInvalidoException{
+				Message: fmt.Sprintf("A coluna deve estar entre 1 e %d", regras.Colunas.Quantidade),
+			}
+		}
+		numeros, err := parseNumeros([]string{partes[1]}, regras.MenorNumero, regras.MaiorNumero, "número")
+		if err != nil {
+			return nil, err
+		}
+		if _, repetida := colunas[coluna-1]; repetida {
+			return nil, &model.ParametroInvalidoException{Message: fmt.Sprintf("A coluna %d foi informada mais de uma vez", coluna)}
+		}
+		colunas[coluna-1] = numeros[0]
+	}
+	return colunas, nil
+}
+
+// colunasOrdenadas retorna as posições das colunas procuradas em ordem.
+func colunasOrdenadas(colunas map[int]int) []int {
+	posicoes := make([]int, 0, len(colunas))
+	for coluna := range colunas {
+		posicoes = append(posicoes, coluna)
+	}
+	sort.Ints(posicoes)
+	return posicoes
+}
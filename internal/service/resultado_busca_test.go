@@ -0,0 +1,62 @@
+package service_test
+
+import (
+	"testing"
+
+	"loterias-api-golang/internal/model"
+	"loterias-api-golang/internal/service"
+)
+
+func TestPrepararBusca(t *testing.T) {
+	megaSena, _ := model.GetRegras("megasena")
+	maisMilionaria, _ := model.GetRegras("maismilionaria")
+	superSete, _ := model.GetRegras("supersete")
+
+	criterio, err := service.PrepararBusca(megaSena, model.PedidoBusca{Dezenas: []string{"33", "4", "15"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if criterio.Min != 3 || criterio.Limite != 50 {
+		t.Errorf("Min = %d, Limite = %d, want 3 and 50", criterio.Min, criterio.Limite)
+	}
+	if len(criterio.Dezenas) != 3 || criterio.Dezenas[0] != 4 {
+		t.Errorf("Dezenas = %v, want ordered [4 15 33]", criterio.Dezenas)
+	}
+
+	criterio, err = service.PrepararBusca(maisMilionaria, model.PedidoBusca{Dezenas: []string{"10", "20"}, Trevos: []string{"1", "2"}, Min: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if criterio.Min != 1 || criterio.MinTrevos != 2 {
+		t.Errorf("Min = %d, MinTrevos = %d, want 1 and 2", criterio.Min, criterio.MinTrevos)
+	}
+
+	criterio, err = service.PrepararBusca(superSete, model.PedidoBusca{Colunas: []string{"1:5", "3:0"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if criterio.Colunas[0] != 5 || criterio.Colunas[2] != 0 || criterio.Min != 2 {
+		t.Errorf("Colunas = %v, Min = %d, want map[0:5 2:0] and 2", criterio.Colunas, criterio.Min)
+	}
+
+	invalidos := []struct {
+		name   string
+		regras model.Regras
+		pedido model.PedidoBusca
+	}{
+		{"Sem números", megaSena, model.PedidoBusca{}},
+		{"Min acima das dezenas", megaSena, model.PedidoBusca{Dezenas: []string{"1", "2"}, Min: 3}},
+		{"Trevos fora da +Milionária", megaSena, model.PedidoBusca{Dezenas: []string{"1"}, Trevos: []string{"1"}}},
+		{"Dezenas na Super Sete", superSete, model.PedidoBusca{Dezenas: []string{"1"}}},
+		{"Coluna inexistente", superSete, model.PedidoBusca{Colunas: []string{"8:1"}}},
+		{"Coluna repetida", superSete, model.PedidoBusca{Colunas: []string{"1:1", "1:2"}}},
+		{"Limite excessivo", megaSena, model.PedidoBusca{Dezenas: []string{"1"}, Limite: 501}},
+	}
+	for _, tt := range invalidos {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := service.PrepararBusca(tt.regras, tt.pedido); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
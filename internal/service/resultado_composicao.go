@@ -0,0 +1,191 @@
+package service
+
+import (
+	"log"
+	"strconv"
+	"strings"
+
+	"loterias-api-golang/internal/model"
+)
+
+// MigrarComposicao calcula e grava a composição dos resultados gravados
+// antes do cálculo na ingestão. Retorna quantos resultados foram migrados.
+func (s *ResultadoService) MigrarComposicao() (int, error) {
+	total := 0
+	for _, loteria := range model.AllLoterias() {
+		regras, _ := model.GetRegras(loteria)
+		if regras.Tipo == model.TipoBilhete {
+			continue
+		}
+
+		resultados, err := s.repository.FindSemComposicao(loteria)
+		if err != nil {
+			return total, err
+		}
+
+		var migrados []model.Resultado
+		for i := range resultados {
+			ComporResultado(&resultados[i])
+			if len(resultados[i].Composicao) > 0 {
+				migrados = append(migrados, resultados[i])
+			}
+		}
+		if err := s.repository.GravarComposicoes(migrados); err != nil {
+			return total, err
+		}
+		if len(migrados) > 0 {
+			log.Printf("%s: composição calculada em %d concursos", loteria, len(migrados))
+		}
+		total += len(migrados)
+	}
+	return total, nil
+}
+
+// ComporResultado calcula a composição de cada sorteio do resultado. Não faz
+// nada na Federal nem em resultados ainda sem dezenas.
+func ComporResultado(resultado *model.Resultado) {
+	regras, ok := model.GetRegras(resultado.ID.Loteria)
+	if !ok || regras.Tipo == model.TipoBilhete || len(resultado.Dezenas) == 0 {
+		return
+	}
+	if regras.Sorteios > 1 && len(resultado.Sorteios) == 0 {
+		resultado.MontarSorteios()
+		if len(resultado.Sorteios) == 0 {
+			return
+		}
+	}
+
+	composicao := make([]model.Composicao, 0, regras.Sorteios)
+	for sorteio := 1; sorteio <= regras.Sorteios; sorteio++ {
+		c := CalcularComposicao(regras, resultado.DezenasDoSorteio(sorteio))
+		c.Sorteio = sorteio
+		composicao = append(composicao, c)
+	}
+	resultado.Composicao = composicao
+}
+
+// CalcularComposicao descreve os números sorteados: soma, pares e ímpares,
+// primos, números de Fibonacci, quantidade por década, linha e coluna do
+// volante e sequências de números seguidos. Na Super Sete cada coluna
+// contribui com seu número, mesmo repetido, e grupos e sequências ficam de
+// fora.
+func CalcularComposicao(regras model.Regras, dezenas []string) model.Composicao {
+	var numeros []int
+	if regras.Tipo == model.TipoColunas {
+		for _, d := range dezenas {
+			if n, err := strconv.Atoi(strings.TrimSpace(d)); err == nil {
+				numeros = append(numeros, n)
+			}
+		}
+	} else {
+		numeros = parseDezenas(dezenas)
+	}
+	validos := numeros[:0]
+	for _, n := range numeros {
+		if n >= regras.MenorNumero && n <= regras.MaiorNumero {
+			validos = append(validos, n)
+		}
+	}
+	numeros = validos
+
+	fibonacci := numerosFibonacci(regras.MaiorNumero)
+	composicao := model.Composicao{}
+	for _, n := range numeros {
+		composicao.Soma += n
+		if n%2 == 0 {
+			composicao.Pares++
+		} else {
+			composicao.Impares++
+		}
+		if primo(n) {
+			composicao.Primos++
+		}
+		if fibonacci[n] {
+			composicao.Fibonacci++
+		}
+	}
+	if regras.Tipo == model.TipoColunas {
+		return composicao
+	}
+
+	composicao.Decadas = make([]int, (regras.MaiorNumero-regras.MenorNumero)/10+1)
+	if regras.NumerosPorLinha > 0 {
+		composicao.Linhas = make([]int, (regras.MaiorNumero-regras.MenorNumero)/regras.NumerosPorLinha+1)
+		composicao.Colunas = make([]int, regras.NumerosPorLinha)
+	}
+	sequencia := 0
+	for i, n := range numeros {
+		posicao := n - regras.MenorNumero
+		composicao.Decadas[posicao/10]++
+		if regras.NumerosPorLinha > 0 {
+			composicao.Linhas[posicao/regras.NumerosPorLinha]++
+			composicao.Colunas[posicao%regras.NumerosPorLinha]++
+		}
+
+		sequencia++
+		if i > 0 && numeros[i-1] == n-1 {
+			composicao.Consecutivos++
+		} else {
+			sequencia = 1
+		}
+		if sequencia > composicao.MaiorSequencia {
+			composicao.MaiorSequencia = sequencia
+		}
+	}
+	return composicao
+}
+
+// gruposComposicao retorna o rótulo de cada década e de cada linha e coluna
+// do volante, na ordem dos contadores da composição.
+func gruposComposicao(regras model.Regras) (decadas, linhas, colunas []string) {
+	intervalo := func(inicio, tamanho int) string {
+		fim := inicio + tamanho - 1
+		if fim > regras.MaiorNumero {
+			fim = regras.MaiorNumero
+		}
+		if fim == inicio {
+			return formatarDezena(inicio)
+		}
+		return formatarDezena(inicio) + "-" + formatarDezena(fim)
+	}
+
+	for inicio := regras.MenorNumero; inicio <= regras.MaiorNumero; inicio += 10 {
+		decadas = append(decadas, intervalo(inicio, 10))
+	}
+	if regras.NumerosPorLinha == 0 {
+		return decadas, nil, nil
+	}
+	for inicio := regras.MenorNumero; inicio <= regras.MaiorNumero; inicio += regras.NumerosPorLinha {
+		linhas = append(linhas, intervalo(inicio, regras.NumerosPorLinha))
+	}
+	for coluna := 0; coluna < regras.NumerosPorLinha; coluna++ {
+		var numeros []string
+		for n := regras.MenorNumero + coluna; n <= regras.MaiorNumero; n += regras.NumerosPorLinha {
+			numeros = append(numeros, formatarDezena(n))
+		}
+		colunas = append(colunas, strings.Join(numeros, "/"))
+	}
+	return decadas, linhas, colunas
+}
+
+func primo(n int) bool {
+	if n < 2 {
+		return false
+	}
+	for d := 2; d*d <= n; d++ {
+		if n%d == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// numerosFibonacci marca os números da sequência de Fibonacci (0, 1, 2, 3,
+// 5, 8, ...) até o limite.
+func numerosFibonacci(limite int) map[int]bool {
+	fibonacci := map[int]bool{}
+	for a, b := 0, 1; a <= limite; a, b = b, a+b {
+		fibonacci[a] = true
+	}
+	return fibonacci
+}
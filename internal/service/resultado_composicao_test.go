@@ -0,0 +1,89 @@
+package service_test
+
+import (
+	"reflect"
+	"testing"
+
+	"loterias-api-golang/internal/model"
+	"loterias-api-golang/internal/service"
+)
+
+func TestCalcularComposicao(t *testing.T) {
+	megaSena, _ := model.MegaSena.Regras()
+	lotofacil, _ := model.Lotofacil.Regras()
+	superSete, _ := model.SuperSete.Regras()
+
+	tests := []struct {
+		name    string
+		regras  model.Regras
+		dezenas []string
+		want    model.Composicao
+	}{
+		{
+			name:    "Mega-Sena",
+			regras:  megaSena,
+			dezenas: []string{"60", "05", "23", "04", "37", "06"},
+			want: model.Composicao{
+				Soma: 135, Pares: 3, Impares: 3, Primos: 3, Fibonacci: 1,
+				Decadas:      []int{3, 0, 1, 1, 0, 1},
+				Linhas:       []int{3, 0, 1, 1, 0, 1},
+				Colunas:      []int{0, 0, 1, 1, 1, 1, 1, 0, 0, 1},
+				Consecutivos: 2, MaiorSequencia: 3,
+			},
+		},
+		{
+			name:    "Lotofácil",
+			regras:  lotofacil,
+			dezenas: []string{"01", "02", "03", "04", "05", "06", "07", "08", "09", "10", "11", "12", "13", "14", "15"},
+			want: model.Composicao{
+				Soma: 120, Pares: 7, Impares: 8, Primos: 6, Fibonacci: 6,
+				Decadas:      []int{10, 5, 0},
+				Linhas:       []int{5, 5, 5, 0, 0},
+				Colunas:      []int{3, 3, 3, 3, 3},
+				Consecutivos: 14, MaiorSequencia: 15,
+			},
+		},
+		{
+			name:    "Super Sete conta números repetidos",
+			regras:  superSete,
+			dezenas: []string{"3", "3", "0", "8", "1", "5", "2"},
+			want:    model.Composicao{Soma: 22, Pares: 3, Impares: 4, Primos: 4, Fibonacci: 7},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := service.CalcularComposicao(tt.regras, tt.dezenas)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CalcularComposicao() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComporResultado(t *testing.T) {
+	duplaSena := &model.Resultado{
+		ID:      model.ResultadoID{Loteria: "duplasena", Concurso: 1},
+		Dezenas: []string{"01", "02", "03", "04", "05", "06", "10", "20", "30", "40", "49", "50"},
+	}
+	service.ComporResultado(duplaSena)
+
+	if len(duplaSena.Composicao) != 2 {
+		t.Fatalf("composição = %+v, want um item por sorteio", duplaSena.Composicao)
+	}
+	if c := duplaSena.Composicao[0]; c.Sorteio != 1 || c.Soma != 21 || c.MaiorSequencia != 6 {
+		t.Errorf("1º sorteio = %+v, want soma 21 e sequência de 6", c)
+	}
+	if c := duplaSena.Composicao[1]; c.Sorteio != 2 || c.Soma != 199 || c.Consecutivos != 1 {
+		t.Errorf("2º sorteio = %+v, want soma 199 e 1 par seguido", c)
+	}
+
+	federal := &model.Resultado{
+		ID:      model.ResultadoID{Loteria: "federal", Concurso: 1},
+		Dezenas: []string{"012345", "054321"},
+	}
+	service.ComporResultado(federal)
+	if federal.Composicao != nil {
+		t.Errorf("Federal composição = %+v, want nil", federal.Composicao)
+	}
+}
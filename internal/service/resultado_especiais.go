@@ -0,0 +1,184 @@
+package service
+
+import (
+	"fmt"
+	"log"
+
+	"loterias-api-golang/internal/model"
+)
+
+// quedaReservaEspecial é a fração da reserva do concurso anterior abaixo da
+// qual se considera que a reserva foi paga, o que marca o concurso especial
+// nos resultados gravados antes da marcação.
+const quedaReservaEspecial = 0.5
+
+// MigrarEspeciais marca os concursos especiais nos resultados gravados antes
+// da marcação, a partir da queda da reserva do concurso especial. Retorna
+// quantos resultados foram alterados.
+func (s *ResultadoService) MigrarEspeciais() (int64, error) {
+	var total int64
+	for _, loteria := range model.AllLoterias() {
+		regras, _ := model.GetRegras(loteria)
+		if regras.ConcursoEspecial == "" {
+			continue
+		}
+
+		reservas, err := s.repository.ListarReservas(loteria)
+		if err != nil {
+			return total, err
+		}
+		if !semMarcacaoEspecial(reservas) {
+			continue
+		}
+
+		especiais := DetectarEspeciais(reservas)
+		alterados, err := s.repository.MarcarEspeciais(loteria, especiais, regras.ConcursoEspecial)
+		if err != nil {
+			return total, err
+		}
+		log.Printf("%s: %d concursos especiais identificados", loteria, len(especiais))
+		total += alterados
+	}
+	return total, nil
+}
+
+func semMarcacaoEspecial(reservas []model.ReservaConcurso) bool {
+	for _, r := range reservas {
+		if r.Especial == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// DetectarEspeciais retorna os concursos especiais da série. Resultados já
+// marcados são respeitados; nos demais, o concurso é especial quando a
+// reserva cai abaixo da metade da informada no concurso imediatamente
+// anterior, já que ela é paga no especial e recomeça em seguida.
+func DetectarEspeciais(reservas []model.ReservaConcurso) []int {
+	especiais := []int{}
+	for i, r := range reservas {
+		if r.Especial != nil {
+			if *r.Especial {
+				especiais = append(especiais, r.Concurso)
+			}
+			continue
+		}
+		if i == 0 {
+			continue
+		}
+		anterior := reservas[i-1]
+		if anterior.Concurso == r.Concurso-1 && anterior.Valor > 0 && r.Valor < anterior.Valor*quedaReservaEspecial {
+			especiais = append(especiais, r.Concurso)
+		}
+	}
+	return especiais
+}
+
+// regrasEspeciais retorna as regras de loterias que têm concurso especial.
+func regrasEspeciais(loteria string) (model.Regras, error) {
+	regras, ok := model.GetRegras(loteria)
+	if !ok {
+		return regras, &model.LoteriaInvalidException{Message: fmt.Sprintf("'%s' não é uma loteria suportada", loteria)}
+	}
+	if regras.ConcursoEspecial == "" {
+		return regras, &model.ParametroInvalidoException{Message: "A loteria " + loteria + " não tem concurso especial"}
+	}
+	return regras, nil
+}
+
+// Especiais retorna os concursos especiais da loteria, do mais recente para
+// o mais antigo.
+func (s *ResultadoService) Especiais(loteria string) ([]model.ConcursoEspecial, error) {
+	if _, err := regrasEspeciais(loteria); err != nil {
+		return nil, err
+	}
+
+	resultados, err := s.repository.FindEspeciais(loteria)
+	if err != nil {
+		return nil, err
+	}
+
+	especiais := make([]model.ConcursoEspecial, 0, len(resultados))
+	for _, resultado := range resultados {
+		especiais = append(especiais, resumirEspecial(resultado))
+	}
+	return especiais, nil
+}
+
+// ReservaEspecial mostra a reserva acumulada para o próximo concurso
+// especial e sua evolução desde o último.
+func (s *ResultadoService) ReservaEspecial(loteria string) (*model.ReservaEspecial, error) {
+	regras, err := regrasEspeciais(loteria)
+	if err != nil {
+		return nil, err
+	}
+
+	reservas, err := s.repository.ListarReservas(loteria)
+	if err != nil {
+		return nil, err
+	}
+
+	reserva := MontarReservaEspecial(reservas)
+	reserva.Loteria = loteria
+	reserva.TipoEspecial = regras.ConcursoEspecial
+
+	if reserva.UltimoEspecial != nil {
+		resultado, err := s.repository.FindByID(loteria, reserva.UltimoEspecial.Concurso)
+		if err != nil {
+			return nil, err
+		}
+		if resultado != nil {
+			ultimo := resumirEspecial(*resultado)
+			reserva.UltimoEspecial = &ultimo
+		}
+	}
+
+	return reserva, nil
+}
+
+// MontarReservaEspecial separa a evolução da reserva depois do último
+// concurso especial. UltimoEspecial traz apenas concurso e data; a
+// premiação é completada por quem tem acesso ao resultado.
+func MontarReservaEspecial(reservas []model.ReservaConcurso) *model.ReservaEspecial {
+	reserva := &model.ReservaEspecial{Evolucao: []model.ReservaConcurso{}}
+	if len(reservas) == 0 {
+		return reserva
+	}
+
+	inicio := 0
+	valorInicial := 0.0
+	for i, r := range reservas {
+		if r.Especial != nil && *r.Especial {
+			inicio = i + 1
+			valorInicial = r.Valor
+			reserva.UltimoEspecial = &model.ConcursoEspecial{Concurso: r.Concurso, Data: r.Data}
+		}
+	}
+
+	reserva.Evolucao = append(reserva.Evolucao, reservas[inicio:]...)
+	reserva.ConcursosDesde = len(reserva.Evolucao)
+	reserva.ValorAtual = arredondarCentavos(reservas[len(reservas)-1].Valor)
+	if reserva.ConcursosDesde > 0 {
+		reserva.CrescimentoMedio = arredondarCentavos((reserva.ValorAtual - valorInicial) / float64(reserva.ConcursosDesde))
+	}
+	return reserva
+}
+
+func resumirEspecial(resultado model.Resultado) model.ConcursoEspecial {
+	especial := model.ConcursoEspecial{
+		Concurso:        resultado.ID.Concurso,
+		Data:            resultado.Data,
+		TipoEspecial:    resultado.TipoEspecial,
+		Dezenas:         resultado.Dezenas,
+		ValorArrecadado: resultado.ValorArrecadado,
+	}
+	for _, p := range resultado.Premiacoes {
+		if p.Faixa == 1 {
+			especial.Ganhadores = p.NumeroDeGanhadores
+			especial.PremioPrincipal = p.Valor
+			break
+		}
+	}
+	return especial
+}
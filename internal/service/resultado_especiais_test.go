@@ -0,0 +1,51 @@
+package service_test
+
+import (
+	"testing"
+
+	"loterias-api-golang/internal/model"
+	"loterias-api-golang/internal/service"
+)
+
+func TestDetectarEspeciais(t *testing.T) {
+	sim, nao := true, false
+	reservas := []model.ReservaConcurso{
+		{Concurso: 1, Valor: 0},
+		{Concurso: 2, Valor: 1000},
+		{Concurso: 3, Valor: 2000},
+		{Concurso: 4, Valor: 50},
+		{Concurso: 5, Valor: 900},
+		{Concurso: 7, Valor: 100},
+		{Concurso: 8, Valor: 10, Especial: &nao},
+		{Concurso: 9, Valor: 200, Especial: &sim},
+	}
+
+	especiais := service.DetectarEspeciais(reservas)
+	if len(especiais) != 2 || especiais[0] != 4 || especiais[1] != 9 {
+		t.Errorf("DetectarEspeciais = %v, want [4 9]", especiais)
+	}
+}
+
+func TestMontarReservaEspecial(t *testing.T) {
+	sim := true
+	reservas := []model.ReservaConcurso{
+		{Concurso: 10, Valor: 5000},
+		{Concurso: 11, Data: "31/12/2024", Valor: 100, Especial: &sim},
+		{Concurso: 12, Valor: 400},
+		{Concurso: 13, Valor: 700},
+	}
+
+	reserva := service.MontarReservaEspecial(reservas)
+	if reserva.UltimoEspecial == nil || reserva.UltimoEspecial.Concurso != 11 {
+		t.Fatalf("UltimoEspecial = %+v, want contest 11", reserva.UltimoEspecial)
+	}
+	if reserva.ConcursosDesde != 2 || reserva.ValorAtual != 700 || reserva.CrescimentoMedio != 300 {
+		t.Errorf("ConcursosDesde = %d, ValorAtual = %v, CrescimentoMedio = %v, want 2, 700 and 300",
+			reserva.ConcursosDesde, reserva.ValorAtual, reserva.CrescimentoMedio)
+	}
+
+	semEspecial := service.MontarReservaEspecial(reservas[:1])
+	if semEspecial.UltimoEspecial != nil || semEspecial.ConcursosDesde != 1 {
+		t.Errorf("reserva = %+v, want whole history without a special contest", semEspecial)
+	}
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"sort"
+	"time"
+
+	"loterias-api-golang/internal/model"
+)
+
+// Proximos retorna o próximo concurso de cada loteria, do sorteio mais
+// próximo para o mais distante.
+func (s *ResultadoService) Proximos() ([]model.ProximoSorteio, error) {
+	ultimos, err := s.repository.FindUltimos()
+	if err != nil {
+		return nil, err
+	}
+	return MontarProximos(ultimos, time.Now()), nil
+}
+
+// MontarProximos monta o calendário a partir do último resultado de cada
+// loteria, ordenado pela data do sorteio. Loterias sem resultado, sem data
+// do próximo concurso ou cujo próximo sorteio já passou há mais que
+// ToleranciaProximoSorteio são marcadas como desatualizadas; as sem data vão
+// para o fim da lista.
+func MontarProximos(ultimos []model.Resultado, agora time.Time) []model.ProximoSorteio {
+	porLoteria := make(map[string]model.Resultado, len(ultimos))
+	for _, resultado := range ultimos {
+		porLoteria[resultado.ID.Loteria] = resultado
+	}
+
+	proximos := make([]model.ProximoSorteio, 0, len(model.AllLoterias()))
+	for _, loteria := range model.AllLoterias() {
+		regras, _ := model.GetRegras(loteria)
+		proximo := model.ProximoSorteio{Loteria: loteria, Nome: regras.Nome}
+
+		resultado, ok := porLoteria[loteria]
+		if !ok {
+			proximo.Desatualizado = true
+			proximo.Motivo = "Nenhum resultado armazenado"
+			proximos = append(proximos, proximo)
+			continue
+		}
+
+		proximo.Concurso = resultado.ProximoConcurso
+		if proximo.Concurso == 0 {
+			proximo.Concurso = resultado.ID.Concurso + 1
+		}
+		proximo.Data = resultado.DataProximoConcurso
+		proximo.DataSorteio = resultado.DataProximoSorteio
+		proximo.ValorEstimado = resultado.ValorEstimadoProximoConcurso
+		proximo.Acumulado = resultado.Acumulou
+		proximo.ValorAcumulado = resultado.ValorAcumuladoProximoConcurso
+		proximo.UltimoConcurso = resultado.ID.Concurso
+		proximo.DataUltimoConcurso = resultado.Data
+
+		switch {
+		case proximo.DataSorteio == nil:
+			proximo.Desatualizado = true
+			proximo.Motivo = "Data do próximo concurso não informada"
+		case agora.After(proximo.DataSorteio.Add(model.ToleranciaProximoSorteio)):
+			proximo.Desatualizado = true
+			proximo.Motivo = "O sorteio de " + proximo.Data + " ainda não foi importado"
+		}
+
+		proximos = append(proximos, proximo)
+	}
+
+	sort.SliceStable(proximos, func(i, j int) bool {
+		a, b := proximos[i], proximos[j]
+		if a.DataSorteio == nil || b.DataSorteio == nil {
+			return a.DataSorteio != nil
+		}
+		return a.DataSorteio.Before(*b.DataSorteio)
+	})
+
+	return proximos
+}
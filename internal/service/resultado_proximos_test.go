@@ -0,0 +1,52 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"loterias-api-golang/internal/model"
+	"loterias-api-golang/internal/service"
+)
+
+func TestMontarProximos(t *testing.T) {
+	agora := time.Date(2025, 10, 28, 15, 0, 0, 0, model.FusoBrasilia)
+	ultimos := []model.Resultado{
+		{ID: model.ResultadoID{Loteria: "megasena", Concurso: 2930}, Data: "25/10/2025",
+			ProximoConcurso: 2931, DataProximoConcurso: "28/10/2025", ValorEstimadoProximoConcurso: 3500000},
+		{ID: model.ResultadoID{Loteria: "lotofacil", Concurso: 3520}, Data: "27/10/2025",
+			ProximoConcurso: 3521, DataProximoConcurso: "28/10/2025"},
+		{ID: model.ResultadoID{Loteria: "quina", Concurso: 6860}, Data: "24/10/2025",
+			ProximoConcurso: 6861, DataProximoConcurso: "25/10/2025"},
+		{ID: model.ResultadoID{Loteria: "timemania", Concurso: 2300}, Data: "23/10/2025"},
+	}
+	for i := range ultimos {
+		ultimos[i].AfterFind()
+	}
+
+	proximos := service.MontarProximos(ultimos, agora)
+	if len(proximos) != len(model.AllLoterias()) {
+		t.Fatalf("len = %d, want one row per lottery", len(proximos))
+	}
+
+	porLoteria := make(map[string]model.ProximoSorteio)
+	for _, p := range proximos {
+		porLoteria[p.Loteria] = p
+	}
+
+	if p := porLoteria["megasena"]; p.Concurso != 2931 || p.Desatualizado || p.ValorEstimado != 3500000 {
+		t.Errorf("megasena = %+v, want contest 2931 up to date", p)
+	}
+	if p := porLoteria["quina"]; !p.Desatualizado {
+		t.Errorf("quina = %+v, want stale after the 25/10 draw", p)
+	}
+	if p := porLoteria["timemania"]; !p.Desatualizado || p.Concurso != 2301 {
+		t.Errorf("timemania = %+v, want stale contest 2301 without date", p)
+	}
+	if p := porLoteria["federal"]; !p.Desatualizado || p.Concurso != 0 {
+		t.Errorf("federal = %+v, want stale without stored results", p)
+	}
+
+	if proximos[0].Loteria != "quina" || proximos[len(proximos)-1].DataSorteio != nil {
+		t.Errorf("order = %s ... %s, want earliest date first and undated last", proximos[0].Loteria, proximos[len(proximos)-1].Loteria)
+	}
+}
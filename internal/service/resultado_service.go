@@ -1,6 +1,8 @@
 package service
 
 import (
+	"time"
+
 	"loterias-api-golang/internal/model"
 	"loterias-api-golang/internal/repository"
 )
@@ -15,8 +17,13 @@ func NewResultadoService(repository *repository.ResultadoRepository) *ResultadoS
 	}
 }
 
-func (s *ResultadoService) FindByLoteria(loteria string) ([]model.Resultado, error) {
-	return s.repository.FindByLoteria(loteria)
+func (s *ResultadoService) FindByLoteria(loteria string, filtro model.FiltroConcursos, paginacao model.Paginacao) (*model.PaginaConcursos, error) {
+	return s.repository.FindByLoteria(loteria, filtro, paginacao)
+}
+
+// FindByData retorna os concursos de todas as loterias sorteados no dia.
+func (s *ResultadoService) FindByData(data time.Time) ([]model.Resultado, error) {
+	return s.repository.FindByData(data)
 }
 
 func (s *ResultadoService) FindByLoteriaAndConcurso(loteria string, concurso int) (*model.Resultado, error) {
@@ -27,6 +34,45 @@ func (s *ResultadoService) FindLatest(loteria string) (*model.Resultado, error)
 	return s.repository.FindLatest(loteria)
 }
 
+func (s *ResultadoService) PercorrerResultados(loteria string, filtro model.FiltroConcursos, fn func(*model.Resultado) error) error {
+	return s.repository.PercorrerResultados(loteria, filtro, fn)
+}
+
+// MigrarSorteios grava os sorteios separados nos resultados da Dupla Sena
+// que ainda estão apenas no formato plano. Retorna quantos foram migrados.
+func (s *ResultadoService) MigrarSorteios() (int, error) {
+	resultados, err := s.repository.FindSemSorteios(string(model.DuplaSena))
+	if err != nil {
+		return 0, err
+	}
+
+	var migrados []model.Resultado
+	for _, resultado := range resultados {
+		if len(resultado.Sorteios) > 0 {
+			migrados = append(migrados, resultado)
+		}
+	}
+
+	return len(migrados), s.repository.SaveAll(migrados)
+}
+
+// MigrarDatas grava as datas convertidas nos resultados antigos. Retorna
+// quantos resultados foram migrados.
+func (s *ResultadoService) MigrarDatas() (int64, error) {
+	return s.repository.MigrarDatas()
+}
+
+// CriarIndices cria os índices da coleção de resultados.
+func (s *ResultadoService) CriarIndices() error {
+	return s.repository.CriarIndices()
+}
+
+// MigrarMaiorPremio grava o maior prêmio pago nos resultados antigos, usado
+// no ranking de prêmios. Retorna quantos resultados foram migrados.
+func (s *ResultadoService) MigrarMaiorPremio() (int64, error) {
+	return s.repository.MigrarMaiorPremio()
+}
+
 func (s *ResultadoService) Save(resultado *model.Resultado) error {
 	return s.repository.Save(resultado)
 }